@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jfrog/gofrog/version"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferinstall"
@@ -27,6 +28,7 @@ import (
 
 	transferconfigmergecore "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferconfigmerge"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/usersmanagement"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	containerutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
@@ -35,22 +37,43 @@ import (
 	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/accesstoken"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/builddiff"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/buildsbom"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/buildshow"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/buildsign"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/diff"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/filesync"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/provenance"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/repoapply"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/specvalidate"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/templateexport"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/userssync"
 	"github.com/jfrog/jfrog-cli/buildtools"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokencreate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokeninspect"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokenlist"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokenrefresh"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokenrevoke"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildadddependencies"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildaddgit"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildappend"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildclean"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildcollectenv"
+	builddiffdocs "github.com/jfrog/jfrog-cli/docs/artifactory/builddiff"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddiscard"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddockercreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpublish"
+	buildsbomdocs "github.com/jfrog/jfrog-cli/docs/artifactory/buildsbom"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildscan"
+	buildshowdocs "github.com/jfrog/jfrog-cli/docs/artifactory/buildshow"
+	buildverifydocs "github.com/jfrog/jfrog-cli/docs/artifactory/buildverify"
 	copydocs "github.com/jfrog/jfrog-cli/docs/artifactory/copy"
 	curldocs "github.com/jfrog/jfrog-cli/docs/artifactory/curl"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/delete"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/deleteprops"
+	diffdocs "github.com/jfrog/jfrog-cli/docs/artifactory/diff"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpull"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dockerpush"
@@ -79,6 +102,7 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/ocstartbuild"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargetcreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargetdelete"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargetexport"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargettemplate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/permissiontargetupdate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/ping"
@@ -88,26 +112,38 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/podmanpush"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/replicationcreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/replicationdelete"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/replicationexport"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/replicationtemplate"
+	repoapplydocs "github.com/jfrog/jfrog-cli/docs/artifactory/repoapply"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repocreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repodelete"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/repoexport"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repotemplate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/repoupdate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/search"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/setprops"
+	specvalidatedocs "github.com/jfrog/jfrog-cli/docs/artifactory/specvalidate"
+	syncdocs "github.com/jfrog/jfrog-cli/docs/artifactory/sync"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/transferconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/transferconfigmerge"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/transferfiles"
+	transferjournaldocs "github.com/jfrog/jfrog-cli/docs/artifactory/transferjournal"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/transfersettings"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/upload"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/usercreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/userscreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/usersdelete"
+	userssyncdocs "github.com/jfrog/jfrog-cli/docs/artifactory/userssync"
 	yarndocs "github.com/jfrog/jfrog-cli/docs/artifactory/yarn"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/yarnconfig"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/searchoutput"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
+	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-cli/utils/throttling"
+	"github.com/jfrog/jfrog-cli/utils/transferjournal"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
@@ -184,6 +220,42 @@ func GetCommands() []cli.Command {
 				return deleteCmd(c)
 			},
 		},
+		{
+			Name:         "diff",
+			Flags:        cliutils.GetCommandFlags(cliutils.Diff),
+			Usage:        diffdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt diff", diffdocs.GetDescription(), diffdocs.Usage),
+			UsageText:    diffdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return diffCmd(c)
+			},
+		},
+		{
+			Name:         "spec-validate",
+			Flags:        cliutils.GetCommandFlags(cliutils.SpecValidate),
+			Usage:        specvalidatedocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt spec-validate", specvalidatedocs.GetDescription(), specvalidatedocs.Usage),
+			UsageText:    specvalidatedocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return specValidateCmd(c)
+			},
+		},
+		{
+			Name:         "sync",
+			Flags:        cliutils.GetCommandFlags(cliutils.RtSync),
+			Usage:        syncdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt sync", syncdocs.GetDescription(), syncdocs.Usage),
+			UsageText:    syncdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return syncCmd(c)
+			},
+		},
 		{
 			Name:         "search",
 			Flags:        cliutils.GetCommandFlags(cliutils.Search),
@@ -339,6 +411,58 @@ func GetCommands() []cli.Command {
 				return buildDiscardCmd(c)
 			},
 		},
+		{
+			Name:         "build-sbom",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildSbom),
+			Aliases:      []string{"bsb"},
+			Usage:        buildsbomdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-sbom", buildsbomdocs.GetDescription(), buildsbomdocs.Usage),
+			UsageText:    buildsbomdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildSbomCmd(c)
+			},
+		},
+		{
+			Name:         "build-diff",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildDiff),
+			Aliases:      []string{"bdf"},
+			Usage:        builddiffdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-diff", builddiffdocs.GetDescription(), builddiffdocs.Usage),
+			UsageText:    builddiffdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildDiffCmd(c)
+			},
+		},
+		{
+			Name:         "build-show",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildShow),
+			Aliases:      []string{"bsh"},
+			Usage:        buildshowdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-show", buildshowdocs.GetDescription(), buildshowdocs.Usage),
+			UsageText:    buildshowdocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildShowCmd(c)
+			},
+		},
+		{
+			Name:         "build-verify",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildVerify),
+			Aliases:      []string{"bvf"},
+			Usage:        buildverifydocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt build-verify", buildverifydocs.GetDescription(), buildverifydocs.Usage),
+			UsageText:    buildverifydocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildVerifyCmd(c)
+			},
+		},
 		{
 			Name:         "git-lfs-clean",
 			Flags:        cliutils.GetCommandFlags(cliutils.GitLfsClean),
@@ -759,6 +883,30 @@ func GetCommands() []cli.Command {
 				return repoUpdateCmd(c)
 			},
 		},
+		{
+			Name:         "repo-apply",
+			Flags:        cliutils.GetCommandFlags(cliutils.RepoApply),
+			Usage:        repoapplydocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt repo-apply", repoapplydocs.GetDescription(), repoapplydocs.Usage),
+			UsageText:    repoapplydocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return repoApplyCmd(c)
+			},
+		},
+		{
+			Name:         "repo-export",
+			Flags:        cliutils.GetCommandFlags(cliutils.TemplateExport),
+			Usage:        repoexport.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt repo-export", repoexport.GetDescription(), repoexport.Usage),
+			UsageText:    repoexport.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return templateExportCmd(c, templateexport.Repositories)
+			},
+		},
 		{
 			Name:         "repo-delete",
 			Aliases:      []string{"rdel"},
@@ -798,6 +946,18 @@ func GetCommands() []cli.Command {
 				return replicationCreateCmd(c)
 			},
 		},
+		{
+			Name:         "replication-export",
+			Flags:        cliutils.GetCommandFlags(cliutils.TemplateExport),
+			Usage:        replicationexport.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt replication-export", replicationexport.GetDescription(), replicationexport.Usage),
+			UsageText:    replicationexport.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return templateExportCmd(c, templateexport.Replications)
+			},
+		},
 		{
 			Name:         "replication-delete",
 			Aliases:      []string{"rpldel"},
@@ -849,6 +1009,18 @@ func GetCommands() []cli.Command {
 				return permissionTargetUpdateCmd(c)
 			},
 		},
+		{
+			Name:         "permission-target-export",
+			Flags:        cliutils.GetCommandFlags(cliutils.TemplateExport),
+			Usage:        permissiontargetexport.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt permission-target-export", permissiontargetexport.GetDescription(), permissiontargetexport.Usage),
+			UsageText:    permissiontargetexport.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return templateExportCmd(c, templateexport.PermissionTargets)
+			},
+		},
 		{
 			Name:         "permission-target-delete",
 			Aliases:      []string{"ptdel"},
@@ -898,6 +1070,18 @@ func GetCommands() []cli.Command {
 				return usersDeleteCmd(c)
 			},
 		},
+		{
+			Name:         "users-sync",
+			Aliases:      []string{"us"},
+			Flags:        cliutils.GetCommandFlags(cliutils.UsersSync),
+			Usage:        userssyncdocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt us", userssyncdocs.GetDescription(), userssyncdocs.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return usersSyncCmd(c)
+			},
+		},
 		{
 			Name:         "group-create",
 			Aliases:      []string{"gc"},
@@ -950,6 +1134,58 @@ func GetCommands() []cli.Command {
 				return accessTokenCreateCmd(c)
 			},
 		},
+		{
+			Name:         "access-token-list",
+			Aliases:      []string{"atl"},
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenList),
+			Usage:        accesstokenlist.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt atl", accesstokenlist.GetDescription(), accesstokenlist.Usage),
+			UsageText:    accesstokenlist.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return accessTokenListCmd(c)
+			},
+		},
+		{
+			Name:         "access-token-revoke",
+			Aliases:      []string{"atrv"},
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenRevoke),
+			Usage:        accesstokenrevoke.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt atrv", accesstokenrevoke.GetDescription(), accesstokenrevoke.Usage),
+			UsageText:    accesstokenrevoke.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return accessTokenRevokeCmd(c)
+			},
+		},
+		{
+			Name:         "access-token-refresh",
+			Aliases:      []string{"atr"},
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenRefresh),
+			Usage:        accesstokenrefresh.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt atr", accesstokenrefresh.GetDescription(), accesstokenrefresh.Usage),
+			UsageText:    accesstokenrefresh.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return accessTokenRefreshCmd(c)
+			},
+		},
+		{
+			Name:         "access-token-inspect",
+			Aliases:      []string{"ati"},
+			Flags:        cliutils.GetCommandFlags(cliutils.AccessTokenInspect),
+			Usage:        accesstokeninspect.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt ati", accesstokeninspect.GetDescription(), accesstokeninspect.Usage),
+			UsageText:    accesstokeninspect.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return accessTokenInspectCmd(c)
+			},
+		},
 		{
 			Name:         "transfer-settings",
 			Usage:        transfersettings.GetDescription(),
@@ -1008,6 +1244,18 @@ func GetCommands() []cli.Command {
 				return dataTransferPluginInstallCmd(c)
 			},
 		},
+		{
+			Name:         "transfer-journal",
+			Flags:        cliutils.GetCommandFlags(cliutils.TransferJournal),
+			Usage:        transferjournaldocs.GetDescription(),
+			HelpName:     corecommon.CreateUsage("rt transfer-journal", transferjournaldocs.GetDescription(), transferjournaldocs.Usage),
+			UsageText:    transferjournaldocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc("list", "clean"),
+			Action: func(c *cli.Context) error {
+				return transferJournalCmd(c)
+			},
+		},
 	})
 }
 
@@ -1134,6 +1382,13 @@ func containerPushCmd(c *cli.Context, containerManagerType containerutils.Contai
 	if err != nil {
 		return
 	}
+	limits, err := cliutils.GetThrottlingLimits(c)
+	if err != nil {
+		return
+	}
+	if err = throttling.PushLayersThroughProxy(imageTag, containerManagerType, artDetails, limits); err != nil {
+		return
+	}
 	err = commands.Exec(dockerPushCommand)
 	result := dockerPushCommand.Result()
 
@@ -1319,22 +1574,53 @@ func downloadCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	limits, err := cliutils.GetThrottlingLimits(c)
+	if err != nil {
+		return err
+	}
+	journal, err := loadTransferJournal(c, "rt download", serverDetails, downloadSpec)
+	if err != nil {
+		return err
+	}
+	if journal != nil && len(journal.Files) > 0 {
+		// Files which already exist locally with the same checksum are skipped by the download.
+		// Split downloads which were interrupted continue from their downloaded parts.
+		log.Info("Resuming the download.", len(journal.Files), "files which were downloaded by previous runs are verified by their checksums and skipped.")
+	}
 	downloadCommand := generic.NewDownloadCommand()
-	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(c.Bool("detailed-summary")).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	detailedSummary := c.Bool("detailed-summary")
+	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetServerDetails(serverDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(detailedSummary || c.IsSet("summary-file") || journal != nil).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 
 	if downloadCommand.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some files in your local file system. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
+	var commandWithProgress progressbar.CommandWithProgress = downloadCommand
+	if journal != nil {
+		commandWithProgress = transferjournal.NewResumableDownloadCommand(downloadCommand, journal).SetRetryWaitMilliSecs(retryWaitTime)
+	}
+	recorder, err := cliutils.StartTransferRecorderIfNeeded(c, summary.DownloadTransfer, serverDetails)
+	if err != nil {
+		return err
+	}
 	// This error is being checked later on because we need to generate summary report before return.
-	err = progressbar.ExecWithProgress(downloadCommand)
+	startTime := time.Now()
+	err = progressbar.ExecWithThrottledProgress(commandWithProgress, limits)
 	result := downloadCommand.Result()
+	err = cliutils.StopTransferRecorder(recorder, result, err)
 	defer cliutils.CleanupResult(result, &err)
+	err = updateTransferJournal(journal, result, err)
+	err = cliutils.WriteSummaryFile(c, "rt download", startTime, retries, result, recorder, false, err)
 	basicSummary, err := cliutils.CreateSummaryReportString(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 	if err != nil {
 		return err
 	}
-	err = cliutils.PrintDetailedSummaryReport(basicSummary, result.Reader(), false, err)
+	// The result's reader may exist only for the summary file. In that case, the detailed summary shouldn't be printed.
+	reader := result.Reader()
+	if !detailedSummary {
+		reader = nil
+	}
+	err = cliutils.PrintDetailedSummaryReport(basicSummary, reader, false, err)
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c))
 }
 
@@ -1376,26 +1662,130 @@ func uploadCmd(c *cli.Context) (err error) {
 	if err != nil {
 		return
 	}
+	limits, err := cliutils.GetThrottlingLimits(c)
+	if err != nil {
+		return
+	}
 	uploadCmd := generic.NewUploadCommand()
 	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
 	if err != nil {
 		return
 	}
+	journal, err := loadTransferJournal(c, "rt upload", rtDetails, uploadSpec)
+	if err != nil {
+		return
+	}
+	if journal != nil {
+		if err = journal.SkipVerifiedUploads(uploadSpec); err != nil {
+			return
+		}
+	}
 	printDeploymentView, detailedSummary := log.IsStdErrTerminal(), c.Bool("detailed-summary")
-	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(detailedSummary || printDeploymentView).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetServerDetails(rtDetails).SetDryRun(c.Bool("dry-run")).SetSyncDeletesPath(c.String("sync-deletes")).SetQuiet(cliutils.GetQuietValue(c)).SetDetailedSummary(detailedSummary || printDeploymentView || c.IsSet("summary-file") || journal != nil).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 
 	if uploadCmd.ShouldPrompt() && !coreutils.AskYesNo("Sync-deletes may delete some artifacts in Artifactory. Are you sure you want to continue?\n"+
 		"You can avoid this confirmation message by adding --quiet to the command.", false) {
 		return nil
 	}
+	recorder, err := cliutils.StartTransferRecorderIfNeeded(c, summary.UploadTransfer, rtDetails)
+	if err != nil {
+		return
+	}
 	// This error is being checked latter on because we need to generate summary report before return.
-	err = progressbar.ExecWithProgress(uploadCmd)
+	startTime := time.Now()
+	err = progressbar.ExecWithThrottledProgress(uploadCmd, limits)
 	result := uploadCmd.Result()
+	err = cliutils.StopTransferRecorder(recorder, result, err)
 	defer cliutils.CleanupResult(result, &err)
+	err = updateTransferJournal(journal, result, err)
+	err = cliutils.WriteSummaryFile(c, "rt upload", startTime, retries, result, recorder, true, err)
 	err = cliutils.PrintCommandSummary(uploadCmd.Result(), detailedSummary, printDeploymentView, cliutils.IsFailNoOp(c), err)
 	return
 }
 
+// Loads the transfer journal of the command, if the --resume option was provided. Otherwise, returns nil.
+func loadTransferJournal(c *cli.Context, commandName string, serverDetails *coreConfig.ServerDetails, transferSpec *spec.SpecFiles) (*transferjournal.Journal, error) {
+	if !c.Bool("resume") {
+		return nil, nil
+	}
+	if c.IsSet("sync-deletes") {
+		return nil, errorutils.CheckErrorf("the --resume option cannot be used together with the --sync-deletes option")
+	}
+	if c.Bool("skip-checksum") {
+		return nil, errorutils.CheckErrorf("the --resume option cannot be used together with the --skip-checksum option, since the transferred files are verified by their checksums")
+	}
+	if c.Bool("dry-run") {
+		return nil, nil
+	}
+	for _, file := range transferSpec.Files {
+		if file.Archive != "" {
+			return nil, errorutils.CheckErrorf("the --resume option cannot be used together with the archive option")
+		}
+	}
+	return transferjournal.Load(commandName, serverDetails.ArtifactoryUrl, transferSpec)
+}
+
+// Updates the transfer journal with the result of the command, if a journal is used.
+func updateTransferJournal(journal *transferjournal.Journal, result *commandUtils.Result, originalErr error) error {
+	if journal == nil {
+		return originalErr
+	}
+	err := journal.Update(result, originalErr == nil && result.FailCount() == 0)
+	if originalErr != nil {
+		if err != nil {
+			log.Error(err)
+		}
+		return originalErr
+	}
+	return err
+}
+
+func transferJournalCmd(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	switch c.Args().Get(0) {
+	case "list":
+		if c.NArg() != 1 {
+			return cliutils.WrongNumberOfArgumentsHandler(c)
+		}
+		return printTransferJournals()
+	case "clean":
+		if c.NArg() > 2 {
+			return cliutils.WrongNumberOfArgumentsHandler(c)
+		}
+		if c.NArg() == 2 {
+			return transferjournal.Clean(c.Args().Get(1))
+		}
+		if !cliutils.GetQuietValue(c) && !coreutils.AskYesNo("Are you sure you want to remove all the transfer journals?", false) {
+			return nil
+		}
+		return transferjournal.CleanAll()
+	default:
+		return cliutils.PrintHelpAndReturnError(fmt.Sprintf("Unknown transfer-journal command '%s'.", c.Args().Get(0)), c)
+	}
+}
+
+type transferJournalRow struct {
+	Id      string `col-name:"Journal ID"`
+	Command string `col-name:"Command"`
+	Created string `col-name:"Created"`
+	Updated string `col-name:"Updated"`
+	Files   string `col-name:"Transferred Files"`
+}
+
+func printTransferJournals() error {
+	journals, err := transferjournal.List()
+	if err != nil {
+		return err
+	}
+	rows := []transferJournalRow{}
+	for _, journal := range journals {
+		rows = append(rows, transferJournalRow{Id: journal.Id, Command: journal.Command, Created: journal.Created, Updated: journal.Updated, Files: strconv.Itoa(len(journal.Files))})
+	}
+	return coreutils.PrintTable(rows, "Transfer Journals", "No transfer journals were found", false)
+}
+
 func prepareCopyMoveCommand(c *cli.Context) (*spec.SpecFiles, error) {
 	if c.NArg() > 0 && c.IsSet("spec") {
 		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -1444,8 +1834,15 @@ func moveCmd(c *cli.Context) error {
 		return err
 	}
 	moveCmd.SetThreads(threads).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(moveSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	recorder, err := cliutils.StartTransferRecorderIfNeeded(c, summary.MoveTransfer, rtDetails)
+	if err != nil {
+		return err
+	}
+	startTime := time.Now()
 	err = commands.Exec(moveCmd)
 	result := moveCmd.Result()
+	err = cliutils.StopTransferRecorder(recorder, result, err)
+	err = cliutils.WriteSummaryFile(c, "rt move", startTime, retries, result, recorder, false, err)
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 }
 
@@ -1473,8 +1870,15 @@ func copyCmd(c *cli.Context) error {
 		return err
 	}
 	copyCommand.SetThreads(threads).SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	recorder, err := cliutils.StartTransferRecorderIfNeeded(c, summary.CopyTransfer, rtDetails)
+	if err != nil {
+		return err
+	}
+	startTime := time.Now()
 	err = commands.Exec(copyCommand)
 	result := copyCommand.Result()
+	err = cliutils.StopTransferRecorder(recorder, result, err)
+	err = cliutils.WriteSummaryFile(c, "rt copy", startTime, retries, result, recorder, false, err)
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 }
 
@@ -1534,11 +1938,110 @@ func deleteCmd(c *cli.Context) error {
 		return err
 	}
 	deleteCommand.SetThreads(threads).SetQuiet(cliutils.GetQuietValue(c)).SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetSpec(deleteSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	recorder, err := cliutils.StartTransferRecorderIfNeeded(c, summary.DeleteTransfer, rtDetails)
+	if err != nil {
+		return err
+	}
+	startTime := time.Now()
 	err = commands.Exec(deleteCommand)
 	result := deleteCommand.Result()
+	err = cliutils.StopTransferRecorder(recorder, result, err)
+	err = cliutils.WriteSummaryFile(c, "rt delete", startTime, retries, result, recorder, false, err)
 	return printBriefSummaryAndGetError(result.SuccessCount(), result.FailCount(), cliutils.IsFailNoOp(c), err)
 }
 
+func diffCmd(c *cli.Context) error {
+	source := diff.Side{Build: c.String("source-build"), Project: getProject(c)}
+	target := diff.Side{Build: c.String("target-build"), Project: getProject(c)}
+	switch {
+	case c.NArg() == 2:
+		source.Pattern, target.Pattern = c.Args().Get(0), c.Args().Get(1)
+	case c.NArg() != 0 || source.Build == "" || target.Build == "":
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format := c.String("format")
+	if err := diff.ValidateFormat(format); err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	var ignoreProps []string
+	if c.IsSet("ignore-props") {
+		ignoreProps = strings.Split(c.String("ignore-props"), ",")
+	}
+	diffCommand := diff.NewDiffCommand()
+	diffCommand.SetSource(source).SetTarget(target).SetIgnoreProps(ignoreProps).
+		SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
+	if err = commands.Exec(diffCommand); err != nil {
+		return err
+	}
+	if err = diff.PrintDifferences(diffCommand.Differences(), format); err != nil {
+		return err
+	}
+	if c.Bool("fail-on-diff") && len(diffCommand.Differences()) > 0 {
+		return errorutils.CheckErrorf("found %d differences between the source and the target", len(diffCommand.Differences()))
+	}
+	return nil
+}
+
+func specValidateCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	specValidateCommand := specvalidate.NewSpecValidateCommand()
+	specValidateCommand.SetSpecPath(c.Args().Get(0)).SetSpecVars(coreutils.SpecVarsStringToMap(c.String("spec-vars"))).SetCommand(c.String("for"))
+	return commands.Exec(specValidateCommand)
+}
+
+func syncCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	direction, err := filesync.GetDirection(c.String("direction"))
+	if err != nil {
+		return err
+	}
+	conflictPolicy, err := filesync.GetConflictPolicy(c.String("conflict"))
+	if err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	uploadConfiguration, err := createUploadConfiguration(c)
+	if err != nil {
+		return err
+	}
+	downloadConfiguration, err := createDownloadConfiguration(c)
+	if err != nil {
+		return err
+	}
+	retries, err := getRetries(c)
+	if err != nil {
+		return err
+	}
+	retryWaitTime, err := getRetryWaitTime(c)
+	if err != nil {
+		return err
+	}
+	syncCommand := filesync.NewSyncCommand()
+	syncCommand.SetLocalDir(c.Args().Get(0)).SetRepoPath(c.Args().Get(1)).SetDirection(direction).SetConflictPolicy(conflictPolicy).
+		SetDryRun(c.Bool("dry-run")).SetQuiet(cliutils.GetQuietValue(c)).SetServerDetails(rtDetails).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime).
+		SetUploadConfiguration(uploadConfiguration).SetDownloadConfiguration(downloadConfiguration)
+	return commands.Exec(syncCommand)
+}
+
 func prepareSearchCommand(c *cli.Context) (*spec.SpecFiles, error) {
 	if c.NArg() > 0 && c.IsSet("spec") {
 		return nil, cliutils.PrintHelpAndReturnError("No arguments should be sent when the spec option is used.", c)
@@ -1581,6 +2084,14 @@ func searchCmd(c *cli.Context) (err error) {
 	if err != nil {
 		return
 	}
+	format, err := searchoutput.GetFormat(c.String("format"))
+	if err != nil {
+		return
+	}
+	fields, err := searchoutput.ParseFields(c.String("fields"))
+	if err != nil {
+		return
+	}
 	searchCmd := generic.NewSearchCommand()
 	searchCmd.SetServerDetails(artDetails).SetSpec(searchSpec).SetRetries(retries).SetRetryWaitMilliSecs(retryWaitTime)
 	err = commands.Exec(searchCmd)
@@ -1603,7 +2114,7 @@ func searchCmd(c *cli.Context) (err error) {
 		return err
 	}
 	if !c.Bool("count") {
-		return utils.PrintSearchResults(reader)
+		return searchoutput.PrintSearchResults(reader, format, fields)
 	}
 	log.Output(length)
 	return nil
@@ -1708,6 +2219,20 @@ func buildPublishCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	// The provenance is added to the build info before it is signed, so that the signature covers it.
+	if c.Bool("provenance") {
+		provenanceCmd := provenance.NewBuildProvenanceCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).
+			SetRepository(c.String("provenance-repo")).SetSignKey(c.String("sign-key")).SetDryRun(c.Bool("dry-run"))
+		if err = commands.Exec(provenanceCmd); err != nil {
+			return err
+		}
+	}
+	// Signing saves the signature in the local build info, so it's skipped in a dry run, which doesn't modify the build.
+	if c.String("sign-key") != "" && !c.Bool("dry-run") {
+		if err = buildsign.SignLocalBuildInfo(buildConfiguration, c.String("sign-key")); err != nil {
+			return err
+		}
+	}
 	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(c.Bool("detailed-summary"))
 
 	err = commands.Exec(buildPublishCmd)
@@ -1864,6 +2389,15 @@ func buildPromoteCmd(c *cli.Context) error {
 	if err := buildConfiguration.ValidateBuildParams(); err != nil {
 		return err
 	}
+	if c.Bool("require-signature") {
+		if c.String("pub-key") == "" {
+			return cliutils.PrintHelpAndReturnError("The --pub-key option is mandatory when the --require-signature option is set.", c)
+		}
+		buildVerifyCmd := buildsign.NewBuildVerifyCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetPublicKeyPath(c.String("pub-key"))
+		if err = commands.Exec(buildVerifyCmd); err != nil {
+			return err
+		}
+	}
 	buildPromotionCmd := buildinfo.NewBuildPromotionCommand().SetDryRun(c.Bool("dry-run")).SetServerDetails(rtDetails).SetPromotionParams(configuration).SetBuildConfiguration(buildConfiguration)
 	return commands.Exec(buildPromotionCmd)
 }
@@ -1886,6 +2420,92 @@ func buildDiscardCmd(c *cli.Context) error {
 	return commands.Exec(buildDiscardCmd)
 }
 
+func buildSbomCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	format := c.String("format")
+	if err := buildsbom.ValidateFormat(format); err != nil {
+		return err
+	}
+	buildSbomCmd := buildsbom.NewBuildSbomCommand().SetBuildConfiguration(buildConfiguration).SetFormat(format).SetLocal(c.Bool("local")).SetOutputPath(c.String("out"))
+	// The local build-info doesn't require access to Artifactory.
+	if !c.Bool("local") {
+		rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+		if err != nil {
+			return err
+		}
+		buildSbomCmd.SetServerDetails(rtDetails)
+	}
+	return commands.Exec(buildSbomCmd)
+}
+
+func buildDiffCmd(c *cli.Context) error {
+	var source, target builddiff.Build
+	switch c.NArg() {
+	case 3:
+		source = builddiff.Build{Name: c.Args().Get(0), Number: c.Args().Get(1)}
+		target = builddiff.Build{Name: c.Args().Get(0), Number: c.Args().Get(2)}
+	case 4:
+		source = builddiff.Build{Name: c.Args().Get(0), Number: c.Args().Get(1)}
+		target = builddiff.Build{Name: c.Args().Get(2), Number: c.Args().Get(3)}
+	default:
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format := c.String("format")
+	if err := diff.ValidateFormat(format); err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildDiffCommand := builddiff.NewBuildDiffCommand().SetServerDetails(rtDetails).SetSource(source).SetTarget(target).SetProject(getProject(c))
+	if err = commands.Exec(buildDiffCommand); err != nil {
+		return err
+	}
+	return builddiff.PrintBuildDiff(buildDiffCommand.BuildDiff(), format)
+}
+
+func buildVerifyCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if c.String("pub-key") == "" {
+		return cliutils.PrintHelpAndReturnError("The --pub-key option is mandatory.", c)
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	buildVerifyCmd := buildsign.NewBuildVerifyCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetPublicKeyPath(c.String("pub-key"))
+	return commands.Exec(buildVerifyCmd)
+}
+
+func buildShowCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	buildConfiguration := cliutils.CreateBuildConfiguration(c)
+	if err := buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	format := c.String("format")
+	if err := buildshow.ValidateFormat(format); err != nil {
+		return err
+	}
+	buildShowCmd := buildshow.NewBuildShowCommand().SetBuildConfiguration(buildConfiguration).SetModule(c.String("module")).SetFormat(format).SetValidate(c.Bool("validate"))
+	return commands.Exec(buildShowCmd)
+}
+
 func gitLfsCleanCmd(c *cli.Context) error {
 	if c.NArg() > 1 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
@@ -1927,6 +2547,9 @@ func newRtCurlCommand(c *cli.Context) (*curl.RtCurlCommand, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = secretstore.ResolveSecrets(rtDetails); err != nil {
+		return nil, err
+	}
 	if rtDetails.ArtifactoryUrl == "" {
 		return nil, errorutils.CheckErrorf("No Artifactory servers configured. Use the 'jf c add' command to set the Artifactory server details.")
 	}
@@ -1951,7 +2574,7 @@ func pipDeprecatedInstallCmd(c *cli.Context) error {
 	}
 
 	// Set arg values.
-	rtDetails, err := pythonConfig.ServerDetails()
+	rtDetails, err := secretstore.GetRepositoryServerDetails(pythonConfig)
 	if err != nil {
 		return err
 	}
@@ -2004,6 +2627,37 @@ func repoUpdateCmd(c *cli.Context) error {
 	return commands.Exec(repoUpdateCmd)
 }
 
+func repoApplyCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+
+	repoApplyCmd := repoapply.NewRepoApplyCommand()
+	repoApplyCmd.SetTemplatesDir(c.Args().Get(0)).SetServerDetails(rtDetails).SetVars(c.String("vars")).
+		SetPrunePattern(c.String("prune")).SetApprove(c.Bool("approve"))
+	return commands.Exec(repoApplyCmd)
+}
+
+func templateExportCmd(c *cli.Context, entity templateexport.Entity) error {
+	if c.NArg() != 1 && c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+
+	templateExportCmd := templateexport.NewTemplateExportCommand(entity)
+	templateExportCmd.SetOutputDir(c.Args().Get(0)).SetPattern(c.Args().Get(1)).SetServerDetails(rtDetails).SetVars(c.String("vars"))
+	return commands.Exec(templateExportCmd)
+}
+
 func repoDeleteCmd(c *cli.Context) error {
 	if c.NArg() != 1 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
@@ -2215,6 +2869,23 @@ func usersDeleteCmd(c *cli.Context) error {
 	return commands.Exec(usersDeleteCmd)
 }
 
+func usersSyncCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if c.String("file") == "" {
+		return cliutils.PrintHelpAndReturnError("missing --file <users details file path>", c)
+	}
+	rtDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	usersSyncCmd := userssync.NewUsersSyncCommand().SetServerDetails(rtDetails).SetUsersFilePath(c.String("file")).
+		SetDeactivateMissing(c.Bool("deactivate-missing")).SetDryRun(c.Bool("dry-run")).SetQuiet(cliutils.GetQuietValue(c)).
+		SetReportFilePath(c.String("report-file"))
+	return commands.Exec(usersSyncCmd)
+}
+
 func parseCSVToUsersList(csvFilePath string) ([]services.User, error) {
 	var usersList []services.User
 	csvInput, err := os.ReadFile(csvFilePath)
@@ -2320,24 +2991,94 @@ func accessTokenCreateCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	// If requested, write the token to a dotenv file or to a server configuration, instead of printing it.
+	if output := createTokenOutput(c); output.IsSet() {
+		return output.WriteJson(serverDetails, resString)
+	}
 	log.Output(clientutils.IndentJson(resString))
 
 	return nil
 }
 
+func createTokenOutput(c *cli.Context) accesstoken.TokenOutput {
+	return accesstoken.TokenOutput{EnvFilePath: c.String("output-env"), ServerId: c.String("output-server-id")}
+}
+
+func accessTokenListCmd(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	accessTokenListCmd := accesstoken.NewAccessTokenListCommand().SetServerDetails(serverDetails).SetUsername(c.Args().Get(0))
+	return commands.Exec(accessTokenListCmd)
+}
+
+func accessTokenRevokeCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	if !cliutils.GetQuietValue(c) && !coreutils.AskYesNo("This command will revoke the access token. Are you sure you want to continue?\n"+
+		"You can avoid this confirmation message by adding --quiet to the command.", false) {
+		return nil
+	}
+	accessTokenRevokeCmd := accesstoken.NewAccessTokenRevokeCommand().SetServerDetails(serverDetails).SetIdOrToken(c.Args().Get(0))
+	return commands.Exec(accessTokenRevokeCmd)
+}
+
+func accessTokenRefreshCmd(c *cli.Context) error {
+	if c.NArg() < 1 || c.NArg() > 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+	if err != nil {
+		return err
+	}
+	accessTokenRefreshCmd := accesstoken.NewAccessTokenRefreshCommand().SetServerDetails(serverDetails).SetRefreshToken(c.Args().Get(0)).
+		SetAccessToken(c.Args().Get(1)).SetOutput(createTokenOutput(c))
+	return commands.Exec(accessTokenRefreshCmd)
+}
+
+func accessTokenInspectCmd(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	accessTokenInspectCmd := accesstoken.NewAccessTokenInspectCommand()
+	switch {
+	case c.NArg() == 1:
+		accessTokenInspectCmd.SetToken(c.Args().Get(0))
+	case c.String("access-token") != "":
+		accessTokenInspectCmd.SetToken(c.String("access-token"))
+	default:
+		// Inspect the access token of the configured server.
+		serverDetails, err := cliutils.CreateArtifactoryDetailsByFlags(c)
+		if err != nil {
+			return err
+		}
+		accessTokenInspectCmd.SetServerDetails(serverDetails)
+	}
+	return commands.Exec(accessTokenInspectCmd)
+}
+
 func transferConfigCmd(c *cli.Context) error {
 	if c.NArg() != 2 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
 
 	// Get source Artifactory server
-	sourceServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[0], false, true)
+	sourceServerDetails, err := secretstore.GetSpecificConfig(c.Args()[0], false, true)
 	if err != nil {
 		return err
 	}
 
 	// Get target artifactory server
-	targetServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[1], false, true)
+	targetServerDetails, err := secretstore.GetSpecificConfig(c.Args()[1], false, true)
 	if err != nil {
 		return err
 	}
@@ -2358,13 +3099,13 @@ func transferConfigMergeCmd(c *cli.Context) error {
 	}
 
 	// Get source Artifactory server
-	sourceServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[0], false, true)
+	sourceServerDetails, err := secretstore.GetSpecificConfig(c.Args()[0], false, true)
 	if err != nil {
 		return err
 	}
 
 	// Get target artifactory server
-	targetServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[1], false, true)
+	targetServerDetails, err := secretstore.GetSpecificConfig(c.Args()[1], false, true)
 	if err != nil {
 		return err
 	}
@@ -2387,7 +3128,7 @@ func dataTransferPluginInstallCmd(c *cli.Context) error {
 	} else if c.NArg() == 1 {
 		serverID = c.Args()[0]
 	}
-	serverDetails, err := coreConfig.GetSpecificConfig(serverID, true, true)
+	serverDetails, err := secretstore.GetSpecificConfig(serverID, true, true)
 	if err != nil {
 		return err
 	}
@@ -2413,7 +3154,7 @@ func dataTransferPluginInstallCmd(c *cli.Context) error {
 	return commands.Exec(installCmd)
 }
 
-func transferFilesCmd(c *cli.Context) error {
+func transferFilesCmd(c *cli.Context) (err error) {
 	if c.Bool(cliutils.Status) || c.Bool(cliutils.Stop) {
 		newTransferFilesCmd, err := transferfilescore.NewTransferFilesCommand(nil, nil)
 		if err != nil {
@@ -2428,17 +3169,34 @@ func transferFilesCmd(c *cli.Context) error {
 	}
 
 	// Get source Artifactory server
-	sourceServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[0], false, true)
+	sourceServerDetails, err := secretstore.GetSpecificConfig(c.Args()[0], false, true)
 	if err != nil {
 		return err
 	}
 
 	// Get target artifactory server
-	targetServerDetails, err := coreConfig.GetSpecificConfig(c.Args()[1], false, true)
+	targetServerDetails, err := secretstore.GetSpecificConfig(c.Args()[1], false, true)
 	if err != nil {
 		return err
 	}
 
+	limits, err := cliutils.GetThrottlingLimits(c)
+	if err != nil {
+		return err
+	}
+	if limits.IsSet() {
+		var proxy *throttling.Proxy
+		proxy, sourceServerDetails, err = throttling.StartTransferFilesProxy(sourceServerDetails, limits)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if e := proxy.Close(); err == nil {
+				err = e
+			}
+		}()
+	}
+
 	// Run transfer data command
 	newTransferFilesCmd, err := transferfilescore.NewTransferFilesCommand(sourceServerDetails, targetServerDetails)
 	if err != nil {
@@ -0,0 +1,220 @@
+package accesstoken
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Token IDs are UUIDs. Any other value is treated as the token itself.
+var tokenIdPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type TokenRow struct {
+	TokenId     string `col-name:"Token ID"`
+	Subject     string `col-name:"Subject"`
+	IssuedAt    string `col-name:"Issued At"`
+	ExpiresAt   string `col-name:"Expires At"`
+	Refreshable string `col-name:"Refreshable"`
+}
+
+// Converts the tokens to table rows, sorted by their issue time.
+// If a username is provided, only the tokens of the user are included.
+func ToTokenRows(tokens []services.Token, username string) []TokenRow {
+	rows := []TokenRow{}
+	sort.SliceStable(tokens, func(i, j int) bool { return tokens[i].IssuedAt < tokens[j].IssuedAt })
+	for _, token := range tokens {
+		if username != "" && !strings.HasSuffix(token.Subject, "/users/"+username) {
+			continue
+		}
+		row := TokenRow{TokenId: token.TokenId, Subject: token.Subject, ExpiresAt: "Never", Refreshable: strconv.FormatBool(token.Refreshable)}
+		if token.IssuedAt > 0 {
+			row.IssuedAt = time.Unix(int64(token.IssuedAt), 0).UTC().Format(time.RFC3339)
+		}
+		if token.Expiry > 0 {
+			row.ExpiresAt = time.Unix(int64(token.Expiry), 0).UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Returns the revocation parameters for a token ID or a token.
+func NewRevokeParams(idOrToken string) services.RevokeTokenParams {
+	params := services.NewRevokeTokenParams()
+	if tokenIdPattern.MatchString(idOrToken) {
+		params.TokenId = idOrToken
+	} else {
+		params.Token = idOrToken
+	}
+	return params
+}
+
+type AccessTokenListCommand struct {
+	serverDetails *config.ServerDetails
+	username      string
+	rows          []TokenRow
+}
+
+func NewAccessTokenListCommand() *AccessTokenListCommand {
+	return &AccessTokenListCommand{}
+}
+
+func (atlc *AccessTokenListCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenListCommand {
+	atlc.serverDetails = serverDetails
+	return atlc
+}
+
+func (atlc *AccessTokenListCommand) SetUsername(username string) *AccessTokenListCommand {
+	atlc.username = username
+	return atlc
+}
+
+func (atlc *AccessTokenListCommand) Rows() []TokenRow {
+	return atlc.rows
+}
+
+func (atlc *AccessTokenListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atlc.serverDetails, nil
+}
+
+func (atlc *AccessTokenListCommand) CommandName() string {
+	return "rt_access_token_list"
+}
+
+func (atlc *AccessTokenListCommand) Run() error {
+	servicesManager, err := rtUtils.CreateServiceManager(atlc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	tokens, err := servicesManager.GetTokens()
+	if err != nil {
+		return err
+	}
+	atlc.rows = ToTokenRows(tokens.Tokens, atlc.username)
+	return coreutils.PrintTable(atlc.rows, "Access Tokens", "No access tokens were found", false)
+}
+
+type AccessTokenRevokeCommand struct {
+	serverDetails *config.ServerDetails
+	idOrToken     string
+}
+
+func NewAccessTokenRevokeCommand() *AccessTokenRevokeCommand {
+	return &AccessTokenRevokeCommand{}
+}
+
+func (atrc *AccessTokenRevokeCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenRevokeCommand {
+	atrc.serverDetails = serverDetails
+	return atrc
+}
+
+func (atrc *AccessTokenRevokeCommand) SetIdOrToken(idOrToken string) *AccessTokenRevokeCommand {
+	atrc.idOrToken = idOrToken
+	return atrc
+}
+
+func (atrc *AccessTokenRevokeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atrc.serverDetails, nil
+}
+
+func (atrc *AccessTokenRevokeCommand) CommandName() string {
+	return "rt_access_token_revoke"
+}
+
+func (atrc *AccessTokenRevokeCommand) Run() error {
+	servicesManager, err := rtUtils.CreateServiceManager(atrc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	response, err := servicesManager.RevokeToken(NewRevokeParams(atrc.idOrToken))
+	if err != nil {
+		return err
+	}
+	log.Info(strings.TrimSpace(response))
+	return nil
+}
+
+type AccessTokenRefreshCommand struct {
+	serverDetails *config.ServerDetails
+	refreshToken  string
+	accessToken   string
+	output        TokenOutput
+	response      []byte
+}
+
+func NewAccessTokenRefreshCommand() *AccessTokenRefreshCommand {
+	return &AccessTokenRefreshCommand{}
+}
+
+func (atrc *AccessTokenRefreshCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenRefreshCommand {
+	atrc.serverDetails = serverDetails
+	return atrc
+}
+
+func (atrc *AccessTokenRefreshCommand) SetRefreshToken(refreshToken string) *AccessTokenRefreshCommand {
+	atrc.refreshToken = refreshToken
+	return atrc
+}
+
+// Sets the access token to refresh. If not set, the access token of the server details is refreshed.
+func (atrc *AccessTokenRefreshCommand) SetAccessToken(accessToken string) *AccessTokenRefreshCommand {
+	atrc.accessToken = accessToken
+	return atrc
+}
+
+func (atrc *AccessTokenRefreshCommand) SetOutput(output TokenOutput) *AccessTokenRefreshCommand {
+	atrc.output = output
+	return atrc
+}
+
+func (atrc *AccessTokenRefreshCommand) Response() []byte {
+	return atrc.response
+}
+
+func (atrc *AccessTokenRefreshCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atrc.serverDetails, nil
+}
+
+func (atrc *AccessTokenRefreshCommand) CommandName() string {
+	return "rt_access_token_refresh"
+}
+
+func (atrc *AccessTokenRefreshCommand) Run() error {
+	accessToken := atrc.accessToken
+	if accessToken == "" {
+		accessToken = atrc.serverDetails.AccessToken
+	}
+	if accessToken == "" {
+		return errorutils.CheckErrorf("no access token to refresh was provided, and the server isn't configured with an access token")
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(atrc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	params := services.NewArtifactoryRefreshTokenParams()
+	params.RefreshToken = atrc.refreshToken
+	params.AccessToken = accessToken
+	response, err := servicesManager.RefreshToken(params)
+	if err != nil {
+		return err
+	}
+	if atrc.output.IsSet() {
+		return atrc.output.Write(atrc.serverDetails, response)
+	}
+	if atrc.response, err = json.Marshal(response); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientutils.IndentJson(atrc.response))
+	return nil
+}
@@ -0,0 +1,34 @@
+package accesstoken
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToTokenRows(t *testing.T) {
+	tokens := []services.Token{
+		{TokenId: "2", Subject: "jfrt@01abc/users/bob", IssuedAt: 1700000100, Expiry: 1700003700},
+		{TokenId: "1", Subject: "jfrt@01abc/users/admin", IssuedAt: 1700000000, Refreshable: true},
+	}
+	rows := ToTokenRows(tokens, "")
+	if assert.Len(t, rows, 2) {
+		assert.Equal(t, TokenRow{TokenId: "1", Subject: "jfrt@01abc/users/admin", IssuedAt: "2023-11-14T22:13:20Z", ExpiresAt: "Never", Refreshable: "true"}, rows[0])
+		assert.Equal(t, "2023-11-14T23:15:00Z", rows[1].ExpiresAt)
+	}
+	rows = ToTokenRows(tokens, "bob")
+	if assert.Len(t, rows, 1) {
+		assert.Equal(t, "2", rows[0].TokenId)
+	}
+	assert.Empty(t, ToTokenRows(tokens, "carol"))
+}
+
+func TestNewRevokeParams(t *testing.T) {
+	params := NewRevokeParams("5c3b1b3e-1f3c-4d2a-9a57-3c6d2b9e0f11")
+	assert.Equal(t, "5c3b1b3e-1f3c-4d2a-9a57-3c6d2b9e0f11", params.TokenId)
+	assert.Empty(t, params.Token)
+	params = NewRevokeParams("eyJhbGciOiJSUzI1NiJ9.e30.c2ln")
+	assert.Equal(t, "eyJhbGciOiJSUzI1NiJ9.e30.c2ln", params.Token)
+	assert.Empty(t, params.TokenId)
+}
@@ -0,0 +1,155 @@
+package accesstoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The details of an access token, as decoded from its JWT claims.
+type TokenInfo struct {
+	TokenId   string    `json:"token_id,omitempty"`
+	Subject   string    `json:"subject,omitempty"`
+	Username  string    `json:"username,omitempty"`
+	Issuer    string    `json:"issuer,omitempty"`
+	Scopes    []string  `json:"scopes,omitempty"`
+	Audience  []string  `json:"audience,omitempty"`
+	IssuedAt  time.Time `json:"issued_at,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+type tokenClaims struct {
+	Subject   string      `json:"sub"`
+	Scope     string      `json:"scp"`
+	Issuer    string      `json:"iss"`
+	Audience  interface{} `json:"aud"`
+	IssuedAt  int64       `json:"iat"`
+	ExpiresAt int64       `json:"exp"`
+	JwtId     string      `json:"jti"`
+}
+
+// Decodes the claims of an access token locally. The signature of the token isn't verified.
+func ParseToken(token string) (*TokenInfo, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, errorutils.CheckErrorf("the provided access token is not a JWT. Reference tokens can't be decoded locally")
+	}
+	// JWTs are encoded with the URL-safe base64 alphabet, without padding.
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to decode the access token payload: %s", err.Error())
+	}
+	claims := new(tokenClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the access token payload: %s", err.Error())
+	}
+	info := &TokenInfo{TokenId: claims.JwtId, Subject: claims.Subject, Issuer: claims.Issuer, Scopes: strings.Fields(claims.Scope)}
+	// The subject is in the form of <service id>/users/<username>.
+	if index := strings.LastIndex(claims.Subject, "/users/"); index >= 0 {
+		info.Username = claims.Subject[index+len("/users/"):]
+	}
+	switch audience := claims.Audience.(type) {
+	case string:
+		info.Audience = strings.Fields(audience)
+	case []interface{}:
+		for _, value := range audience {
+			if str, ok := value.(string); ok {
+				info.Audience = append(info.Audience, str)
+			}
+		}
+	}
+	if claims.IssuedAt > 0 {
+		info.IssuedAt = time.Unix(claims.IssuedAt, 0).UTC()
+	}
+	if claims.ExpiresAt > 0 {
+		info.ExpiresAt = time.Unix(claims.ExpiresAt, 0).UTC()
+	}
+	return info, nil
+}
+
+// Returns the token details as aligned lines. The status is computed relative to now.
+func FormatTokenInfo(info *TokenInfo, now time.Time) string {
+	status := "Valid (never expires)"
+	expiresAt := "Never"
+	if !info.ExpiresAt.IsZero() {
+		expiresAt = info.ExpiresAt.Format(time.RFC3339)
+		if left := info.ExpiresAt.Sub(now); left > 0 {
+			status = "Valid (expires in " + left.Truncate(time.Second).String() + ")"
+		} else {
+			status = "Expired"
+		}
+	}
+	issuedAt := ""
+	if !info.IssuedAt.IsZero() {
+		issuedAt = info.IssuedAt.Format(time.RFC3339)
+	}
+	fields := [][2]string{
+		{"Token ID", info.TokenId},
+		{"Subject", info.Subject},
+		{"Username", info.Username},
+		{"Issuer", info.Issuer},
+		{"Scopes", strings.Join(info.Scopes, " ")},
+		{"Audience", strings.Join(info.Audience, " ")},
+		{"Issued at", issuedAt},
+		{"Expires at", expiresAt},
+		{"Status", status},
+	}
+	var lines []string
+	for _, field := range fields {
+		lines = append(lines, fmt.Sprintf("%-11s %s", field[0]+":", field[1]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+type AccessTokenInspectCommand struct {
+	serverDetails *config.ServerDetails
+	token         string
+	info          *TokenInfo
+}
+
+func NewAccessTokenInspectCommand() *AccessTokenInspectCommand {
+	return &AccessTokenInspectCommand{}
+}
+
+func (atic *AccessTokenInspectCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenInspectCommand {
+	atic.serverDetails = serverDetails
+	return atic
+}
+
+func (atic *AccessTokenInspectCommand) SetToken(token string) *AccessTokenInspectCommand {
+	atic.token = token
+	return atic
+}
+
+func (atic *AccessTokenInspectCommand) TokenInfo() *TokenInfo {
+	return atic.info
+}
+
+func (atic *AccessTokenInspectCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atic.serverDetails, nil
+}
+
+func (atic *AccessTokenInspectCommand) CommandName() string {
+	return "rt_access_token_inspect"
+}
+
+func (atic *AccessTokenInspectCommand) Run() (err error) {
+	token := atic.token
+	if token == "" && atic.serverDetails != nil {
+		token = atic.serverDetails.AccessToken
+	}
+	if token == "" {
+		return errorutils.CheckErrorf("no access token to inspect was provided, and the server isn't configured with an access token")
+	}
+	if atic.info, err = ParseToken(token); err != nil {
+		return err
+	}
+	log.Output(FormatTokenInfo(atic.info, time.Now()))
+	return nil
+}
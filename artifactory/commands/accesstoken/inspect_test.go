@@ -0,0 +1,49 @@
+package accesstoken
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createToken(payload string) string {
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2lnbmF0dXJl"
+}
+
+func TestParseToken(t *testing.T) {
+	token := createToken(`{"sub":"jfrt@01abc/users/admin","scp":"applied-permissions/user api:*","aud":["jfrt@*","jfxr@*"],"iss":"jfrt@01abc","exp":1700003600,"iat":1700000000,"jti":"5c3b1b3e-1f3c-4d2a-9a57-3c6d2b9e0f11"}`)
+	info, err := ParseToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "5c3b1b3e-1f3c-4d2a-9a57-3c6d2b9e0f11", info.TokenId)
+	assert.Equal(t, "admin", info.Username)
+	assert.Equal(t, "jfrt@01abc", info.Issuer)
+	assert.Equal(t, []string{"applied-permissions/user", "api:*"}, info.Scopes)
+	assert.Equal(t, []string{"jfrt@*", "jfxr@*"}, info.Audience)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), info.IssuedAt)
+	assert.Equal(t, time.Unix(1700003600, 0).UTC(), info.ExpiresAt)
+
+	// The audience may also be a space-separated string.
+	info, err = ParseToken(createToken(`{"sub":"jfrt@01abc/users/bob","aud":"jfrt@* jfxr@*"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"jfrt@*", "jfxr@*"}, info.Audience)
+	assert.True(t, info.ExpiresAt.IsZero())
+
+	_, err = ParseToken("cmVmdGtuOjAxOjE3MDAwMDAwMDA6YWJjZGVm")
+	assert.Error(t, err)
+	_, err = ParseToken("a.!!!.c")
+	assert.Error(t, err)
+}
+
+func TestFormatTokenInfo(t *testing.T) {
+	info := &TokenInfo{TokenId: "id", Subject: "jfrt@01abc/users/admin", Username: "admin", Scopes: []string{"api:*"},
+		IssuedAt: time.Unix(1700000000, 0).UTC(), ExpiresAt: time.Unix(1700003600, 0).UTC()}
+	output := FormatTokenInfo(info, time.Unix(1700000000, 0))
+	assert.Contains(t, output, "Username:   admin")
+	assert.Contains(t, output, "Expires at: 2023-11-14T23:13:20Z")
+	assert.Contains(t, output, "Status:     Valid (expires in 1h0m0s)")
+	assert.Contains(t, FormatTokenInfo(info, time.Unix(1700003601, 0)), "Status:     Expired")
+	info.ExpiresAt = time.Time{}
+	assert.Contains(t, FormatTokenInfo(info, time.Now()), "Expires at: Never")
+}
@@ -0,0 +1,116 @@
+package accesstoken
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	coreCommonCommands "github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The variables written to the dotenv file.
+const (
+	UrlEnvVar          = "JF_URL"
+	AccessTokenEnvVar  = "JF_ACCESS_TOKEN"
+	RefreshTokenEnvVar = "JF_REFRESH_TOKEN"
+)
+
+// Determines where a created or refreshed token is written to, instead of the standard output.
+type TokenOutput struct {
+	EnvFilePath string
+	ServerId    string
+}
+
+func (to *TokenOutput) IsSet() bool {
+	return to.EnvFilePath != "" || to.ServerId != ""
+}
+
+// Writes the token to the dotenv file and to the server configuration, if requested.
+// serverDetails are the details of the server which issued the token.
+func (to *TokenOutput) Write(serverDetails *config.ServerDetails, response auth.CreateTokenResponseData) error {
+	if response.AccessToken == "" {
+		return errorutils.CheckErrorf("the response doesn't include an access token")
+	}
+	if to.EnvFilePath != "" {
+		values := map[string]string{AccessTokenEnvVar: response.AccessToken}
+		if serverDetails.Url != "" {
+			values[UrlEnvVar] = serverDetails.Url
+		}
+		if response.RefreshToken != "" {
+			values[RefreshTokenEnvVar] = response.RefreshToken
+		}
+		if err := UpdateEnvFile(to.EnvFilePath, values); err != nil {
+			return err
+		}
+		log.Info("The access token was written to " + to.EnvFilePath)
+	}
+	if to.ServerId != "" {
+		if err := addServerConfig(to.ServerId, serverDetails, response.AccessToken); err != nil {
+			return err
+		}
+		log.Info("The access token was saved in the '" + to.ServerId + "' server configuration.")
+	}
+	return nil
+}
+
+// Same as Write, for a token creation response in its JSON form.
+func (to *TokenOutput) WriteJson(serverDetails *config.ServerDetails, response []byte) error {
+	var tokenResponse auth.CreateTokenResponseData
+	if err := json.Unmarshal(response, &tokenResponse); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return to.Write(serverDetails, tokenResponse)
+}
+
+// Sets the values in the dotenv file. Existing assignments of the variables are replaced, and other lines are kept as is.
+func UpdateEnvFile(path string, values map[string]string) error {
+	var lines []string
+	exists, err := fileutils.IsFileExists(path, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		lines = strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	}
+	written := make(map[string]bool, len(values))
+	for i, line := range lines {
+		assignment := strings.TrimPrefix(strings.TrimSpace(line), "export ")
+		name, _, found := strings.Cut(assignment, "=")
+		if value, managed := values[strings.TrimSpace(name)]; found && managed {
+			lines[i] = strings.TrimSpace(name) + "=" + value
+			written[strings.TrimSpace(name)] = true
+		}
+	}
+	// Append the new variables in a stable order.
+	for _, name := range []string{UrlEnvVar, AccessTokenEnvVar, RefreshTokenEnvVar} {
+		if value, exists := values[name]; exists && !written[name] {
+			lines = append(lines, name+"="+value)
+		}
+	}
+	// The file holds secrets, so it is readable by its owner only.
+	return errorutils.CheckError(os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+}
+
+// Creates or replaces a server configuration, which authenticates with the access token.
+func addServerConfig(serverId string, serverDetails *config.ServerDetails, accessToken string) error {
+	details := &config.ServerDetails{
+		Url:               serverDetails.Url,
+		ArtifactoryUrl:    serverDetails.ArtifactoryUrl,
+		DistributionUrl:   serverDetails.DistributionUrl,
+		XrayUrl:           serverDetails.XrayUrl,
+		MissionControlUrl: serverDetails.MissionControlUrl,
+		PipelinesUrl:      serverDetails.PipelinesUrl,
+		AccessToken:       accessToken,
+		InsecureTls:       serverDetails.InsecureTls,
+	}
+	return coreCommonCommands.NewConfigCommand(coreCommonCommands.AddOrEdit, serverId).SetDetails(details).SetInteractive(false).Run()
+}
@@ -0,0 +1,27 @@
+package accesstoken
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, UpdateEnvFile(path, map[string]string{UrlEnvVar: "https://acme.jfrog.io/", AccessTokenEnvVar: "token1"}))
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "JF_URL=https://acme.jfrog.io/\nJF_ACCESS_TOKEN=token1\n", string(content))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// Existing assignments are replaced, and other lines are kept.
+	assert.NoError(t, os.WriteFile(path, []byte("# Comment\nOTHER=value\nexport JF_ACCESS_TOKEN=token1\n"), 0600))
+	assert.NoError(t, UpdateEnvFile(path, map[string]string{AccessTokenEnvVar: "token2", RefreshTokenEnvVar: "refresh2"}))
+	content, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Comment\nOTHER=value\nJF_ACCESS_TOKEN=token2\nJF_REFRESH_TOKEN=refresh2\n", string(content))
+}
@@ -0,0 +1,342 @@
+package builddiff
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type ChangeType string
+
+const (
+	// The element exists in the target build only.
+	Added ChangeType = "added"
+	// The element exists in the source build only.
+	Removed ChangeType = "removed"
+	// The element exists in both builds, with a different version, checksum or value.
+	Updated ChangeType = "updated"
+)
+
+type Build struct {
+	Name   string `json:"name"`
+	Number string `json:"number"`
+}
+
+func (b Build) String() string {
+	return b.Name + "/" + b.Number
+}
+
+// The differences between the source and the target builds.
+type BuildDiff struct {
+	Source      Build        `json:"source"`
+	Target      Build        `json:"target"`
+	Modules     []ModuleDiff `json:"modules"`
+	Environment []EnvDiff    `json:"environment"`
+	Vcs         []VcsDiff    `json:"vcs"`
+}
+
+func (bd *BuildDiff) IsEmpty() bool {
+	return len(bd.Modules) == 0 && len(bd.Environment) == 0 && len(bd.Vcs) == 0
+}
+
+// The differences of a module, which is matched between the builds by its ID without the version.
+type ModuleDiff struct {
+	Id            string           `json:"id"`
+	Change        ChangeType       `json:"change,omitempty"`
+	SourceVersion string           `json:"sourceVersion,omitempty"`
+	TargetVersion string           `json:"targetVersion,omitempty"`
+	Dependencies  []DependencyDiff `json:"dependencies,omitempty"`
+	Artifacts     []ArtifactDiff   `json:"artifacts,omitempty"`
+}
+
+type DependencyDiff struct {
+	Change        ChangeType `json:"change"`
+	Id            string     `json:"id"`
+	SourceVersion string     `json:"sourceVersion,omitempty"`
+	TargetVersion string     `json:"targetVersion,omitempty"`
+	SourceSha1    string     `json:"sourceSha1,omitempty"`
+	TargetSha1    string     `json:"targetSha1,omitempty"`
+}
+
+type ArtifactDiff struct {
+	Change     ChangeType `json:"change"`
+	Name       string     `json:"name"`
+	SourceSha1 string     `json:"sourceSha1,omitempty"`
+	TargetSha1 string     `json:"targetSha1,omitempty"`
+}
+
+type EnvDiff struct {
+	Change      ChangeType `json:"change"`
+	Name        string     `json:"name"`
+	SourceValue string     `json:"sourceValue,omitempty"`
+	TargetValue string     `json:"targetValue,omitempty"`
+}
+
+// The revision range of a VCS repository, which is matched between the builds by its URL.
+type VcsDiff struct {
+	Change         ChangeType `json:"change"`
+	Url            string     `json:"url"`
+	SourceBranch   string     `json:"sourceBranch,omitempty"`
+	TargetBranch   string     `json:"targetBranch,omitempty"`
+	SourceRevision string     `json:"sourceRevision,omitempty"`
+	TargetRevision string     `json:"targetRevision,omitempty"`
+}
+
+// Returns the revision range, in the form accepted by 'git log'.
+func (vd VcsDiff) RevisionRange() string {
+	if vd.SourceRevision == "" || vd.TargetRevision == "" {
+		return vd.SourceRevision + vd.TargetRevision
+	}
+	return vd.SourceRevision + ".." + vd.TargetRevision
+}
+
+// Compares the source build-info with the target build-info. All the differences are sorted.
+func Compare(source, target *buildinfo.BuildInfo) *BuildDiff {
+	return &BuildDiff{
+		Source:      Build{Name: source.Name, Number: source.Number},
+		Target:      Build{Name: target.Name, Number: target.Number},
+		Modules:     compareModules(source.Modules, target.Modules),
+		Environment: compareEnv(source.Properties, target.Properties),
+		Vcs:         compareVcs(source.VcsList, target.VcsList),
+	}
+}
+
+// SplitId splits the ID of a module or a dependency to its name and version. For example, 'group:artifact:version' for Maven, and 'name:version' for npm.
+// IDs without a version, such as the IDs of generic modules and dependencies, are returned as the name.
+func SplitId(id string) (name, version string) {
+	// Colons followed by a slash are part of the name, such as the port of a Docker registry.
+	if index := strings.LastIndex(id, ":"); index > 0 && !strings.Contains(id[index:], "/") {
+		return id[:index], id[index+1:]
+	}
+	return id, ""
+}
+
+func compareModules(source, target []buildinfo.Module) []ModuleDiff {
+	sourceModules, targetModules := mapModules(source), mapModules(target)
+	moduleDiffs := []ModuleDiff{}
+	for name, sourceModule := range sourceModules {
+		_, sourceVersion := SplitId(sourceModule.Id)
+		targetModule, exists := targetModules[name]
+		if !exists {
+			moduleDiffs = append(moduleDiffs, ModuleDiff{Id: name, Change: Removed, SourceVersion: sourceVersion})
+			continue
+		}
+		_, targetVersion := SplitId(targetModule.Id)
+		moduleDiff := ModuleDiff{
+			Id:           name,
+			Dependencies: compareDependencies(sourceModule.Dependencies, targetModule.Dependencies),
+			Artifacts:    compareArtifacts(sourceModule.Artifacts, targetModule.Artifacts),
+		}
+		if sourceVersion != targetVersion {
+			moduleDiff.Change, moduleDiff.SourceVersion, moduleDiff.TargetVersion = Updated, sourceVersion, targetVersion
+		}
+		if moduleDiff.Change != "" || len(moduleDiff.Dependencies) > 0 || len(moduleDiff.Artifacts) > 0 {
+			moduleDiffs = append(moduleDiffs, moduleDiff)
+		}
+	}
+	for name, targetModule := range targetModules {
+		if _, exists := sourceModules[name]; !exists {
+			_, targetVersion := SplitId(targetModule.Id)
+			moduleDiffs = append(moduleDiffs, ModuleDiff{Id: name, Change: Added, TargetVersion: targetVersion})
+		}
+	}
+	sort.Slice(moduleDiffs, func(i, j int) bool { return moduleDiffs[i].Id < moduleDiffs[j].Id })
+	return moduleDiffs
+}
+
+func mapModules(modules []buildinfo.Module) map[string]*buildinfo.Module {
+	mapped := make(map[string]*buildinfo.Module, len(modules))
+	for i := range modules {
+		name, _ := SplitId(modules[i].Id)
+		mapped[name] = &modules[i]
+	}
+	return mapped
+}
+
+func compareDependencies(source, target []buildinfo.Dependency) []DependencyDiff {
+	sourceDependencies, targetDependencies := mapDependencies(source), mapDependencies(target)
+	dependencyDiffs := []DependencyDiff{}
+	for name, sourceDependency := range sourceDependencies {
+		_, sourceVersion := SplitId(sourceDependency.Id)
+		targetDependency, exists := targetDependencies[name]
+		if !exists {
+			dependencyDiffs = append(dependencyDiffs, DependencyDiff{Change: Removed, Id: name, SourceVersion: sourceVersion, SourceSha1: sourceDependency.Sha1})
+			continue
+		}
+		_, targetVersion := SplitId(targetDependency.Id)
+		if sourceVersion != targetVersion || sourceDependency.Sha1 != targetDependency.Sha1 {
+			dependencyDiffs = append(dependencyDiffs, DependencyDiff{Change: Updated, Id: name, SourceVersion: sourceVersion, TargetVersion: targetVersion, SourceSha1: sourceDependency.Sha1, TargetSha1: targetDependency.Sha1})
+		}
+	}
+	for name, targetDependency := range targetDependencies {
+		if _, exists := sourceDependencies[name]; !exists {
+			_, targetVersion := SplitId(targetDependency.Id)
+			dependencyDiffs = append(dependencyDiffs, DependencyDiff{Change: Added, Id: name, TargetVersion: targetVersion, TargetSha1: targetDependency.Sha1})
+		}
+	}
+	sort.Slice(dependencyDiffs, func(i, j int) bool { return dependencyDiffs[i].Id < dependencyDiffs[j].Id })
+	return dependencyDiffs
+}
+
+func mapDependencies(dependencies []buildinfo.Dependency) map[string]*buildinfo.Dependency {
+	mapped := make(map[string]*buildinfo.Dependency, len(dependencies))
+	for i := range dependencies {
+		name, _ := SplitId(dependencies[i].Id)
+		mapped[name] = &dependencies[i]
+	}
+	return mapped
+}
+
+func compareArtifacts(source, target []buildinfo.Artifact) []ArtifactDiff {
+	sourceArtifacts, targetArtifacts := mapArtifacts(source), mapArtifacts(target)
+	artifactDiffs := []ArtifactDiff{}
+	for name, sourceSha1 := range sourceArtifacts {
+		targetSha1, exists := targetArtifacts[name]
+		switch {
+		case !exists:
+			artifactDiffs = append(artifactDiffs, ArtifactDiff{Change: Removed, Name: name, SourceSha1: sourceSha1})
+		case sourceSha1 != targetSha1:
+			artifactDiffs = append(artifactDiffs, ArtifactDiff{Change: Updated, Name: name, SourceSha1: sourceSha1, TargetSha1: targetSha1})
+		}
+	}
+	for name, targetSha1 := range targetArtifacts {
+		if _, exists := sourceArtifacts[name]; !exists {
+			artifactDiffs = append(artifactDiffs, ArtifactDiff{Change: Added, Name: name, TargetSha1: targetSha1})
+		}
+	}
+	sort.Slice(artifactDiffs, func(i, j int) bool { return artifactDiffs[i].Name < artifactDiffs[j].Name })
+	return artifactDiffs
+}
+
+// Maps the artifacts' checksums by their names.
+func mapArtifacts(artifacts []buildinfo.Artifact) map[string]string {
+	mapped := make(map[string]string, len(artifacts))
+	for _, artifact := range artifacts {
+		mapped[artifact.Name] = artifact.Sha1
+	}
+	return mapped
+}
+
+// Compares the environment variables, which are kept in the build-info properties.
+func compareEnv(source, target buildinfo.Env) []EnvDiff {
+	envDiffs := []EnvDiff{}
+	for key, sourceValue := range source {
+		name := strings.TrimPrefix(key, buildinfo.BuildInfoEnvPrefix)
+		if name == key {
+			continue
+		}
+		targetValue, exists := target[key]
+		switch {
+		case !exists:
+			envDiffs = append(envDiffs, EnvDiff{Change: Removed, Name: name, SourceValue: sourceValue})
+		case sourceValue != targetValue:
+			envDiffs = append(envDiffs, EnvDiff{Change: Updated, Name: name, SourceValue: sourceValue, TargetValue: targetValue})
+		}
+	}
+	for key, targetValue := range target {
+		name := strings.TrimPrefix(key, buildinfo.BuildInfoEnvPrefix)
+		if _, exists := source[key]; !exists && name != key {
+			envDiffs = append(envDiffs, EnvDiff{Change: Added, Name: name, TargetValue: targetValue})
+		}
+	}
+	sort.Slice(envDiffs, func(i, j int) bool { return envDiffs[i].Name < envDiffs[j].Name })
+	return envDiffs
+}
+
+func compareVcs(source, target []buildinfo.Vcs) []VcsDiff {
+	targetVcs := make(map[string]buildinfo.Vcs, len(target))
+	for _, vcs := range target {
+		targetVcs[vcs.Url] = vcs
+	}
+	sourceUrls := make(map[string]bool, len(source))
+	vcsDiffs := []VcsDiff{}
+	for _, sourceVcs := range source {
+		sourceUrls[sourceVcs.Url] = true
+		vcs, exists := targetVcs[sourceVcs.Url]
+		switch {
+		case !exists:
+			vcsDiffs = append(vcsDiffs, VcsDiff{Change: Removed, Url: sourceVcs.Url, SourceBranch: sourceVcs.Branch, SourceRevision: sourceVcs.Revision})
+		case sourceVcs.Revision != vcs.Revision || sourceVcs.Branch != vcs.Branch:
+			vcsDiffs = append(vcsDiffs, VcsDiff{Change: Updated, Url: sourceVcs.Url, SourceBranch: sourceVcs.Branch, TargetBranch: vcs.Branch, SourceRevision: sourceVcs.Revision, TargetRevision: vcs.Revision})
+		}
+	}
+	for _, vcs := range target {
+		if !sourceUrls[vcs.Url] {
+			vcsDiffs = append(vcsDiffs, VcsDiff{Change: Added, Url: vcs.Url, TargetBranch: vcs.Branch, TargetRevision: vcs.Revision})
+		}
+	}
+	sort.Slice(vcsDiffs, func(i, j int) bool { return vcsDiffs[i].Url < vcsDiffs[j].Url })
+	return vcsDiffs
+}
+
+// The table representation of a difference.
+type differenceRow struct {
+	Type    string     `col-name:"Type"`
+	Module  string     `col-name:"Module"`
+	Change  ChangeType `col-name:"Change"`
+	Name    string     `col-name:"Name"`
+	Details string     `col-name:"Details"`
+}
+
+// Returns the differences as table rows, sorted by the modules.
+func (bd *BuildDiff) toRows() []differenceRow {
+	rows := []differenceRow{}
+	for _, module := range bd.Modules {
+		if module.Change != "" {
+			rows = append(rows, differenceRow{Type: "module", Module: module.Id, Change: module.Change, Details: getValueDetails(module.SourceVersion, module.TargetVersion)})
+		}
+		for _, dependency := range module.Dependencies {
+			details := getValueDetails(dependency.SourceVersion, dependency.TargetVersion)
+			if dependency.Change == Updated && dependency.SourceVersion == dependency.TargetVersion {
+				details = getSha1Details(dependency.SourceSha1, dependency.TargetSha1)
+			}
+			rows = append(rows, differenceRow{Type: "dependency", Module: module.Id, Change: dependency.Change, Name: dependency.Id, Details: details})
+		}
+		for _, artifact := range module.Artifacts {
+			rows = append(rows, differenceRow{Type: "artifact", Module: module.Id, Change: artifact.Change, Name: artifact.Name, Details: getSha1Details(artifact.SourceSha1, artifact.TargetSha1)})
+		}
+	}
+	for _, env := range bd.Environment {
+		rows = append(rows, differenceRow{Type: "env", Change: env.Change, Name: env.Name, Details: getValueDetails(env.SourceValue, env.TargetValue)})
+	}
+	for _, vcs := range bd.Vcs {
+		rows = append(rows, differenceRow{Type: "vcs", Change: vcs.Change, Name: vcs.Url, Details: "revisions: " + vcs.RevisionRange()})
+	}
+	return rows
+}
+
+func getSha1Details(sourceSha1, targetSha1 string) string {
+	if sourceSha1 == "" && targetSha1 == "" {
+		return ""
+	}
+	return "sha1: " + getValueDetails(sourceSha1, targetSha1)
+}
+
+func getValueDetails(sourceValue, targetValue string) string {
+	switch {
+	case sourceValue == "":
+		return targetValue
+	case targetValue == "":
+		return sourceValue
+	default:
+		return sourceValue + " -> " + targetValue
+	}
+}
+
+// Prints the differences as a table or as JSON. The format should be validated by diff.ValidateFormat.
+func PrintBuildDiff(buildDiff *BuildDiff, format string) error {
+	if format == "json" {
+		content, err := json.MarshalIndent(buildDiff, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	return coreutils.PrintTable(buildDiff.toRows(), "Build Differences ("+buildDiff.Source.String()+" -> "+buildDiff.Target.String()+")", "No differences were found", false)
+}
@@ -0,0 +1,97 @@
+package builddiff
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func readBuildInfo(t *testing.T, fileName string) *buildinfo.BuildInfo {
+	content, err := os.ReadFile(filepath.Join("testdata", fileName))
+	assert.NoError(t, err)
+	buildInfo := &buildinfo.BuildInfo{}
+	assert.NoError(t, json.Unmarshal(content, buildInfo))
+	return buildInfo
+}
+
+func TestSplitId(t *testing.T) {
+	tests := []struct {
+		id              string
+		expectedName    string
+		expectedVersion string
+	}{
+		{"org.acme:app:1.0.0", "org.acme:app", "1.0.0"},
+		{"@acme/web:2.0.0", "@acme/web", "2.0.0"},
+		{"localhost:8082/acme/web:2.0", "localhost:8082/acme/web", "2.0"},
+		{"localhost:8082/acme/web", "localhost:8082/acme/web", ""},
+		{"path/to/file.zip", "path/to/file.zip", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			name, version := SplitId(test.id)
+			assert.Equal(t, test.expectedName, name)
+			assert.Equal(t, test.expectedVersion, version)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	buildDiff := Compare(readBuildInfo(t, "source.json"), readBuildInfo(t, "target.json"))
+	assert.Equal(t, Build{Name: "my-build", Number: "1"}, buildDiff.Source)
+	assert.Equal(t, Build{Name: "my-build", Number: "2"}, buildDiff.Target)
+	assert.Equal(t, []ModuleDiff{
+		{Id: "localhost:8082/acme/web", Change: Added, TargetVersion: "2.0"},
+		{
+			Id:            "org.acme:app",
+			Change:        Updated,
+			SourceVersion: "1.0.0",
+			TargetVersion: "1.1.0",
+			Dependencies: []DependencyDiff{
+				{Change: Removed, Id: "com.google.guava:guava", SourceVersion: "31.0-jre", SourceSha1: "guava1"},
+				{Change: Updated, Id: "org.acme:snapshot", SourceVersion: "1.0-SNAPSHOT", TargetVersion: "1.0-SNAPSHOT", SourceSha1: "snap1", TargetSha1: "snap2"},
+				{Change: Updated, Id: "org.slf4j:slf4j-api", SourceVersion: "1.7.36", TargetVersion: "2.0.7", SourceSha1: "slf1", TargetSha1: "slf2"},
+				{Change: Added, Id: "org.yaml:snakeyaml", TargetVersion: "2.0", TargetSha1: "yaml2"},
+			},
+			Artifacts: []ArtifactDiff{
+				{Change: Added, Name: "app-javadoc.jar", TargetSha1: "doc2"},
+				{Change: Removed, Name: "app-sources.jar", SourceSha1: "src1"},
+				{Change: Updated, Name: "app.jar", SourceSha1: "app1", TargetSha1: "app2"},
+			},
+		},
+		{Id: "web", Change: Removed, SourceVersion: "1.0.0"},
+	}, buildDiff.Modules)
+	// Only the environment variables are compared, and not the other properties.
+	assert.Equal(t, []EnvDiff{
+		{Change: Added, Name: "ADDED", TargetValue: "value"},
+		{Change: Updated, Name: "JAVA_HOME", SourceValue: "/opt/java/11", TargetValue: "/opt/java/17"},
+		{Change: Removed, Name: "REMOVED", SourceValue: "value"},
+	}, buildDiff.Environment)
+	assert.Equal(t, []VcsDiff{
+		{Change: Added, Url: "https://github.com/acme/added.git", TargetBranch: "dev", TargetRevision: "ddd444"},
+		{Change: Updated, Url: "https://github.com/acme/app.git", SourceBranch: "main", TargetBranch: "main", SourceRevision: "aaa111", TargetRevision: "bbb222"},
+		{Change: Removed, Url: "https://github.com/acme/removed.git", SourceBranch: "main", SourceRevision: "ccc333"},
+	}, buildDiff.Vcs)
+	assert.Equal(t, "aaa111..bbb222", buildDiff.Vcs[1].RevisionRange())
+	assert.False(t, buildDiff.IsEmpty())
+}
+
+func TestCompareSameBuild(t *testing.T) {
+	buildDiff := Compare(readBuildInfo(t, "source.json"), readBuildInfo(t, "source.json"))
+	assert.True(t, buildDiff.IsEmpty())
+	assert.Empty(t, buildDiff.toRows())
+}
+
+func TestToRows(t *testing.T) {
+	rows := Compare(readBuildInfo(t, "source.json"), readBuildInfo(t, "target.json")).toRows()
+	assert.Contains(t, rows, differenceRow{Type: "module", Module: "org.acme:app", Change: Updated, Details: "1.0.0 -> 1.1.0"})
+	assert.Contains(t, rows, differenceRow{Type: "dependency", Module: "org.acme:app", Change: Updated, Name: "org.slf4j:slf4j-api", Details: "1.7.36 -> 2.0.7"})
+	assert.Contains(t, rows, differenceRow{Type: "dependency", Module: "org.acme:app", Change: Updated, Name: "org.acme:snapshot", Details: "sha1: snap1 -> snap2"})
+	assert.Contains(t, rows, differenceRow{Type: "artifact", Module: "org.acme:app", Change: Updated, Name: "app.jar", Details: "sha1: app1 -> app2"})
+	assert.Contains(t, rows, differenceRow{Type: "env", Change: Updated, Name: "JAVA_HOME", Details: "/opt/java/11 -> /opt/java/17"})
+	assert.Contains(t, rows, differenceRow{Type: "vcs", Change: Updated, Name: "https://github.com/acme/app.git", Details: "revisions: aaa111..bbb222"})
+	assert.Len(t, rows, 16)
+}
@@ -0,0 +1,88 @@
+package builddiff
+
+import (
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type BuildDiffCommand struct {
+	serverDetails *config.ServerDetails
+	source        Build
+	target        Build
+	project       string
+	buildDiff     *BuildDiff
+}
+
+func NewBuildDiffCommand() *BuildDiffCommand {
+	return &BuildDiffCommand{}
+}
+
+func (bdc *BuildDiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildDiffCommand {
+	bdc.serverDetails = serverDetails
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetSource(source Build) *BuildDiffCommand {
+	bdc.source = source
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetTarget(target Build) *BuildDiffCommand {
+	bdc.target = target
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetProject(project string) *BuildDiffCommand {
+	bdc.project = project
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) BuildDiff() *BuildDiff {
+	return bdc.buildDiff
+}
+
+func (bdc *BuildDiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bdc.serverDetails, nil
+}
+
+func (bdc *BuildDiffCommand) CommandName() string {
+	return "rt_build_diff"
+}
+
+func (bdc *BuildDiffCommand) Run() error {
+	log.Info("Comparing build", bdc.source.String(), "with build", bdc.target.String()+"...")
+	servicesManager, err := utils.CreateServiceManager(bdc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	source, err := bdc.getBuildInfo(servicesManager, bdc.source)
+	if err != nil {
+		return err
+	}
+	target, err := bdc.getBuildInfo(servicesManager, bdc.target)
+	if err != nil {
+		return err
+	}
+	bdc.buildDiff = Compare(source, target)
+	return nil
+}
+
+func (bdc *BuildDiffCommand) getBuildInfo(servicesManager artifactory.ArtifactoryServicesManager, build Build) (*buildinfo.BuildInfo, error) {
+	params := services.NewBuildInfoParams()
+	params.BuildName = build.Name
+	params.BuildNumber = build.Number
+	params.ProjectKey = bdc.project
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(params)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s was not found in Artifactory", build.String())
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
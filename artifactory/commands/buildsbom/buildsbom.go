@@ -0,0 +1,152 @@
+package buildsbom
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func ValidateFormat(format string) error {
+	switch format {
+	case "", CycloneDxJson, SpdxJson:
+		return nil
+	default:
+		return errorutils.CheckErrorf("the --format option accepts the following values: %s and %s, but received '%s'", CycloneDxJson, SpdxJson, format)
+	}
+}
+
+// Converts the build-info to an SBOM in the requested format. The format should be validated by ValidateFormat.
+func CreateSbom(buildInfo *buildinfo.BuildInfo, format string, timestamp time.Time) ([]byte, error) {
+	tool := Tool{Vendor: "JFrog", Name: coreutils.GetCliUserAgentName(), Version: coreutils.GetCliUserAgentVersion()}
+	sbom := NewSbom(buildInfo)
+	var document interface{}
+	if format == SpdxJson {
+		document = ToSpdx(sbom, tool, uuid.NewString(), timestamp)
+	} else {
+		document = ToCycloneDx(sbom, tool, uuid.NewString(), timestamp)
+	}
+	content, err := json.MarshalIndent(document, "", "  ")
+	return content, errorutils.CheckError(err)
+}
+
+type BuildSbomCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	format             string
+	local              bool
+	outputPath         string
+}
+
+func NewBuildSbomCommand() *BuildSbomCommand {
+	return &BuildSbomCommand{}
+}
+
+func (bsc *BuildSbomCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildSbomCommand {
+	bsc.serverDetails = serverDetails
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildSbomCommand {
+	bsc.buildConfiguration = buildConfiguration
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetFormat(format string) *BuildSbomCommand {
+	bsc.format = format
+	return bsc
+}
+
+// If set to true, the SBOM is created from the local build-info, which wasn't published yet.
+func (bsc *BuildSbomCommand) SetLocal(local bool) *BuildSbomCommand {
+	bsc.local = local
+	return bsc
+}
+
+// Sets the path of the SBOM file to create. If not set, the SBOM is written to the standard output.
+func (bsc *BuildSbomCommand) SetOutputPath(outputPath string) *BuildSbomCommand {
+	bsc.outputPath = outputPath
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bsc.serverDetails, nil
+}
+
+func (bsc *BuildSbomCommand) CommandName() string {
+	return "rt_build_sbom"
+}
+
+func (bsc *BuildSbomCommand) Run() error {
+	buildInfo, err := bsc.getBuildInfo()
+	if err != nil {
+		return err
+	}
+	content, err := CreateSbom(buildInfo, bsc.format, time.Now())
+	if err != nil {
+		return err
+	}
+	if bsc.outputPath == "" {
+		log.Output(string(content))
+		return nil
+	}
+	if err = os.WriteFile(bsc.outputPath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("The SBOM was written to", bsc.outputPath)
+	return nil
+}
+
+func (bsc *BuildSbomCommand) getBuildInfo() (*buildinfo.BuildInfo, error) {
+	buildName, err := bsc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return nil, err
+	}
+	buildNumber, err := bsc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return nil, err
+	}
+	if bsc.local {
+		return GetLocalBuildInfo(buildName, buildNumber, bsc.buildConfiguration.GetProject())
+	}
+	servicesManager, err := utils.CreateServiceManager(bsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	params := services.NewBuildInfoParams()
+	params.BuildName = buildName
+	params.BuildNumber = buildNumber
+	params.ProjectKey = bsc.buildConfiguration.GetProject()
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(params)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s/%s was not found in Artifactory", buildName, buildNumber)
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
+
+// Returns the build-info collected locally for the build, which wasn't published yet, similarly to the build-info created by build-publish.
+func GetLocalBuildInfo(buildName, buildNumber, projectKey string) (*buildinfo.BuildInfo, error) {
+	build, err := utils.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	buildInfo, err := build.ToBuildInfo()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(buildInfo.Modules) == 0 {
+		return nil, errorutils.CheckErrorf("no local build-info was collected for build %s/%s", buildName, buildNumber)
+	}
+	return buildInfo, nil
+}
@@ -0,0 +1,262 @@
+package buildsbom
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+)
+
+const (
+	CycloneDxJson = "cyclonedx-json"
+	SpdxJson      = "spdx-json"
+)
+
+// Information about the tool which created the SBOM.
+type Tool struct {
+	Vendor  string
+	Name    string
+	Version string
+}
+
+// CycloneDX 1.4, as defined by https://cyclonedx.org/docs/1.4/json.
+type cycloneDxBom struct {
+	BomFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     cycloneDxMetadata     `json:"metadata"`
+	Components   []cycloneDxComponent  `json:"components"`
+	Dependencies []cycloneDxDependency `json:"dependencies"`
+}
+
+type cycloneDxMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     []cycloneDxTool    `json:"tools"`
+	Component cycloneDxComponent `json:"component"`
+}
+
+type cycloneDxTool struct {
+	Vendor  string `json:"vendor"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type cycloneDxComponent struct {
+	Type       string               `json:"type"`
+	BomRef     string               `json:"bom-ref"`
+	Name       string               `json:"name"`
+	Version    string               `json:"version,omitempty"`
+	Purl       string               `json:"purl,omitempty"`
+	Hashes     []cycloneDxHash      `json:"hashes,omitempty"`
+	Properties []cycloneDxProperty  `json:"properties,omitempty"`
+	Components []cycloneDxComponent `json:"components,omitempty"`
+}
+
+type cycloneDxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cycloneDxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+func toCycloneDxHashes(checksum buildinfo.Checksum) []cycloneDxHash {
+	var hashes []cycloneDxHash
+	if checksum.Sha256 != "" {
+		hashes = append(hashes, cycloneDxHash{Alg: "SHA-256", Content: checksum.Sha256})
+	}
+	if checksum.Sha1 != "" {
+		hashes = append(hashes, cycloneDxHash{Alg: "SHA-1", Content: checksum.Sha1})
+	}
+	if checksum.Md5 != "" {
+		hashes = append(hashes, cycloneDxHash{Alg: "MD5", Content: checksum.Md5})
+	}
+	return hashes
+}
+
+func toCycloneDxComponent(component Component, componentType string) cycloneDxComponent {
+	if component.IsFile() {
+		componentType = "file"
+	}
+	result := cycloneDxComponent{Type: componentType, BomRef: component.Ref, Name: component.Name, Version: component.Version, Purl: component.Purl, Hashes: toCycloneDxHashes(component.Checksum)}
+	if len(component.Scopes) > 0 {
+		result.Properties = []cycloneDxProperty{{Name: "jfrog:build-info:scopes", Value: strings.Join(component.Scopes, ",")}}
+	}
+	return result
+}
+
+func ToCycloneDx(sbom *Sbom, tool Tool, serialNumber string, timestamp time.Time) interface{} {
+	buildRef := "build:" + sbom.BuildName + "/" + sbom.BuildNumber
+	bom := &cycloneDxBom{
+		BomFormat:    "CycloneDX",
+		SpecVersion:  "1.4",
+		SerialNumber: "urn:uuid:" + serialNumber,
+		Version:      1,
+		Metadata: cycloneDxMetadata{
+			Timestamp: timestamp.UTC().Format(time.RFC3339),
+			Tools:     []cycloneDxTool{{Vendor: tool.Vendor, Name: tool.Name, Version: tool.Version}},
+			Component: cycloneDxComponent{Type: "application", BomRef: buildRef, Name: sbom.BuildName, Version: sbom.BuildNumber},
+		},
+		Components:   []cycloneDxComponent{},
+		Dependencies: []cycloneDxDependency{},
+	}
+	var moduleRefs []string
+	for _, module := range sbom.Modules {
+		component := toCycloneDxComponent(module.Component, "library")
+		for _, artifact := range module.Artifacts {
+			component.Components = append(component.Components, toCycloneDxComponent(artifact, "file"))
+		}
+		bom.Components = append(bom.Components, component)
+		bom.Dependencies = append(bom.Dependencies, cycloneDxDependency{Ref: module.Ref, DependsOn: append([]string{}, module.Dependencies...)})
+		moduleRefs = append(moduleRefs, module.Ref)
+	}
+	for _, dependency := range sbom.Dependencies {
+		bom.Components = append(bom.Components, toCycloneDxComponent(dependency, "library"))
+		bom.Dependencies = append(bom.Dependencies, cycloneDxDependency{Ref: dependency.Ref, DependsOn: []string{}})
+	}
+	bom.Dependencies = append([]cycloneDxDependency{{Ref: buildRef, DependsOn: append([]string{}, moduleRefs...)}}, bom.Dependencies...)
+	return bom
+}
+
+// SPDX 2.3, as defined by https://spdx.github.io/spdx-spec/v2.3.
+type spdxDocument struct {
+	SpdxVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SpdxId            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Files             []spdxFile         `json:"files,omitempty"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SpdxId           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxFile struct {
+	FileName  string         `json:"fileName"`
+	SpdxId    string         `json:"SPDXID"`
+	Checksums []spdxChecksum `json:"checksums"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+// SPDX identifiers may only include letters, numbers, dots and dashes.
+var spdxIdInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+// Creates unique SPDX identifiers from the component references.
+type spdxIds struct {
+	ids  map[string]string
+	used map[string]bool
+}
+
+func (si *spdxIds) get(ref string) string {
+	if id, exists := si.ids[ref]; exists {
+		return id
+	}
+	base := "SPDXRef-" + strings.Trim(spdxIdInvalidChars.ReplaceAllString(ref, "-"), "-")
+	id := base
+	for i := 2; si.used[id]; i++ {
+		id = base + "-" + strconv.Itoa(i)
+	}
+	si.ids[ref], si.used[id] = id, true
+	return id
+}
+
+func toSpdxChecksums(checksum buildinfo.Checksum) []spdxChecksum {
+	var checksums []spdxChecksum
+	if checksum.Sha1 != "" {
+		checksums = append(checksums, spdxChecksum{Algorithm: "SHA1", ChecksumValue: checksum.Sha1})
+	}
+	if checksum.Sha256 != "" {
+		checksums = append(checksums, spdxChecksum{Algorithm: "SHA256", ChecksumValue: checksum.Sha256})
+	}
+	if checksum.Md5 != "" {
+		checksums = append(checksums, spdxChecksum{Algorithm: "MD5", ChecksumValue: checksum.Md5})
+	}
+	return checksums
+}
+
+func toSpdxPackage(component Component, spdxId string) spdxPackage {
+	pkg := spdxPackage{Name: component.Name, SpdxId: spdxId, VersionInfo: component.Version, DownloadLocation: "NOASSERTION", Checksums: toSpdxChecksums(component.Checksum)}
+	if component.Purl != "" {
+		pkg.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: component.Purl}}
+	}
+	return pkg
+}
+
+func ToSpdx(sbom *Sbom, tool Tool, namespaceId string, timestamp time.Time) interface{} {
+	ids := &spdxIds{ids: map[string]string{}, used: map[string]bool{}}
+	documentName := sbom.BuildName + "-" + sbom.BuildNumber
+	buildId := ids.get("build-" + documentName)
+	document := &spdxDocument{
+		SpdxVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SpdxId:            "SPDXRef-DOCUMENT",
+		Name:              documentName,
+		DocumentNamespace: "https://jfrog.com/spdx/" + escapePurlSegment(documentName) + "-" + namespaceId,
+		CreationInfo: spdxCreationInfo{
+			Created:  timestamp.UTC().Format(time.RFC3339),
+			Creators: []string{"Organization: " + tool.Vendor, "Tool: " + tool.Name + "-" + tool.Version},
+		},
+		Packages:      []spdxPackage{{Name: sbom.BuildName, SpdxId: buildId, VersionInfo: sbom.BuildNumber, DownloadLocation: "NOASSERTION"}},
+		Relationships: []spdxRelationship{{SpdxElementId: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSpdxElement: buildId}},
+	}
+	for _, module := range sbom.Modules {
+		moduleId := ids.get(module.Ref)
+		document.Packages = append(document.Packages, toSpdxPackage(module.Component, moduleId))
+		document.Relationships = append(document.Relationships, spdxRelationship{SpdxElementId: buildId, RelationshipType: "CONTAINS", RelatedSpdxElement: moduleId})
+		for _, artifact := range module.Artifacts {
+			artifactId := ids.get(artifact.Ref)
+			document.Files = append(document.Files, spdxFile{FileName: artifact.Name, SpdxId: artifactId, Checksums: toSpdxChecksums(artifact.Checksum)})
+			document.Relationships = append(document.Relationships, spdxRelationship{SpdxElementId: moduleId, RelationshipType: "GENERATES", RelatedSpdxElement: artifactId})
+		}
+	}
+	for _, dependency := range sbom.Dependencies {
+		document.Packages = append(document.Packages, toSpdxPackage(dependency, ids.get(dependency.Ref)))
+	}
+	for _, module := range sbom.Modules {
+		for _, dependencyRef := range module.Dependencies {
+			document.Relationships = append(document.Relationships, spdxRelationship{SpdxElementId: ids.get(module.Ref), RelationshipType: "DEPENDS_ON", RelatedSpdxElement: ids.get(dependencyRef)})
+		}
+	}
+	return document
+}
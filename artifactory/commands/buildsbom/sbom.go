@@ -0,0 +1,216 @@
+package buildsbom
+
+import (
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+)
+
+// Maps the build-info module types to package URL types.
+var purlTypes = map[buildinfo.ModuleType]string{
+	buildinfo.Maven:  "maven",
+	buildinfo.Gradle: "maven",
+	buildinfo.Npm:    "npm",
+	buildinfo.Go:     "golang",
+	buildinfo.Python: "pypi",
+	buildinfo.Nuget:  "nuget",
+	buildinfo.Docker: "docker",
+}
+
+// A component of the SBOM - a module, an artifact or a dependency of the build.
+type Component struct {
+	// A unique reference to the component within the SBOM.
+	Ref     string
+	Name    string
+	Version string
+	// The package URL of the component. Empty for files, such as artifacts and generic dependencies.
+	Purl   string
+	Scopes []string
+	buildinfo.Checksum
+}
+
+func (c *Component) IsFile() bool {
+	return c.Purl == ""
+}
+
+type Module struct {
+	Component
+	Artifacts []Component
+	// The references of the module's dependencies.
+	Dependencies []string
+}
+
+// A format independent representation of the build's bill of materials.
+type Sbom struct {
+	BuildName   string
+	BuildNumber string
+	Started     string
+	Modules     []Module
+	// The unique dependencies of all the modules, sorted by their references.
+	Dependencies []Component
+}
+
+func NewSbom(buildInfo *buildinfo.BuildInfo) *Sbom {
+	sbom := &Sbom{BuildName: buildInfo.Name, BuildNumber: buildInfo.Number, Started: buildInfo.Started}
+	moduleRefs := map[string]bool{}
+	for _, module := range buildInfo.Modules {
+		component := newPackageComponent(module.Type, module.Id)
+		component.Ref = "module:" + component.Ref
+		if component.Purl != "" {
+			component.Ref = component.Purl
+		}
+		component.Checksum = module.Checksum
+		moduleRefs[component.Ref] = true
+		sbom.Modules = append(sbom.Modules, Module{Component: component})
+	}
+	dependencies := map[string]*Component{}
+	for i, module := range buildInfo.Modules {
+		sbomModule := &sbom.Modules[i]
+		for _, artifact := range module.Artifacts {
+			name := artifact.Name
+			if artifact.Path != "" {
+				name = artifact.Path
+			}
+			sbomModule.Artifacts = append(sbomModule.Artifacts, Component{Ref: "artifact:" + sbomModule.Ref + "/" + name, Name: artifact.Name, Checksum: artifact.Checksum})
+		}
+		dependencyRefs := map[string]bool{}
+		for _, dependency := range module.Dependencies {
+			component := newDependencyComponent(module.Type, dependency)
+			if !dependencyRefs[component.Ref] {
+				dependencyRefs[component.Ref] = true
+				sbomModule.Dependencies = append(sbomModule.Dependencies, component.Ref)
+			}
+			// A dependency on another module of the build references the module itself.
+			if moduleRefs[component.Ref] {
+				continue
+			}
+			if existing, exists := dependencies[component.Ref]; exists {
+				existing.Scopes = mergeScopes(existing.Scopes, component.Scopes)
+				continue
+			}
+			dependencies[component.Ref] = &component
+		}
+		sort.Strings(sbomModule.Dependencies)
+	}
+	for _, dependency := range dependencies {
+		sbom.Dependencies = append(sbom.Dependencies, *dependency)
+	}
+	sort.Slice(sbom.Dependencies, func(i, j int) bool { return sbom.Dependencies[i].Ref < sbom.Dependencies[j].Ref })
+	return sbom
+}
+
+func newDependencyComponent(moduleType buildinfo.ModuleType, dependency buildinfo.Dependency) Component {
+	var component Component
+	// Docker dependencies are the image layers, and generic dependencies are files.
+	if moduleType == buildinfo.Docker || purlTypes[moduleType] == "" {
+		component = Component{Name: dependency.Id}
+		component.Ref = "file:" + dependency.Id
+		if dependency.Sha1 != "" {
+			component.Ref = "file:" + dependency.Sha1
+		}
+	} else {
+		component = newPackageComponent(moduleType, dependency.Id)
+	}
+	component.Checksum = dependency.Checksum
+	component.Scopes = mergeScopes(nil, dependency.Scopes)
+	return component
+}
+
+// Creates a component from the ID of a module or a dependency.
+// The ID format depends on the module type. For example, 'group:artifact:version' for Maven, and 'name:version' for npm.
+func newPackageComponent(moduleType buildinfo.ModuleType, id string) Component {
+	purlType := purlTypes[moduleType]
+	if purlType == "" {
+		return Component{Ref: id, Name: id}
+	}
+	namespace, name, version := parsePackageId(moduleType, id)
+	component := Component{Name: name, Version: version, Purl: createPurl(purlType, namespace, name, version)}
+	if namespace != "" {
+		separator := "/"
+		if purlType == "maven" {
+			separator = ":"
+		}
+		component.Name = namespace + separator + name
+	}
+	component.Ref = component.Purl
+	return component
+}
+
+func parsePackageId(moduleType buildinfo.ModuleType, id string) (namespace, name, version string) {
+	if moduleType == buildinfo.Maven || moduleType == buildinfo.Gradle {
+		parts := strings.Split(id, ":")
+		if len(parts) >= 3 {
+			return parts[0], parts[1], parts[2]
+		}
+		if len(parts) == 2 {
+			return parts[0], parts[1], ""
+		}
+		return "", id, ""
+	}
+	name = id
+	// The version follows the last colon. Colons followed by a slash are part of the name, such as the port of a Docker registry.
+	if index := strings.LastIndex(id, ":"); index > 0 && !strings.Contains(id[index:], "/") {
+		name, version = id[:index], id[index+1:]
+	}
+	switch moduleType {
+	case buildinfo.Npm:
+		if strings.HasPrefix(name, "@") {
+			if scope, scopedName, found := strings.Cut(name, "/"); found {
+				return scope, scopedName, version
+			}
+		}
+	case buildinfo.Go:
+		if dir := path.Dir(name); dir != "." {
+			return dir, path.Base(name), version
+		}
+	case buildinfo.Python:
+		// Python package names are case-insensitive, and their normalized form uses dashes.
+		name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	case buildinfo.Docker:
+		if dir := path.Dir(name); dir != "." {
+			return dir, path.Base(name), version
+		}
+	}
+	return "", name, version
+}
+
+// Creates a package URL, as defined by https://github.com/package-url/purl-spec.
+func createPurl(purlType, namespace, name, version string) string {
+	purl := "pkg:" + purlType + "/"
+	if namespace != "" {
+		var segments []string
+		for _, segment := range strings.Split(namespace, "/") {
+			segments = append(segments, escapePurlSegment(segment))
+		}
+		purl += strings.Join(segments, "/") + "/"
+	}
+	purl += escapePurlSegment(name)
+	if version != "" {
+		purl += "@" + escapePurlSegment(version)
+	}
+	return purl
+}
+
+func escapePurlSegment(segment string) string {
+	return strings.ReplaceAll(url.PathEscape(segment), "@", "%40")
+}
+
+func mergeScopes(scopes, other []string) []string {
+	for _, scope := range other {
+		found := false
+		for _, existing := range scopes {
+			if existing == scope {
+				found = true
+				break
+			}
+		}
+		if !found {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
@@ -0,0 +1,133 @@
+package buildsbom
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func readBuildInfo(t *testing.T) *buildinfo.BuildInfo {
+	content, err := os.ReadFile(filepath.Join("testdata", "buildinfo.json"))
+	assert.NoError(t, err)
+	buildInfo := &buildinfo.BuildInfo{}
+	assert.NoError(t, json.Unmarshal(content, buildInfo))
+	return buildInfo
+}
+
+func TestNewPackageComponent(t *testing.T) {
+	tests := []struct {
+		moduleType   buildinfo.ModuleType
+		id           string
+		expectedName string
+		expectedPurl string
+	}{
+		{buildinfo.Maven, "org.acme:api:1.0.0", "org.acme:api", "pkg:maven/org.acme/api@1.0.0"},
+		{buildinfo.Gradle, "org.acme:api:1.0.0", "org.acme:api", "pkg:maven/org.acme/api@1.0.0"},
+		{buildinfo.Npm, "lodash:4.17.21", "lodash", "pkg:npm/lodash@4.17.21"},
+		{buildinfo.Npm, "@acme/web:2.0.0", "@acme/web", "pkg:npm/%40acme/web@2.0.0"},
+		{buildinfo.Go, "github.com/jfrog/jfrog-cli:v2.40.0", "github.com/jfrog/jfrog-cli", "pkg:golang/github.com/jfrog/jfrog-cli@v2.40.0"},
+		{buildinfo.Python, "Flask_Cors:3.0.10", "flask-cors", "pkg:pypi/flask-cors@3.0.10"},
+		{buildinfo.Nuget, "Newtonsoft.Json:13.0.1", "Newtonsoft.Json", "pkg:nuget/Newtonsoft.Json@13.0.1"},
+		{buildinfo.Docker, "localhost:8082/docker-local/hello:1.0", "localhost:8082/docker-local/hello", "pkg:docker/localhost:8082/docker-local/hello@1.0"},
+		{buildinfo.Docker, "localhost:8082/hello", "localhost:8082/hello", "pkg:docker/localhost:8082/hello"},
+		{buildinfo.Generic, "my-build", "my-build", ""},
+	}
+	for _, test := range tests {
+		t.Run(string(test.moduleType)+"/"+test.id, func(t *testing.T) {
+			component := newPackageComponent(test.moduleType, test.id)
+			assert.Equal(t, test.expectedName, component.Name)
+			assert.Equal(t, test.expectedPurl, component.Purl)
+		})
+	}
+}
+
+func TestNewSbom(t *testing.T) {
+	sbom := NewSbom(readBuildInfo(t))
+	assert.Equal(t, "my-build", sbom.BuildName)
+	assert.Equal(t, "7", sbom.BuildNumber)
+
+	var moduleRefs []string
+	for _, module := range sbom.Modules {
+		moduleRefs = append(moduleRefs, module.Ref)
+	}
+	assert.Equal(t, []string{"pkg:maven/org.acme/api@1.0.0", "pkg:maven/org.acme/app@1.0.0", "pkg:npm/%40acme/web@2.0.0", "module:my-build"}, moduleRefs)
+	assert.Equal(t, []Component{{Ref: "artifact:pkg:maven/org.acme/api@1.0.0/org/acme/api/1.0.0/api-1.0.0.jar", Name: "api-1.0.0.jar", Checksum: buildinfo.Checksum{Sha1: "a1", Sha256: "a256", Md5: "amd5"}}}, sbom.Modules[0].Artifacts)
+	// A dependency on another module of the build references the module.
+	assert.Equal(t, []string{"pkg:maven/org.acme/api@1.0.0", "pkg:maven/org.slf4j/slf4j-api@1.7.36"}, sbom.Modules[1].Dependencies)
+	assert.Equal(t, []string{"file:g1"}, sbom.Modules[3].Dependencies)
+
+	var dependencyRefs []string
+	for _, dependency := range sbom.Dependencies {
+		dependencyRefs = append(dependencyRefs, dependency.Ref)
+	}
+	assert.Equal(t, []string{"file:g1", "pkg:maven/junit/junit@4.13.2", "pkg:maven/org.slf4j/slf4j-api@1.7.36", "pkg:npm/%40types/node@18.0.0"}, dependencyRefs)
+	// The scopes of a dependency shared by several modules are merged.
+	assert.Equal(t, []string{"compile", "runtime"}, sbom.Dependencies[2].Scopes)
+	assert.True(t, sbom.Dependencies[0].IsFile())
+	assert.Equal(t, "tools/setup.sh", sbom.Dependencies[0].Name)
+}
+
+func TestToCycloneDx(t *testing.T) {
+	timestamp := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	bom := ToCycloneDx(NewSbom(readBuildInfo(t)), Tool{Vendor: "JFrog", Name: "jfrog-cli-go", Version: "2.40.0"}, "serial", timestamp).(*cycloneDxBom)
+	assert.Equal(t, "CycloneDX", bom.BomFormat)
+	assert.Equal(t, "1.4", bom.SpecVersion)
+	assert.Equal(t, "urn:uuid:serial", bom.SerialNumber)
+	assert.Equal(t, "2023-05-01T12:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, "build:my-build/7", bom.Metadata.Component.BomRef)
+	// 4 modules and 4 dependencies.
+	assert.Len(t, bom.Components, 8)
+	api := bom.Components[0]
+	assert.Equal(t, "library", api.Type)
+	assert.Equal(t, "pkg:maven/org.acme/api@1.0.0", api.Purl)
+	assert.Len(t, api.Components, 1)
+	assert.Equal(t, "file", api.Components[0].Type)
+	assert.Equal(t, []cycloneDxHash{{Alg: "SHA-256", Content: "a256"}, {Alg: "SHA-1", Content: "a1"}, {Alg: "MD5", Content: "amd5"}}, api.Components[0].Hashes)
+	assert.Equal(t, "file", bom.Components[4].Type)
+	assert.Equal(t, []cycloneDxProperty{{Name: "jfrog:build-info:scopes", Value: "compile,runtime"}}, bom.Components[6].Properties)
+
+	assert.Equal(t, cycloneDxDependency{Ref: "build:my-build/7", DependsOn: []string{"pkg:maven/org.acme/api@1.0.0", "pkg:maven/org.acme/app@1.0.0", "pkg:npm/%40acme/web@2.0.0", "module:my-build"}}, bom.Dependencies[0])
+	assert.Equal(t, cycloneDxDependency{Ref: "pkg:maven/org.acme/app@1.0.0", DependsOn: []string{"pkg:maven/org.acme/api@1.0.0", "pkg:maven/org.slf4j/slf4j-api@1.7.36"}}, bom.Dependencies[2])
+	assert.Len(t, bom.Dependencies, 9)
+}
+
+func TestToSpdx(t *testing.T) {
+	timestamp := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	document := ToSpdx(NewSbom(readBuildInfo(t)), Tool{Vendor: "JFrog", Name: "jfrog-cli-go", Version: "2.40.0"}, "namespace", timestamp).(*spdxDocument)
+	assert.Equal(t, "SPDX-2.3", document.SpdxVersion)
+	assert.Equal(t, "my-build-7", document.Name)
+	assert.Equal(t, "https://jfrog.com/spdx/my-build-7-namespace", document.DocumentNamespace)
+	assert.Equal(t, spdxCreationInfo{Created: "2023-05-01T12:00:00Z", Creators: []string{"Organization: JFrog", "Tool: jfrog-cli-go-2.40.0"}}, document.CreationInfo)
+	// The build, 4 modules and 4 dependencies.
+	assert.Len(t, document.Packages, 9)
+	assert.Equal(t, "SPDXRef-build-my-build-7", document.Packages[0].SpdxId)
+	assert.Equal(t, "SPDXRef-pkg-maven-org.acme-api-1.0.0", document.Packages[1].SpdxId)
+	assert.Equal(t, []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: "pkg:maven/org.acme/api@1.0.0"}}, document.Packages[1].ExternalRefs)
+	assert.Len(t, document.Files, 1)
+	assert.Equal(t, "api-1.0.0.jar", document.Files[0].FileName)
+
+	assert.Contains(t, document.Relationships, spdxRelationship{SpdxElementId: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSpdxElement: "SPDXRef-build-my-build-7"})
+	assert.Contains(t, document.Relationships, spdxRelationship{SpdxElementId: "SPDXRef-build-my-build-7", RelationshipType: "CONTAINS", RelatedSpdxElement: "SPDXRef-pkg-maven-org.acme-api-1.0.0"})
+	assert.Contains(t, document.Relationships, spdxRelationship{SpdxElementId: "SPDXRef-pkg-maven-org.acme-api-1.0.0", RelationshipType: "GENERATES", RelatedSpdxElement: document.Files[0].SpdxId})
+	assert.Contains(t, document.Relationships, spdxRelationship{SpdxElementId: "SPDXRef-pkg-maven-org.acme-app-1.0.0", RelationshipType: "DEPENDS_ON", RelatedSpdxElement: "SPDXRef-pkg-maven-org.acme-api-1.0.0"})
+	ids := map[string]bool{}
+	for _, pkg := range document.Packages {
+		assert.False(t, ids[pkg.SpdxId], "duplicate SPDX ID "+pkg.SpdxId)
+		ids[pkg.SpdxId] = true
+	}
+}
+
+func TestCreateSbom(t *testing.T) {
+	for _, format := range []string{CycloneDxJson, SpdxJson} {
+		assert.NoError(t, ValidateFormat(format))
+		content, err := CreateSbom(readBuildInfo(t), format, time.Now())
+		assert.NoError(t, err)
+		assert.True(t, json.Valid(content))
+	}
+	assert.Error(t, ValidateFormat("xml"))
+}
@@ -0,0 +1,213 @@
+package buildshow
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	Tree  = "tree"
+	Table = "table"
+	Json  = "json"
+)
+
+func ValidateFormat(format string) error {
+	switch format {
+	case "", Tree, Table, Json:
+		return nil
+	default:
+		return errorutils.CheckErrorf("the --format option accepts the following values: %s, %s and %s, but received '%s'", Tree, Table, Json, format)
+	}
+}
+
+type BuildShowCommand struct {
+	buildConfiguration *utils.BuildConfiguration
+	module             string
+	format             string
+	validate           bool
+}
+
+func NewBuildShowCommand() *BuildShowCommand {
+	return &BuildShowCommand{}
+}
+
+func (bsc *BuildShowCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildShowCommand {
+	bsc.buildConfiguration = buildConfiguration
+	return bsc
+}
+
+// Sets the ID of the module to show. If not set, all the modules are shown.
+func (bsc *BuildShowCommand) SetModule(module string) *BuildShowCommand {
+	bsc.module = module
+	return bsc
+}
+
+func (bsc *BuildShowCommand) SetFormat(format string) *BuildShowCommand {
+	bsc.format = format
+	return bsc
+}
+
+// If set to true, the build-info is validated instead of shown.
+func (bsc *BuildShowCommand) SetValidate(validate bool) *BuildShowCommand {
+	bsc.validate = validate
+	return bsc
+}
+
+func (bsc *BuildShowCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (bsc *BuildShowCommand) CommandName() string {
+	return "rt_build_show"
+}
+
+func (bsc *BuildShowCommand) Run() error {
+	buildName, err := bsc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bsc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	buildInfo, err := getLocalBuildInfo(buildName, buildNumber, bsc.buildConfiguration.GetProject())
+	if err != nil {
+		return err
+	}
+	if bsc.module != "" {
+		if buildInfo.Modules, err = filterModules(buildInfo.Modules, bsc.module); err != nil {
+			return err
+		}
+	}
+	if bsc.validate {
+		return printIssues(Validate(buildInfo), buildName, buildNumber)
+	}
+	return printBuildInfo(buildInfo, bsc.format)
+}
+
+// Returns the build-info collected locally, merged from the partial build-info files, as it would be published by build-publish.
+func getLocalBuildInfo(buildName, buildNumber, projectKey string) (*buildinfo.BuildInfo, error) {
+	build, err := utils.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	buildInfo, err := build.ToBuildInfo()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(buildInfo.Modules) == 0 && len(buildInfo.Properties) == 0 && len(buildInfo.VcsList) == 0 {
+		return nil, errorutils.CheckErrorf("no local build-info was collected for build %s/%s", buildName, buildNumber)
+	}
+	sortBuildInfo(buildInfo)
+	return buildInfo, nil
+}
+
+// The partial build-info files are merged in no particular order, so the modules, artifacts and dependencies are sorted to keep the output stable.
+func sortBuildInfo(buildInfo *buildinfo.BuildInfo) {
+	sort.Slice(buildInfo.Modules, func(i, j int) bool { return buildInfo.Modules[i].Id < buildInfo.Modules[j].Id })
+	for _, module := range buildInfo.Modules {
+		artifacts, dependencies := module.Artifacts, module.Dependencies
+		sort.SliceStable(artifacts, func(i, j int) bool { return getArtifactPath(artifacts[i]) < getArtifactPath(artifacts[j]) })
+		sort.SliceStable(dependencies, func(i, j int) bool { return dependencies[i].Id < dependencies[j].Id })
+	}
+}
+
+func filterModules(modules []buildinfo.Module, moduleId string) ([]buildinfo.Module, error) {
+	for _, module := range modules {
+		if module.Id == moduleId {
+			return []buildinfo.Module{module}, nil
+		}
+	}
+	return nil, errorutils.CheckErrorf("module '%s' was not found in the local build-info", moduleId)
+}
+
+func getArtifactPath(artifact buildinfo.Artifact) string {
+	if artifact.Path != "" {
+		return artifact.Path
+	}
+	return artifact.Name
+}
+
+// Returns the environment variables of the build-info, sorted by their names.
+func getEnv(buildInfo *buildinfo.BuildInfo) (names, values []string) {
+	env := map[string]string{}
+	for key, value := range buildInfo.Properties {
+		if name := strings.TrimPrefix(key, buildinfo.BuildInfoEnvPrefix); name != key {
+			env[name] = value
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values = append(values, env[name])
+	}
+	return
+}
+
+func printBuildInfo(buildInfo *buildinfo.BuildInfo, format string) error {
+	switch format {
+	case Json:
+		content, err := json.MarshalIndent(buildInfo, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	case Table:
+		return printTable(buildInfo)
+	default:
+		log.Output(NewTree(buildInfo).String())
+		return nil
+	}
+}
+
+// The table representation of a module.
+type moduleRow struct {
+	Id           string `col-name:"Module"`
+	Type         string `col-name:"Type"`
+	Artifacts    string `col-name:"Artifacts"`
+	Dependencies string `col-name:"Dependencies"`
+}
+
+type envRow struct {
+	Name  string `col-name:"Name"`
+	Value string `col-name:"Value"`
+}
+
+type vcsRow struct {
+	Url      string `col-name:"URL"`
+	Branch   string `col-name:"Branch"`
+	Revision string `col-name:"Revision"`
+}
+
+func printTable(buildInfo *buildinfo.BuildInfo) error {
+	var modules []moduleRow
+	for _, module := range buildInfo.Modules {
+		modules = append(modules, moduleRow{Id: module.Id, Type: string(module.Type), Artifacts: strconv.Itoa(len(module.Artifacts)), Dependencies: strconv.Itoa(len(module.Dependencies))})
+	}
+	if err := coreutils.PrintTable(modules, "Modules", "No modules were collected", false); err != nil {
+		return err
+	}
+	var env []envRow
+	names, values := getEnv(buildInfo)
+	for i := range names {
+		env = append(env, envRow{Name: names[i], Value: values[i]})
+	}
+	if err := coreutils.PrintTable(env, "Environment Variables", "No environment variables were collected", false); err != nil {
+		return err
+	}
+	var vcs []vcsRow
+	for _, vcsDetails := range buildInfo.VcsList {
+		vcs = append(vcs, vcsRow{Url: vcsDetails.Url, Branch: vcsDetails.Branch, Revision: vcsDetails.Revision})
+	}
+	return coreutils.PrintTable(vcs, "VCS", "No VCS details were collected", false)
+}
@@ -0,0 +1,98 @@
+package buildshow
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func createBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:   "my-build",
+		Number: "1",
+		Modules: []buildinfo.Module{
+			{
+				Type: buildinfo.Maven,
+				Id:   "org.acme:app:1.0.0",
+				Artifacts: []buildinfo.Artifact{
+					{Name: "app.jar", Path: "org/acme/app/1.0.0/app.jar", Checksum: buildinfo.Checksum{Sha1: "app1", Md5: "appmd5"}},
+					{Name: "app.jar", Path: "org/acme/app/1.0.0/app.jar", Checksum: buildinfo.Checksum{Sha1: "app2", Md5: "appmd5"}},
+				},
+				Dependencies: []buildinfo.Dependency{
+					{Id: "junit:junit:4.13.2", Scopes: []string{"test"}, Checksum: buildinfo.Checksum{Sha1: "junit1", Md5: "junitmd5"}},
+					{Id: "org.slf4j:slf4j-api:1.7.36", Checksum: buildinfo.Checksum{Md5: "slf4jmd5"}},
+				},
+			},
+			{Type: buildinfo.Npm, Id: "web:1.0.0"},
+			{Type: buildinfo.Build, Id: "other-build/3"},
+		},
+		Properties: buildinfo.Env{"buildInfo.env.CI": "true", "buildInfo.licenseControl.runChecks": "false"},
+		VcsList:    []buildinfo.Vcs{{Url: "https://github.com/acme/app.git", Revision: "abc123", Branch: "main"}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.Equal(t, []Issue{
+		{Type: DuplicateArtifact, Module: "org.acme:app:1.0.0", Name: "org/acme/app/1.0.0/app.jar", Details: "the artifact was collected more than once, with different checksums"},
+		{Type: MissingChecksum, Module: "org.acme:app:1.0.0", Name: "org.slf4j:slf4j-api:1.7.36", Details: "missing sha1"},
+		{Type: EmptyModule, Module: "web:1.0.0", Details: "the module has no artifacts and no dependencies"},
+	}, Validate(createBuildInfo()))
+	assert.Equal(t, []Issue{{Type: NoModules, Details: "no modules were collected"}}, Validate(&buildinfo.BuildInfo{}))
+}
+
+func TestNewTree(t *testing.T) {
+	buildInfo := createBuildInfo()
+	buildInfo.Modules = buildInfo.Modules[:2]
+	expected := `Build my-build/1
+├── Modules
+│   ├── org.acme:app:1.0.0 (maven)
+│   │   ├── Artifacts
+│   │   │   ├── org/acme/app/1.0.0/app.jar (sha1: app1)
+│   │   │   └── org/acme/app/1.0.0/app.jar (sha1: app2)
+│   │   └── Dependencies
+│   │       ├── junit:junit:4.13.2 [test] (sha1: junit1)
+│   │       └── org.slf4j:slf4j-api:1.7.36 (no checksum)
+│   └── web:1.0.0 (npm)
+├── Environment
+│   └── CI=true
+└── VCS
+    └── https://github.com/acme/app.git @ abc123 (main)`
+	assert.Equal(t, expected, NewTree(buildInfo).String())
+}
+
+func TestFilterModules(t *testing.T) {
+	modules, err := filterModules(createBuildInfo().Modules, "web:1.0.0")
+	assert.NoError(t, err)
+	assert.Len(t, modules, 1)
+	assert.Equal(t, "web:1.0.0", modules[0].Id)
+	_, err = filterModules(createBuildInfo().Modules, "missing")
+	assert.ErrorContains(t, err, "module 'missing' was not found")
+}
+
+func TestGetLocalBuildInfo(t *testing.T) {
+	buildName, buildNumber := "build-show-test", strconv.FormatInt(time.Now().UnixNano(), 10)
+	defer func() {
+		assert.NoError(t, utils.RemoveBuildDir(buildName, buildNumber, ""))
+	}()
+	_, err := getLocalBuildInfo(buildName, buildNumber, "")
+	assert.ErrorContains(t, err, "no local build-info was collected")
+
+	for _, dependencyId := range []string{"b:1.0", "a:1.0"} {
+		dependency := buildinfo.Dependency{Id: dependencyId, Checksum: buildinfo.Checksum{Sha1: dependencyId}}
+		assert.NoError(t, utils.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildinfo.Partial) {
+			partial.ModuleType = buildinfo.Generic
+			partial.ModuleId = buildName
+			partial.Dependencies = []buildinfo.Dependency{dependency}
+		}))
+	}
+	buildInfo, err := getLocalBuildInfo(buildName, buildNumber, "")
+	assert.NoError(t, err)
+	assert.Len(t, buildInfo.Modules, 1)
+	// The dependencies are sorted.
+	assert.Equal(t, "a:1.0", buildInfo.Modules[0].Dependencies[0].Id)
+	assert.Equal(t, "b:1.0", buildInfo.Modules[0].Dependencies[1].Id)
+}
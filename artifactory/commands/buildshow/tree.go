@@ -0,0 +1,90 @@
+package buildshow
+
+import (
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+)
+
+// A node of the tree view of the build-info.
+type Node struct {
+	Name     string
+	Children []*Node
+}
+
+func (n *Node) add(name string) *Node {
+	child := &Node{Name: name}
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// Creates a tree view of the build-info's modules, artifacts, dependencies, environment variables and VCS details.
+func NewTree(buildInfo *buildinfo.BuildInfo) *Node {
+	root := &Node{Name: "Build " + buildInfo.Name + "/" + buildInfo.Number}
+	modules := root.add("Modules")
+	for _, module := range buildInfo.Modules {
+		moduleNode := modules.add(module.Id + " (" + string(module.Type) + ")")
+		if len(module.Artifacts) > 0 {
+			artifacts := moduleNode.add("Artifacts")
+			for _, artifact := range module.Artifacts {
+				artifacts.add(getArtifactPath(artifact) + getSha1Details(artifact.Sha1))
+			}
+		}
+		if len(module.Dependencies) > 0 {
+			dependencies := moduleNode.add("Dependencies")
+			for _, dependency := range module.Dependencies {
+				name := dependency.Id
+				if len(dependency.Scopes) > 0 {
+					name += " [" + strings.Join(dependency.Scopes, ", ") + "]"
+				}
+				dependencies.add(name + getSha1Details(dependency.Sha1))
+			}
+		}
+	}
+	if names, values := getEnv(buildInfo); len(names) > 0 {
+		env := root.add("Environment")
+		for i := range names {
+			env.add(names[i] + "=" + values[i])
+		}
+	}
+	if len(buildInfo.VcsList) > 0 {
+		vcs := root.add("VCS")
+		for _, vcsDetails := range buildInfo.VcsList {
+			name := vcsDetails.Url + " @ " + vcsDetails.Revision
+			if vcsDetails.Branch != "" {
+				name += " (" + vcsDetails.Branch + ")"
+			}
+			vcs.add(name)
+		}
+	}
+	return root
+}
+
+func getSha1Details(sha1 string) string {
+	if sha1 == "" {
+		return " (no checksum)"
+	}
+	return " (sha1: " + sha1 + ")"
+}
+
+func (n *Node) String() string {
+	return strings.Join(n.strings(), "\n")
+}
+
+func (n *Node) strings() []string {
+	strs := []string{n.Name}
+	for i, child := range n.Children {
+		prefix, innerPrefix := "├── ", "│   "
+		if i == len(n.Children)-1 {
+			prefix, innerPrefix = "└── ", "    "
+		}
+		for j, childStr := range child.strings() {
+			if j == 0 {
+				strs = append(strs, prefix+childStr)
+			} else {
+				strs = append(strs, innerPrefix+childStr)
+			}
+		}
+	}
+	return strs
+}
@@ -0,0 +1,91 @@
+package buildshow
+
+import (
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type IssueType string
+
+const (
+	NoModules           IssueType = "no-modules"
+	EmptyModule         IssueType = "empty-module"
+	DuplicateArtifact   IssueType = "duplicate-artifact"
+	DuplicateDependency IssueType = "duplicate-dependency"
+	MissingChecksum     IssueType = "missing-checksum"
+)
+
+// An issue found in the build-info, which should be fixed before it is published.
+type Issue struct {
+	Type    IssueType `col-name:"Issue"`
+	Module  string    `col-name:"Module"`
+	Name    string    `col-name:"Name"`
+	Details string    `col-name:"Details"`
+}
+
+// Validates the build-info, and returns the issues found in it.
+func Validate(buildInfo *buildinfo.BuildInfo) []Issue {
+	if len(buildInfo.Modules) == 0 {
+		return []Issue{{Type: NoModules, Details: "no modules were collected"}}
+	}
+	var issues []Issue
+	for _, module := range buildInfo.Modules {
+		// Modules of aggregated builds have no artifacts and dependencies.
+		if module.Type == buildinfo.Build {
+			continue
+		}
+		if len(module.Artifacts) == 0 && len(module.Dependencies) == 0 {
+			issues = append(issues, Issue{Type: EmptyModule, Module: module.Id, Details: "the module has no artifacts and no dependencies"})
+			continue
+		}
+		artifactPaths := map[string]int{}
+		for _, artifact := range module.Artifacts {
+			path := getArtifactPath(artifact)
+			artifactPaths[path]++
+			if artifactPaths[path] == 2 {
+				issues = append(issues, Issue{Type: DuplicateArtifact, Module: module.Id, Name: path, Details: "the artifact was collected more than once, with different checksums"})
+			}
+			if missing := getMissingChecksums(artifact.Checksum); missing != "" {
+				issues = append(issues, Issue{Type: MissingChecksum, Module: module.Id, Name: path, Details: "missing " + missing})
+			}
+		}
+		dependencyIds := map[string]int{}
+		for _, dependency := range module.Dependencies {
+			dependencyIds[dependency.Id]++
+			if dependencyIds[dependency.Id] == 2 {
+				issues = append(issues, Issue{Type: DuplicateDependency, Module: module.Id, Name: dependency.Id, Details: "the dependency was collected more than once, with different checksums or scopes"})
+			}
+			if missing := getMissingChecksums(dependency.Checksum); missing != "" {
+				issues = append(issues, Issue{Type: MissingChecksum, Module: module.Id, Name: dependency.Id, Details: "missing " + missing})
+			}
+		}
+	}
+	return issues
+}
+
+// Returns the names of the checksums which Artifactory requires, and are missing.
+func getMissingChecksums(checksum buildinfo.Checksum) string {
+	var missing []string
+	if checksum.Sha1 == "" {
+		missing = append(missing, "sha1")
+	}
+	if checksum.Md5 == "" {
+		missing = append(missing, "md5")
+	}
+	return strings.Join(missing, ", ")
+}
+
+func printIssues(issues []Issue, buildName, buildNumber string) error {
+	if len(issues) == 0 {
+		log.Info("No issues were found in the local build-info of build", buildName+"/"+buildNumber+".")
+		return nil
+	}
+	if err := coreutils.PrintTable(issues, "Build-Info Issues", "", false); err != nil {
+		return err
+	}
+	return errorutils.CheckErrorf("found %d issues in the local build-info of build %s/%s", len(issues), buildName, buildNumber)
+}
@@ -0,0 +1,200 @@
+package buildsign
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/utils/attestation"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The build-info property which holds the DSSE envelope of the build's signed statement. The property is deliberately
+	// not prefixed by buildinfo.BuildInfoEnvPrefix, so that the --env-include and --env-exclude options of build-publish,
+	// which filter only the properties with that prefix, always keep it.
+	SignatureProperty = "buildInfo.signature"
+	PredicateType     = "https://jfrog.com/build-info/signature/v1"
+)
+
+// The signed details of the build. The artifacts of the build are the subjects of the statement.
+type Predicate struct {
+	BuildName   string            `json:"buildName"`
+	BuildNumber string            `json:"buildNumber"`
+	Modules     []PredicateModule `json:"modules"`
+}
+
+type PredicateModule struct {
+	Id           string                `json:"id"`
+	Dependencies []attestation.Subject `json:"dependencies,omitempty"`
+}
+
+// Creates the statement which is signed for the build. Only the build's identity, artifacts and dependencies are included,
+// since the other parts of the build-info, such as the environment variables, may be filtered when the build is published.
+func NewStatement(buildInfo *buildinfo.BuildInfo) (*attestation.Statement, error) {
+	predicate := Predicate{BuildName: buildInfo.Name, BuildNumber: buildInfo.Number, Modules: []PredicateModule{}}
+	subjects := []attestation.Subject{}
+	for _, module := range buildInfo.Modules {
+		for _, artifact := range module.Artifacts {
+			path := artifact.Path
+			if path == "" {
+				path = artifact.Name
+			}
+			subjects = append(subjects, attestation.Subject{Name: module.Id + "/" + path, Digest: attestation.NewDigest(artifact.Checksum)})
+		}
+		predicateModule := PredicateModule{Id: module.Id}
+		for _, dependency := range module.Dependencies {
+			predicateModule.Dependencies = append(predicateModule.Dependencies, attestation.Subject{Name: dependency.Id, Digest: attestation.NewDigest(dependency.Checksum)})
+		}
+		sortSubjects(predicateModule.Dependencies)
+		predicate.Modules = append(predicate.Modules, predicateModule)
+	}
+	sortSubjects(subjects)
+	sort.Slice(predicate.Modules, func(i, j int) bool { return predicate.Modules[i].Id < predicate.Modules[j].Id })
+	return attestation.NewStatement(subjects, PredicateType, predicate)
+}
+
+func sortSubjects(subjects []attestation.Subject) {
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i].Name < subjects[j].Name })
+}
+
+// Signs the build-info, and returns the JSON of the DSSE envelope.
+func Sign(buildInfo *buildinfo.BuildInfo, signer attestation.Signer) (string, error) {
+	statement, err := NewStatement(buildInfo)
+	if err != nil {
+		return "", err
+	}
+	envelope, err := attestation.SignStatement(statement, signer)
+	if err != nil {
+		return "", err
+	}
+	content, err := json.Marshal(envelope)
+	return string(content), errorutils.CheckError(err)
+}
+
+// Signs the local build-info, and saves the signature as a property of the build-info, so that it is published with the build.
+func SignLocalBuildInfo(buildConfiguration *utils.BuildConfiguration, signKey string) error {
+	signer, err := attestation.NewSigner(signKey)
+	if err != nil {
+		return err
+	}
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	// The number of a build configured in a config file is determined only when it is published.
+	if buildConfiguration.IsLoadedFromConfigFile() {
+		return errorutils.CheckErrorf("signing the build-info requires providing the build number")
+	}
+	build, err := utils.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, buildConfiguration.GetProject())
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	buildInfo, err := build.ToBuildInfo()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	signature, err := Sign(buildInfo, signer)
+	if err != nil {
+		return err
+	}
+	log.Info("Signed the build-info of build", buildName+"/"+buildNumber+".")
+	return utils.SavePartialBuildInfo(buildName, buildNumber, buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.Env = buildinfo.Env{SignatureProperty: signature}
+	})
+}
+
+// Verifies the signature of the build-info, and that the signed statement matches the build-info.
+func Verify(buildInfo *buildinfo.BuildInfo, verifier attestation.Verifier) error {
+	signature := buildInfo.Properties[SignatureProperty]
+	if signature == "" {
+		return errorutils.CheckErrorf("build %s/%s isn't signed", buildInfo.Name, buildInfo.Number)
+	}
+	envelope, err := attestation.ParseEnvelope([]byte(signature))
+	if err != nil {
+		return err
+	}
+	signed, err := attestation.VerifyStatement(envelope, verifier)
+	if err != nil {
+		return err
+	}
+	if signed.PredicateType != PredicateType {
+		return errorutils.CheckErrorf("the signed statement's predicate type is '%s', while '%s' is expected", signed.PredicateType, PredicateType)
+	}
+	signedPredicate := &Predicate{}
+	if err = json.Unmarshal(signed.Predicate, signedPredicate); err != nil {
+		return errorutils.CheckErrorf("failed to parse the signed statement's predicate: %s", err.Error())
+	}
+	actual, err := NewStatement(buildInfo)
+	if err != nil {
+		return err
+	}
+	actualPredicate := &Predicate{}
+	if err = json.Unmarshal(actual.Predicate, actualPredicate); err != nil {
+		return errorutils.CheckError(err)
+	}
+	mismatches := compare(signed.Subject, signedPredicate, actual.Subject, actualPredicate)
+	if len(mismatches) > 0 {
+		return errorutils.CheckErrorf("the signature is valid, but the build-info was modified after it was signed:\n%s", strings.Join(mismatches, "\n"))
+	}
+	return nil
+}
+
+// Returns descriptions of the differences between the signed statement and the actual build-info.
+func compare(signedSubjects []attestation.Subject, signedPredicate *Predicate, actualSubjects []attestation.Subject, actualPredicate *Predicate) []string {
+	var mismatches []string
+	if signedPredicate.BuildName != actualPredicate.BuildName || signedPredicate.BuildNumber != actualPredicate.BuildNumber {
+		mismatches = append(mismatches, "the signature was created for build "+signedPredicate.BuildName+"/"+signedPredicate.BuildNumber)
+	}
+	mismatches = append(mismatches, compareSubjects("artifact", signedSubjects, actualSubjects)...)
+	actualModules := map[string]PredicateModule{}
+	for _, module := range actualPredicate.Modules {
+		actualModules[module.Id] = module
+	}
+	for _, signedModule := range signedPredicate.Modules {
+		actualModule, exists := actualModules[signedModule.Id]
+		if !exists {
+			mismatches = append(mismatches, "signed module "+signedModule.Id+" is missing")
+			continue
+		}
+		delete(actualModules, signedModule.Id)
+		mismatches = append(mismatches, compareSubjects("dependency", signedModule.Dependencies, actualModule.Dependencies)...)
+	}
+	for _, module := range actualPredicate.Modules {
+		if _, exists := actualModules[module.Id]; exists {
+			mismatches = append(mismatches, "module "+module.Id+" isn't signed")
+		}
+	}
+	return mismatches
+}
+
+func compareSubjects(kind string, signed, actual []attestation.Subject) []string {
+	var mismatches []string
+	actualDigests := map[string]map[string]string{}
+	for _, subject := range actual {
+		actualDigests[subject.Name] = subject.Digest
+	}
+	for _, subject := range signed {
+		digest, exists := actualDigests[subject.Name]
+		switch {
+		case !exists:
+			mismatches = append(mismatches, "signed "+kind+" "+subject.Name+" is missing")
+		case !attestation.MatchDigests(subject.Digest, digest):
+			mismatches = append(mismatches, "the checksums of "+kind+" "+subject.Name+" don't match the signed checksums")
+		}
+		delete(actualDigests, subject.Name)
+	}
+	for _, subject := range actual {
+		if _, exists := actualDigests[subject.Name]; exists {
+			mismatches = append(mismatches, kind+" "+subject.Name+" isn't signed")
+		}
+	}
+	return mismatches
+}
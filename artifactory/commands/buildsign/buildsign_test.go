@@ -0,0 +1,115 @@
+package buildsign
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/attestation"
+	"github.com/stretchr/testify/assert"
+)
+
+func createKeys(t *testing.T) (attestation.Signer, attestation.Verifier) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateDer, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	publicDer, err := x509.MarshalPKIXPublicKey(key.Public())
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	privateKeyPath, publicKeyPath := filepath.Join(dir, "key.pem"), filepath.Join(dir, "key.pub")
+	assert.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDer}), 0600))
+	assert.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer}), 0644))
+	signer, err := attestation.NewSigner(privateKeyPath)
+	assert.NoError(t, err)
+	verifier, err := attestation.NewVerifier(publicKeyPath)
+	assert.NoError(t, err)
+	return signer, verifier
+}
+
+func createBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:   "my-build",
+		Number: "1",
+		Modules: []buildinfo.Module{
+			{
+				Id:           "org.acme:app:1.0.0",
+				Artifacts:    []buildinfo.Artifact{{Name: "app.jar", Path: "org/acme/app/1.0.0/app.jar", Checksum: buildinfo.Checksum{Sha1: "app1", Sha256: "app256"}}},
+				Dependencies: []buildinfo.Dependency{{Id: "junit:junit:4.13.2", Checksum: buildinfo.Checksum{Sha1: "junit1", Md5: "junitmd5"}}},
+			},
+		},
+		Properties: buildinfo.Env{"buildInfo.env.CI": "true"},
+	}
+}
+
+func signBuildInfo(t *testing.T, buildInfo *buildinfo.BuildInfo, signer attestation.Signer) {
+	signature, err := Sign(buildInfo, signer)
+	assert.NoError(t, err)
+	buildInfo.Properties[SignatureProperty] = signature
+}
+
+func TestNewStatement(t *testing.T) {
+	statement, err := NewStatement(createBuildInfo())
+	assert.NoError(t, err)
+	assert.Equal(t, PredicateType, statement.PredicateType)
+	assert.Equal(t, []attestation.Subject{{Name: "org.acme:app:1.0.0/org/acme/app/1.0.0/app.jar", Digest: map[string]string{"sha1": "app1", "sha256": "app256"}}}, statement.Subject)
+	assert.JSONEq(t, `{"buildName":"my-build","buildNumber":"1","modules":[{"id":"org.acme:app:1.0.0","dependencies":[{"name":"junit:junit:4.13.2","digest":{"sha1":"junit1","md5":"junitmd5"}}]}]}`, string(statement.Predicate))
+}
+
+func TestVerify(t *testing.T) {
+	signer, verifier := createKeys(t)
+	buildInfo := createBuildInfo()
+	signBuildInfo(t, buildInfo, signer)
+	assert.NoError(t, Verify(buildInfo, verifier))
+
+	// Environment variables filtered when publishing don't affect the signature.
+	delete(buildInfo.Properties, "buildInfo.env.CI")
+	assert.NoError(t, Verify(buildInfo, verifier))
+
+	// Published build-info without the SHA-256 checksums is still verified by the SHA-1 checksums.
+	buildInfo.Modules[0].Artifacts[0].Sha256 = ""
+	assert.NoError(t, Verify(buildInfo, verifier))
+}
+
+func TestSignatureKeptByEnvFilters(t *testing.T) {
+	signer, verifier := createKeys(t)
+	buildInfo := createBuildInfo()
+	signBuildInfo(t, buildInfo, signer)
+	// Filter the environment the way build-publish does, with patterns which match the signature property's name.
+	assert.NoError(t, buildInfo.IncludeEnv("CI"))
+	assert.NoError(t, buildInfo.ExcludeEnv("*", "*signature*", "buildInfo*"))
+	assert.NotContains(t, buildInfo.Properties, "buildInfo.env.CI")
+	assert.Contains(t, buildInfo.Properties, SignatureProperty)
+	assert.NoError(t, Verify(buildInfo, verifier))
+}
+
+func TestVerifyModifiedBuildInfo(t *testing.T) {
+	signer, verifier := createKeys(t)
+	buildInfo := createBuildInfo()
+	signBuildInfo(t, buildInfo, signer)
+	buildInfo.Number = "2"
+	buildInfo.Modules[0].Artifacts[0].Sha1 = "modified"
+	buildInfo.Modules[0].Dependencies = append(buildInfo.Modules[0].Dependencies, buildinfo.Dependency{Id: "added:added:1.0", Checksum: buildinfo.Checksum{Sha1: "added1"}})
+	buildInfo.Modules = append(buildInfo.Modules, buildinfo.Module{Id: "added-module"})
+	err := Verify(buildInfo, verifier)
+	assert.ErrorContains(t, err, "the build-info was modified after it was signed")
+	assert.ErrorContains(t, err, "the signature was created for build my-build/1")
+	assert.ErrorContains(t, err, "the checksums of artifact org.acme:app:1.0.0/org/acme/app/1.0.0/app.jar don't match the signed checksums")
+	assert.ErrorContains(t, err, "dependency added:added:1.0 isn't signed")
+	assert.ErrorContains(t, err, "module added-module isn't signed")
+}
+
+func TestVerifyUnsignedBuildInfo(t *testing.T) {
+	_, verifier := createKeys(t)
+	assert.ErrorContains(t, Verify(createBuildInfo(), verifier), "build my-build/1 isn't signed")
+
+	otherSigner, _ := createKeys(t)
+	buildInfo := createBuildInfo()
+	signBuildInfo(t, buildInfo, otherSigner)
+	assert.ErrorContains(t, Verify(buildInfo, verifier), "none of the 1 signatures of the envelope was created by the key")
+}
@@ -0,0 +1,78 @@
+package buildsign
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/attestation"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type BuildVerifyCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	publicKeyPath      string
+}
+
+func NewBuildVerifyCommand() *BuildVerifyCommand {
+	return &BuildVerifyCommand{}
+}
+
+func (bvc *BuildVerifyCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildVerifyCommand {
+	bvc.serverDetails = serverDetails
+	return bvc
+}
+
+func (bvc *BuildVerifyCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildVerifyCommand {
+	bvc.buildConfiguration = buildConfiguration
+	return bvc
+}
+
+func (bvc *BuildVerifyCommand) SetPublicKeyPath(publicKeyPath string) *BuildVerifyCommand {
+	bvc.publicKeyPath = publicKeyPath
+	return bvc
+}
+
+func (bvc *BuildVerifyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bvc.serverDetails, nil
+}
+
+func (bvc *BuildVerifyCommand) CommandName() string {
+	return "rt_build_verify"
+}
+
+func (bvc *BuildVerifyCommand) Run() error {
+	verifier, err := attestation.NewVerifier(bvc.publicKeyPath)
+	if err != nil {
+		return err
+	}
+	buildName, err := bvc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bvc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(bvc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	params := services.NewBuildInfoParams()
+	params.BuildName = buildName
+	params.BuildNumber = buildNumber
+	params.ProjectKey = bvc.buildConfiguration.GetProject()
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(params)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("build %s/%s was not found in Artifactory", buildName, buildNumber)
+	}
+	if err = Verify(&publishedBuildInfo.BuildInfo, verifier); err != nil {
+		return err
+	}
+	log.Info("Build", buildName+"/"+buildNumber, "is signed by key", verifier.KeyId()+", and wasn't modified since it was signed.")
+	return nil
+}
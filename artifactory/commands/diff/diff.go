@@ -0,0 +1,352 @@
+package diff
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/builddiff"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type ChangeType string
+
+const (
+	// The file exists in the target only.
+	Added ChangeType = "added"
+	// The file exists in the source only.
+	Removed ChangeType = "removed"
+	// The file exists on both sides, with different checksums.
+	Modified ChangeType = "modified"
+	// The file exists on both sides with the same checksum, but with different properties.
+	PropsChanged ChangeType = "props-changed"
+)
+
+// One side of the comparison. The pattern is a repository path, optionally with wildcards.
+// If a build is provided, in the form of build-name/build-number, only the artifacts of the build are compared.
+type Side struct {
+	Pattern string
+	Build   string
+	Project string
+}
+
+func (s Side) String() string {
+	if s.Build == "" {
+		return s.Pattern
+	}
+	return s.Pattern + " (build " + s.Build + ")"
+}
+
+// Returns the path which the files of this side are compared by, which is the pattern up to its first wildcard.
+func (s Side) getRoot() string {
+	pattern := s.getPattern()
+	if wildcard := strings.IndexAny(pattern, "*?"); wildcard >= 0 {
+		pattern = pattern[:wildcard]
+	}
+	return pattern[:strings.LastIndex(pattern, "/")+1]
+}
+
+// Whether the side is the artifacts of a build, without a pattern.
+// The paths of such artifacts usually include the build's version, so they're compared by their modules and names instead.
+func (s Side) isBuildOnly() bool {
+	return s.Pattern == "" && s.Build != ""
+}
+
+// A pattern without wildcards is treated as a directory.
+func (s Side) getPattern() string {
+	switch {
+	case s.Pattern == "":
+		return "*"
+	case !strings.ContainsAny(s.Pattern, "*?") && !strings.HasSuffix(s.Pattern, "/"):
+		return s.Pattern + "/"
+	default:
+		return s.Pattern
+	}
+}
+
+type Difference struct {
+	Change      ChangeType          `json:"change"`
+	Path        string              `json:"path"`
+	SourceSha1  string              `json:"sourceSha1,omitempty"`
+	TargetSha1  string              `json:"targetSha1,omitempty"`
+	SourceProps map[string][]string `json:"sourceProps,omitempty"`
+	TargetProps map[string][]string `json:"targetProps,omitempty"`
+}
+
+// The table representation of a difference.
+type differenceRow struct {
+	Change  ChangeType `col-name:"Change"`
+	Path    string     `col-name:"Path"`
+	Details string     `col-name:"Details"`
+}
+
+// Compares the source and target files, which are mapped by their relative paths.
+// The properties with the provided keys are ignored. The differences are sorted by their paths.
+func Compare(sourceFiles, targetFiles map[string]*utils.SearchResult, ignoreProps []string) []Difference {
+	ignoredKeys := make(map[string]bool, len(ignoreProps))
+	for _, key := range ignoreProps {
+		ignoredKeys[strings.TrimSpace(key)] = true
+	}
+	differences := []Difference{}
+	for relPath, source := range sourceFiles {
+		target, exists := targetFiles[relPath]
+		switch {
+		case !exists:
+			differences = append(differences, Difference{Change: Removed, Path: relPath, SourceSha1: source.Sha1})
+		case source.Sha1 != target.Sha1:
+			differences = append(differences, Difference{Change: Modified, Path: relPath, SourceSha1: source.Sha1, TargetSha1: target.Sha1})
+		default:
+			sourceProps, targetProps := filterProps(source.Props, ignoredKeys), filterProps(target.Props, ignoredKeys)
+			if !equalProps(sourceProps, targetProps) {
+				differences = append(differences, Difference{Change: PropsChanged, Path: relPath, SourceProps: sourceProps, TargetProps: targetProps})
+			}
+		}
+	}
+	for relPath, target := range targetFiles {
+		if _, exists := sourceFiles[relPath]; !exists {
+			differences = append(differences, Difference{Change: Added, Path: relPath, TargetSha1: target.Sha1})
+		}
+	}
+	sort.Slice(differences, func(i, j int) bool { return differences[i].Path < differences[j].Path })
+	return differences
+}
+
+// Returns the properties without the ignored keys, with sorted values.
+func filterProps(props map[string][]string, ignoredKeys map[string]bool) map[string][]string {
+	filtered := make(map[string][]string, len(props))
+	for key, values := range props {
+		if !ignoredKeys[key] {
+			sortedValues := append([]string{}, values...)
+			sort.Strings(sortedValues)
+			filtered[key] = sortedValues
+		}
+	}
+	return filtered
+}
+
+func equalProps(source, target map[string][]string) bool {
+	if len(source) != len(target) {
+		return false
+	}
+	for key, values := range source {
+		targetValues, exists := target[key]
+		if !exists || strings.Join(values, ",") != strings.Join(targetValues, ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the keys of the properties which differ between the source and the target, sorted.
+func getChangedPropsKeys(source, target map[string][]string) []string {
+	var keys []string
+	for key, values := range source {
+		if strings.Join(values, ",") != strings.Join(target[key], ",") {
+			keys = append(keys, key)
+		}
+	}
+	for key := range target {
+		if _, exists := source[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type DiffCommand struct {
+	serverDetails          *config.ServerDetails
+	source                 Side
+	target                 Side
+	ignoreProps            []string
+	retries                int
+	retryWaitTimeMilliSecs int
+	differences            []Difference
+}
+
+func NewDiffCommand() *DiffCommand {
+	return &DiffCommand{}
+}
+
+func (dc *DiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *DiffCommand {
+	dc.serverDetails = serverDetails
+	return dc
+}
+
+func (dc *DiffCommand) SetSource(source Side) *DiffCommand {
+	dc.source = source
+	return dc
+}
+
+func (dc *DiffCommand) SetTarget(target Side) *DiffCommand {
+	dc.target = target
+	return dc
+}
+
+func (dc *DiffCommand) SetIgnoreProps(ignoreProps []string) *DiffCommand {
+	dc.ignoreProps = ignoreProps
+	return dc
+}
+
+func (dc *DiffCommand) SetRetries(retries int) *DiffCommand {
+	dc.retries = retries
+	return dc
+}
+
+func (dc *DiffCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *DiffCommand {
+	dc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return dc
+}
+
+func (dc *DiffCommand) Differences() []Difference {
+	return dc.differences
+}
+
+func (dc *DiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dc.serverDetails, nil
+}
+
+func (dc *DiffCommand) CommandName() string {
+	return "rt_diff"
+}
+
+func (dc *DiffCommand) Run() error {
+	log.Info("Comparing", dc.source.String(), "with", dc.target.String()+"...")
+	sourceFiles, err := dc.search(dc.source)
+	if err != nil {
+		return err
+	}
+	targetFiles, err := dc.search(dc.target)
+	if err != nil {
+		return err
+	}
+	dc.differences = Compare(sourceFiles, targetFiles, dc.ignoreProps)
+	log.Info("Found", len(dc.differences), "differences.")
+	return nil
+}
+
+// Searches the files of one side, and maps them by their paths relative to the side's root.
+// The artifacts of a build without a pattern are mapped by their modules and names, in the form of module-name/artifact-name.
+func (dc *DiffCommand) search(side Side) (files map[string]*utils.SearchResult, err error) {
+	var artifactKeys map[artifactId]string
+	if side.isBuildOnly() {
+		if artifactKeys, err = dc.getBuildArtifactKeys(side); err != nil {
+			return nil, err
+		}
+	}
+	searchSpec := spec.NewBuilder().Pattern(side.getPattern()).Build(side.Build).Project(side.Project).Recursive(true).BuildSpec()
+	searchCommand := generic.NewSearchCommand()
+	searchCommand.SetServerDetails(dc.serverDetails).SetSpec(searchSpec).SetRetries(dc.retries).SetRetryWaitMilliSecs(dc.retryWaitTimeMilliSecs)
+	reader, err := searchCommand.Search()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		e := reader.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	root := side.getRoot()
+	files = make(map[string]*utils.SearchResult)
+	for result := new(utils.SearchResult); reader.NextRecord(result) == nil; result = new(utils.SearchResult) {
+		if result.Type == "folder" {
+			continue
+		}
+		key, exists := artifactKeys[artifactId{name: path.Base(result.Path), sha1: result.Sha1}]
+		if !exists {
+			key = strings.TrimPrefix(result.Path, root)
+		}
+		files[key] = result
+	}
+	return files, reader.GetError()
+}
+
+// Identifies an artifact of a build by its file name and checksum, since the build info doesn't always include the artifacts' paths.
+type artifactId struct {
+	name string
+	sha1 string
+}
+
+func (dc *DiffCommand) getBuildArtifactKeys(side Side) (map[artifactId]string, error) {
+	buildName, buildNumber, err := servicesutils.ParseNameAndVersion(side.Build, true)
+	if err != nil {
+		return nil, err
+	}
+	servicesManager, err := utils.CreateServiceManager(dc.serverDetails, dc.retries, dc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return nil, err
+	}
+	params := services.NewBuildInfoParams()
+	params.BuildName = buildName
+	params.BuildNumber = buildNumber
+	params.ProjectKey = side.Project
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(params)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s was not found in Artifactory", side.Build)
+	}
+	return mapBuildArtifacts(publishedBuildInfo.BuildInfo.Modules), nil
+}
+
+// Maps the artifacts of the modules to their keys, which are the module IDs without the versions, followed by the artifact names.
+// Modules are matched between builds in the same way as by the build-diff command.
+func mapBuildArtifacts(modules []buildinfo.Module) map[artifactId]string {
+	keys := make(map[artifactId]string)
+	for _, module := range modules {
+		moduleName, _ := builddiff.SplitId(module.Id)
+		for _, artifact := range module.Artifacts {
+			keys[artifactId{name: artifact.Name, sha1: artifact.Sha1}] = moduleName + "/" + artifact.Name
+		}
+	}
+	return keys
+}
+
+func ValidateFormat(format string) error {
+	switch format {
+	case "", "table", "json":
+		return nil
+	default:
+		return errorutils.CheckErrorf("the --format option accepts the following values: table and json, but received '%s'", format)
+	}
+}
+
+// Prints the differences as a table or as JSON. The format should be validated by ValidateFormat.
+func PrintDifferences(differences []Difference, format string) error {
+	if format == "json" {
+		content, err := json.MarshalIndent(differences, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(string(content))
+		return nil
+	}
+	rows := make([]differenceRow, 0, len(differences))
+	for _, difference := range differences {
+		rows = append(rows, differenceRow{Change: difference.Change, Path: difference.Path, Details: getDetails(difference)})
+	}
+	return coreutils.PrintTable(rows, "Differences", "No differences were found", false)
+}
+
+func getDetails(difference Difference) string {
+	switch difference.Change {
+	case Modified:
+		return "sha1: " + difference.SourceSha1 + " -> " + difference.TargetSha1
+	case PropsChanged:
+		return "properties: " + strings.Join(getChangedPropsKeys(difference.SourceProps, difference.TargetProps), ", ")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,77 @@
+package diff
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRoot(t *testing.T) {
+	tests := []struct {
+		side            Side
+		expectedPattern string
+		expectedRoot    string
+	}{
+		{Side{Pattern: "repo-a/path"}, "repo-a/path/", "repo-a/path/"},
+		{Side{Pattern: "repo-a/path/"}, "repo-a/path/", "repo-a/path/"},
+		{Side{Pattern: "repo-a/path/*.zip"}, "repo-a/path/*.zip", "repo-a/path/"},
+		{Side{Pattern: "repo-a/pa*/file"}, "repo-a/pa*/file", "repo-a/"},
+		{Side{Build: "build/1"}, "*", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.side.String(), func(t *testing.T) {
+			assert.Equal(t, test.expectedPattern, test.side.getPattern())
+			assert.Equal(t, test.expectedRoot, test.side.getRoot())
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	sourceFiles := map[string]*utils.SearchResult{
+		"same.zip":     {Sha1: "1", Props: map[string][]string{"build.number": {"1"}}},
+		"modified.zip": {Sha1: "2"},
+		"props.zip":    {Sha1: "3", Props: map[string][]string{"status": {"a", "b"}, "owner": {"x"}}},
+		"removed.zip":  {Sha1: "4"},
+	}
+	targetFiles := map[string]*utils.SearchResult{
+		"same.zip":     {Sha1: "1", Props: map[string][]string{"build.number": {"2"}}},
+		"modified.zip": {Sha1: "5"},
+		"props.zip":    {Sha1: "3", Props: map[string][]string{"status": {"b", "a"}, "approved": {"true"}}},
+		"added.zip":    {Sha1: "6"},
+	}
+	differences := Compare(sourceFiles, targetFiles, []string{"build.number"})
+	assert.Equal(t, []Difference{
+		{Change: Added, Path: "added.zip", TargetSha1: "6"},
+		{Change: Modified, Path: "modified.zip", SourceSha1: "2", TargetSha1: "5"},
+		{Change: PropsChanged, Path: "props.zip",
+			SourceProps: map[string][]string{"status": {"a", "b"}, "owner": {"x"}},
+			TargetProps: map[string][]string{"status": {"a", "b"}, "approved": {"true"}}},
+		{Change: Removed, Path: "removed.zip", SourceSha1: "4"},
+	}, differences)
+	assert.Equal(t, "properties: approved, owner", getDetails(differences[2]))
+
+	// Without ignoring the build number, the properties of same.zip differ.
+	differences = Compare(sourceFiles, targetFiles, nil)
+	assert.Len(t, differences, 5)
+	assert.Empty(t, Compare(sourceFiles, sourceFiles, nil))
+}
+
+func TestMapBuildArtifacts(t *testing.T) {
+	modules := []buildinfo.Module{
+		{Id: "org.acme:app:1.0", Artifacts: []buildinfo.Artifact{{Name: "app.jar", Checksum: buildinfo.Checksum{Sha1: "1"}}, {Name: "app.pom", Checksum: buildinfo.Checksum{Sha1: "2"}}}},
+		{Id: "generic-module", Artifacts: []buildinfo.Artifact{{Name: "app.zip", Checksum: buildinfo.Checksum{Sha1: "3"}}}},
+	}
+	assert.Equal(t, map[artifactId]string{
+		{name: "app.jar", sha1: "1"}: "org.acme:app/app.jar",
+		{name: "app.pom", sha1: "2"}: "org.acme:app/app.pom",
+		{name: "app.zip", sha1: "3"}: "generic-module/app.zip",
+	}, mapBuildArtifacts(modules))
+
+	// The artifacts of builds whose paths include their versions are matched by their modules.
+	sourceKey := mapBuildArtifacts(modules)[artifactId{name: "app.jar", sha1: "1"}]
+	modules[0].Id = "org.acme:app:2.0"
+	targetKey := mapBuildArtifacts(modules)[artifactId{name: "app.jar", sha1: "1"}]
+	assert.Equal(t, sourceKey, targetKey)
+}
@@ -0,0 +1,579 @@
+package filesync
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type Direction string
+
+const (
+	// Mirror the local directory to the repository path, including deletions.
+	Push Direction = "push"
+	// Mirror the repository path to the local directory, including deletions.
+	Pull Direction = "pull"
+	// Copy the missing files to each side. Nothing is deleted, and modified files are resolved by the conflict policy.
+	Both Direction = "both"
+)
+
+type ConflictPolicy string
+
+const (
+	Fail   ConflictPolicy = "fail"
+	Local  ConflictPolicy = "local"
+	Remote ConflictPolicy = "remote"
+	Newer  ConflictPolicy = "newer"
+	Skip   ConflictPolicy = "skip"
+)
+
+type ActionType string
+
+const (
+	Upload       ActionType = "upload"
+	Download     ActionType = "download"
+	DeleteRemote ActionType = "delete remote"
+	DeleteLocal  ActionType = "delete local"
+	Conflict     ActionType = "conflict"
+)
+
+const artifactoryTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+func GetDirection(direction string) (Direction, error) {
+	switch Direction(direction) {
+	case "":
+		return Push, nil
+	case Push, Pull, Both:
+		return Direction(direction), nil
+	default:
+		return "", errorutils.CheckErrorf("the --direction option accepts the following values: push, pull and both, but received '%s'", direction)
+	}
+}
+
+func GetConflictPolicy(policy string) (ConflictPolicy, error) {
+	switch ConflictPolicy(policy) {
+	case "":
+		return Fail, nil
+	case Fail, Local, Remote, Newer, Skip:
+		return ConflictPolicy(policy), nil
+	default:
+		return "", errorutils.CheckErrorf("the --conflict option accepts the following values: fail, local, remote, newer and skip, but received '%s'", policy)
+	}
+}
+
+// The state of a single file on one side of the sync, identified by its path relative to the synced directory.
+type FileState struct {
+	Path     string
+	Sha1     string
+	Modified time.Time
+}
+
+// A single step of the sync plan.
+type Action struct {
+	Type   ActionType `col-name:"Action"`
+	Path   string     `col-name:"Path"`
+	Reason string     `col-name:"Reason"`
+}
+
+// Computes the actions needed to sync the local and remote files, by comparing their checksums.
+// Returns an error if conflicts exist and the conflict policy is 'fail'.
+func CreatePlan(localFiles, remoteFiles map[string]*FileState, direction Direction, policy ConflictPolicy) ([]Action, error) {
+	var plan []Action
+	var conflicts []string
+	for relPath, local := range localFiles {
+		remote, exists := remoteFiles[relPath]
+		switch {
+		case !exists && direction != Pull:
+			plan = append(plan, Action{Type: Upload, Path: relPath, Reason: "missing in Artifactory"})
+		case !exists:
+			plan = append(plan, Action{Type: DeleteLocal, Path: relPath, Reason: "missing in Artifactory"})
+		case local.Sha1 == remote.Sha1:
+			continue
+		case direction == Push:
+			plan = append(plan, Action{Type: Upload, Path: relPath, Reason: "modified"})
+		case direction == Pull:
+			plan = append(plan, Action{Type: Download, Path: relPath, Reason: "modified"})
+		default:
+			action := resolveConflict(local, remote, policy)
+			if action.Type == Conflict && policy == Fail {
+				conflicts = append(conflicts, relPath)
+			}
+			plan = append(plan, action)
+		}
+	}
+	for relPath := range remoteFiles {
+		if _, exists := localFiles[relPath]; exists {
+			continue
+		}
+		if direction == Push {
+			plan = append(plan, Action{Type: DeleteRemote, Path: relPath, Reason: "missing locally"})
+		} else {
+			plan = append(plan, Action{Type: Download, Path: relPath, Reason: "missing locally"})
+		}
+	}
+	sort.Slice(plan, func(i, j int) bool { return plan[i].Path < plan[j].Path })
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return plan, errorutils.CheckErrorf("the following files were modified both locally and in Artifactory: %s. Use the --conflict option to choose how to resolve the conflicts", strings.Join(conflicts, ", "))
+	}
+	return plan, nil
+}
+
+func resolveConflict(local, remote *FileState, policy ConflictPolicy) Action {
+	action := Action{Type: Conflict, Path: local.Path, Reason: "modified on both sides"}
+	switch policy {
+	case Local:
+		action.Type, action.Reason = Upload, "conflict resolved by the local file"
+	case Remote:
+		action.Type, action.Reason = Download, "conflict resolved by the Artifactory file"
+	case Newer:
+		if local.Modified.After(remote.Modified) {
+			action.Type, action.Reason = Upload, "conflict resolved by the newer local file"
+		} else {
+			action.Type, action.Reason = Download, "conflict resolved by the newer Artifactory file"
+		}
+	case Skip:
+		action.Reason = "modified on both sides, skipped"
+	}
+	return action
+}
+
+// Returns the number of actions of the provided type.
+func countActions(plan []Action, actionType ActionType) (count int) {
+	for _, action := range plan {
+		if action.Type == actionType {
+			count++
+		}
+	}
+	return
+}
+
+type SyncCommand struct {
+	serverDetails          *config.ServerDetails
+	localDir               string
+	repoPath               string
+	direction              Direction
+	conflictPolicy         ConflictPolicy
+	dryRun                 bool
+	quiet                  bool
+	retries                int
+	retryWaitTimeMilliSecs int
+	uploadConfiguration    *utils.UploadConfiguration
+	downloadConfiguration  *utils.DownloadConfiguration
+	plan                   []Action
+}
+
+func NewSyncCommand() *SyncCommand {
+	return &SyncCommand{}
+}
+
+func (sc *SyncCommand) SetServerDetails(serverDetails *config.ServerDetails) *SyncCommand {
+	sc.serverDetails = serverDetails
+	return sc
+}
+
+func (sc *SyncCommand) SetLocalDir(localDir string) *SyncCommand {
+	sc.localDir = localDir
+	return sc
+}
+
+// The repository path is in the form of 'repo/path', without wildcards.
+func (sc *SyncCommand) SetRepoPath(repoPath string) *SyncCommand {
+	sc.repoPath = strings.Trim(repoPath, "/")
+	return sc
+}
+
+func (sc *SyncCommand) SetDirection(direction Direction) *SyncCommand {
+	sc.direction = direction
+	return sc
+}
+
+func (sc *SyncCommand) SetConflictPolicy(conflictPolicy ConflictPolicy) *SyncCommand {
+	sc.conflictPolicy = conflictPolicy
+	return sc
+}
+
+func (sc *SyncCommand) SetDryRun(dryRun bool) *SyncCommand {
+	sc.dryRun = dryRun
+	return sc
+}
+
+func (sc *SyncCommand) SetQuiet(quiet bool) *SyncCommand {
+	sc.quiet = quiet
+	return sc
+}
+
+func (sc *SyncCommand) SetRetries(retries int) *SyncCommand {
+	sc.retries = retries
+	return sc
+}
+
+func (sc *SyncCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *SyncCommand {
+	sc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return sc
+}
+
+func (sc *SyncCommand) SetUploadConfiguration(uploadConfiguration *utils.UploadConfiguration) *SyncCommand {
+	sc.uploadConfiguration = uploadConfiguration
+	return sc
+}
+
+func (sc *SyncCommand) SetDownloadConfiguration(downloadConfiguration *utils.DownloadConfiguration) *SyncCommand {
+	sc.downloadConfiguration = downloadConfiguration
+	return sc
+}
+
+func (sc *SyncCommand) Plan() []Action {
+	return sc.plan
+}
+
+func (sc *SyncCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+func (sc *SyncCommand) CommandName() string {
+	return "rt_sync"
+}
+
+func (sc *SyncCommand) Run() (err error) {
+	if sc.repoPath == "" || strings.ContainsAny(sc.repoPath, "*?") {
+		return errorutils.CheckErrorf("the repository path '%s' should be in the form of 'repo/path', without wildcards", sc.repoPath)
+	}
+	localFiles, err := getLocalFiles(sc.localDir, sc.direction != Pull)
+	if err != nil {
+		return err
+	}
+	remoteFiles, err := sc.getRemoteFiles()
+	if err != nil {
+		return err
+	}
+	var planErr error
+	sc.plan, planErr = CreatePlan(localFiles, remoteFiles, sc.direction, sc.conflictPolicy)
+	if err = sc.printPlan(); err != nil || planErr != nil {
+		return errorutils.CheckError(planErr)
+	}
+	if sc.dryRun || len(sc.plan) == 0 {
+		return nil
+	}
+	deletes := countActions(sc.plan, DeleteRemote) + countActions(sc.plan, DeleteLocal)
+	if deletes > 0 && !sc.quiet && !coreutils.AskYesNo("The sync plan deletes files. Are you sure you want to continue?\n"+
+		"You can avoid this confirmation message by adding --quiet to the command.", false) {
+		return nil
+	}
+	if err = sc.upload(); err != nil {
+		return err
+	}
+	if err = sc.download(); err != nil {
+		return err
+	}
+	if err = sc.deleteRemote(); err != nil {
+		return err
+	}
+	return sc.deleteLocal()
+}
+
+func (sc *SyncCommand) printPlan() error {
+	if len(sc.plan) == 0 {
+		log.Info("The local directory and the repository path are in sync.")
+		return nil
+	}
+	log.Info("Sync plan:", countActions(sc.plan, Upload), "to upload,", countActions(sc.plan, Download), "to download,",
+		countActions(sc.plan, DeleteRemote), "to delete in Artifactory,", countActions(sc.plan, DeleteLocal), "to delete locally and",
+		countActions(sc.plan, Conflict), "conflicts.")
+	return coreutils.PrintTable(sc.plan, "Sync Plan", "", false)
+}
+
+// Returns the files of the local directory, mapped by their relative paths.
+// If the directory doesn't exist, it is created when allowed, since it may be the target of a pull.
+func getLocalFiles(localDir string, mustExist bool) (map[string]*FileState, error) {
+	files := make(map[string]*FileState)
+	if _, err := os.Stat(localDir); err != nil {
+		if os.IsNotExist(err) && !mustExist {
+			return files, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	err := filepath.WalkDir(localDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		relPath, err := filepath.Rel(localDir, filePath)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		checksum, err := calcSha1(filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		files[relPath] = &FileState{Path: relPath, Sha1: checksum, Modified: info.ModTime()}
+		return nil
+	})
+	return files, errorutils.CheckError(err)
+}
+
+func calcSha1(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	//#nosec G401 -- sha1 is supported by Artifactory.
+	hash := sha1.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Returns the files under the repository path, mapped by their paths relative to it.
+func (sc *SyncCommand) getRemoteFiles() (files map[string]*FileState, err error) {
+	serviceManager, err := utils.CreateServiceManager(sc.serverDetails, sc.retries, sc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return
+	}
+	searchParams := services.NewSearchParams()
+	searchParams.CommonParams = &serviceutils.CommonParams{Pattern: sc.repoPath + "/", Recursive: true}
+	reader, err := serviceManager.SearchFiles(searchParams)
+	if err != nil {
+		return
+	}
+	defer func() {
+		e := reader.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	files = make(map[string]*FileState)
+	for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+		relPath := strings.TrimPrefix(getItemPath(item), sc.repoPath+"/")
+		modified, e := time.Parse(artifactoryTimeFormat, item.Modified)
+		if e != nil {
+			log.Debug("Couldn't parse the modification time of", relPath+":", e.Error())
+		}
+		files[relPath] = &FileState{Path: relPath, Sha1: item.Actual_Sha1, Modified: modified}
+	}
+	err = reader.GetError()
+	return
+}
+
+func getItemPath(item *serviceutils.ResultItem) string {
+	if item.Path == "." {
+		return path.Join(item.Repo, item.Name)
+	}
+	return path.Join(item.Repo, item.Path, item.Name)
+}
+
+// Returns the paths of the actions of the provided type.
+func (sc *SyncCommand) getPaths(actionType ActionType) (paths []string) {
+	for _, action := range sc.plan {
+		if action.Type == actionType {
+			paths = append(paths, action.Path)
+		}
+	}
+	return
+}
+
+func (sc *SyncCommand) upload() (err error) {
+	paths := sc.getPaths(Upload)
+	if len(paths) == 0 {
+		return nil
+	}
+	uploadSpec, linksDir, err := sc.createUploadSpec(paths)
+	defer func() {
+		if linksDir != "" {
+			if e := fileutils.RemoveTempDir(linksDir); err == nil {
+				err = e
+			}
+		}
+	}()
+	if err != nil {
+		return
+	}
+	uploadCommand := generic.NewUploadCommand()
+	uploadCommand.SetUploadConfiguration(sc.uploadConfiguration).SetSpec(uploadSpec).SetServerDetails(sc.serverDetails).SetRetries(sc.retries).SetRetryWaitMilliSecs(sc.retryWaitTimeMilliSecs)
+	return runTransfer(uploadCommand, "upload", len(paths))
+}
+
+// Returns a spec file for each of the local files. A file whose path can't be used as its pattern is uploaded
+// from a link with a plain name, in a temp dir which is returned so that it's removed after the upload.
+func (sc *SyncCommand) createUploadSpec(paths []string) (uploadSpec *spec.SpecFiles, linksDir string, err error) {
+	uploadSpec = new(spec.SpecFiles)
+	for i, relPath := range paths {
+		file := spec.File{
+			Pattern: filepath.Join(sc.localDir, filepath.FromSlash(relPath)),
+			Target:  sc.repoPath + "/" + relPath,
+			Flat:    "true",
+		}
+		if isPatternPath(file.Pattern, file.Target) {
+			if linksDir == "" {
+				if linksDir, err = fileutils.CreateTempDir(); err != nil {
+					return
+				}
+			}
+			link := filepath.Join(linksDir, strconv.Itoa(i))
+			if err = linkOrCopyFile(file.Pattern, link); err != nil {
+				return
+			}
+			file.Pattern = link
+		}
+		uploadSpec.Files = append(uploadSpec.Files, file)
+	}
+	return
+}
+
+// The pattern of a single file is taken as its literal path, unless it includes a wildcard,
+// or parentheses which may be matched with placeholders in the target. Wildcard patterns can't be escaped.
+func isPatternPath(pattern, target string) bool {
+	return strings.Contains(pattern, "*") || (strings.ContainsAny(pattern, "()") && strings.Contains(target, "{"))
+}
+
+// Links the file to a new path, or copies it if it can't be linked, such as when the paths are on different devices.
+func linkOrCopyFile(src, dst string) (err error) {
+	if os.Link(src, dst) == nil {
+		return nil
+	}
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		e := srcFile.Close()
+		if err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		e := dstFile.Close()
+		if err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	_, err = io.Copy(dstFile, srcFile)
+	return errorutils.CheckError(err)
+}
+
+// Files are downloaded by queries for their exact paths, since the paths of download patterns may include wildcards.
+func (sc *SyncCommand) download() error {
+	paths := sc.getPaths(Download)
+	if len(paths) == 0 {
+		return nil
+	}
+	downloadSpec := new(spec.SpecFiles)
+	for _, relPath := range paths {
+		repo, itemPath, name := splitItemPath(sc.repoPath + "/" + relPath)
+		itemsFind, err := json.Marshal(map[string]string{"repo": repo, "path": itemPath, "name": name})
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		downloadSpec.Files = append(downloadSpec.Files, spec.File{
+			Aql:    serviceutils.Aql{ItemsFind: string(itemsFind)},
+			Target: filepath.Join(sc.localDir, filepath.FromSlash(relPath)),
+			Flat:   "true",
+		})
+	}
+	downloadCommand := generic.NewDownloadCommand()
+	downloadCommand.SetConfiguration(sc.downloadConfiguration).SetSpec(downloadSpec).SetServerDetails(sc.serverDetails).SetRetries(sc.retries).SetRetryWaitMilliSecs(sc.retryWaitTimeMilliSecs)
+	return runTransfer(downloadCommand, "download", len(paths))
+}
+
+// Splits the path of an item in Artifactory into its repository, path and name, as they are searched by.
+func splitItemPath(fullPath string) (repo, itemPath, name string) {
+	dir, name := path.Split(fullPath)
+	repo, itemPath, _ = strings.Cut(strings.TrimSuffix(dir, "/"), "/")
+	if itemPath == "" {
+		itemPath = "."
+	}
+	return
+}
+
+type transferCommand interface {
+	Run() error
+	Result() *commandsutils.Result
+}
+
+// Runs an upload or a download command, and fails if not all the planned files were transferred.
+func runTransfer(command transferCommand, name string, expected int) error {
+	if err := command.Run(); err != nil {
+		return err
+	}
+	result := command.Result()
+	if result.FailCount() > 0 || result.SuccessCount() < expected {
+		return errorutils.CheckErrorf("failed to %s %d out of %d files", name, expected-result.SuccessCount(), expected)
+	}
+	return nil
+}
+
+func (sc *SyncCommand) deleteRemote() (err error) {
+	paths := sc.getPaths(DeleteRemote)
+	if len(paths) == 0 {
+		return nil
+	}
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return err
+	}
+	for _, relPath := range paths {
+		repo, itemPath, name := splitItemPath(sc.repoPath + "/" + relPath)
+		writer.Write(serviceutils.ResultItem{Repo: repo, Path: itemPath, Name: name, Type: "file"})
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		e := reader.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	deleteCommand := generic.NewDeleteCommand()
+	deleteCommand.SetThreads(sc.uploadConfiguration.Threads).SetServerDetails(sc.serverDetails).SetRetries(sc.retries).SetRetryWaitMilliSecs(sc.retryWaitTimeMilliSecs)
+	_, failed, err := deleteCommand.DeleteFiles(reader)
+	if err == nil && failed > 0 {
+		err = errorutils.CheckErrorf("failed to delete %d out of %d files in Artifactory", failed, len(paths))
+	}
+	return err
+}
+
+func (sc *SyncCommand) deleteLocal() error {
+	for _, relPath := range sc.getPaths(DeleteLocal) {
+		log.Info("Deleting local file:", relPath)
+		if err := os.Remove(filepath.Join(sc.localDir, filepath.FromSlash(relPath))); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
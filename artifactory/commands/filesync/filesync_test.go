@@ -0,0 +1,146 @@
+package filesync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	older = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer = time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func getTestFiles() (localFiles, remoteFiles map[string]*FileState) {
+	localFiles = map[string]*FileState{
+		"same.txt":       {Path: "same.txt", Sha1: "1", Modified: older},
+		"modified.txt":   {Path: "modified.txt", Sha1: "2", Modified: newer},
+		"local-only.txt": {Path: "local-only.txt", Sha1: "3", Modified: older},
+	}
+	remoteFiles = map[string]*FileState{
+		"same.txt":          {Path: "same.txt", Sha1: "1", Modified: newer},
+		"modified.txt":      {Path: "modified.txt", Sha1: "4", Modified: older},
+		"a/remote-only.txt": {Path: "a/remote-only.txt", Sha1: "5", Modified: older},
+	}
+	return
+}
+
+func TestCreatePlan(t *testing.T) {
+	tests := []struct {
+		name           string
+		direction      Direction
+		policy         ConflictPolicy
+		expectedPlan   []Action
+		expectConflict bool
+	}{
+		{"push", Push, Fail, []Action{
+			{DeleteRemote, "a/remote-only.txt", "missing locally"},
+			{Upload, "local-only.txt", "missing in Artifactory"},
+			{Upload, "modified.txt", "modified"},
+		}, false},
+		{"pull", Pull, Fail, []Action{
+			{Download, "a/remote-only.txt", "missing locally"},
+			{DeleteLocal, "local-only.txt", "missing in Artifactory"},
+			{Download, "modified.txt", "modified"},
+		}, false},
+		{"bothFail", Both, Fail, []Action{
+			{Download, "a/remote-only.txt", "missing locally"},
+			{Upload, "local-only.txt", "missing in Artifactory"},
+			{Conflict, "modified.txt", "modified on both sides"},
+		}, true},
+		{"bothRemote", Both, Remote, []Action{
+			{Download, "a/remote-only.txt", "missing locally"},
+			{Upload, "local-only.txt", "missing in Artifactory"},
+			{Download, "modified.txt", "conflict resolved by the Artifactory file"},
+		}, false},
+		{"bothNewer", Both, Newer, []Action{
+			{Download, "a/remote-only.txt", "missing locally"},
+			{Upload, "local-only.txt", "missing in Artifactory"},
+			{Upload, "modified.txt", "conflict resolved by the newer local file"},
+		}, false},
+		{"bothSkip", Both, Skip, []Action{
+			{Download, "a/remote-only.txt", "missing locally"},
+			{Upload, "local-only.txt", "missing in Artifactory"},
+			{Conflict, "modified.txt", "modified on both sides, skipped"},
+		}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			localFiles, remoteFiles := getTestFiles()
+			plan, err := CreatePlan(localFiles, remoteFiles, test.direction, test.policy)
+			if test.expectConflict {
+				assert.ErrorContains(t, err, "modified.txt")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedPlan, plan)
+		})
+	}
+}
+
+func TestGetLocalFiles(t *testing.T) {
+	localDir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(localDir, "a", "b"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(localDir, "a", "b", "c.txt"), []byte("c"), 0644))
+	files, err := getLocalFiles(localDir, true)
+	assert.NoError(t, err)
+	if assert.Contains(t, files, "a/b/c.txt") {
+		assert.Equal(t, "84a516841ba77a5b4648de2cd0dfcb30ea46dbb4", files["a/b/c.txt"].Sha1)
+	}
+	assert.Len(t, files, 1)
+
+	// A missing directory is allowed only when pulling.
+	_, err = getLocalFiles(filepath.Join(localDir, "missing"), true)
+	assert.Error(t, err)
+	files, err = getLocalFiles(filepath.Join(localDir, "missing"), false)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestCreateUploadSpec(t *testing.T) {
+	localDir := t.TempDir()
+	paths := []string{"a/plain.txt", "a/star*.txt", "a/(x){1}.txt"}
+	for _, relPath := range paths {
+		assert.NoError(t, os.MkdirAll(filepath.Join(localDir, "a"), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(localDir, filepath.FromSlash(relPath)), []byte(relPath), 0644))
+	}
+	// A file which a wildcard pattern of another file would match.
+	assert.NoError(t, os.WriteFile(filepath.Join(localDir, "a", "star-other.txt"), []byte("other"), 0644))
+	sc := NewSyncCommand().SetLocalDir(localDir).SetRepoPath("repo/dir")
+	uploadSpec, linksDir, err := sc.createUploadSpec(paths)
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fileutils.RemoveTempDir(linksDir))
+	}()
+	if !assert.Len(t, uploadSpec.Files, len(paths)) {
+		return
+	}
+	assert.Equal(t, filepath.Join(localDir, "a", "plain.txt"), uploadSpec.Get(0).Pattern)
+	// Each file is collected for the upload by itself, and is uploaded to its literal target.
+	for i, relPath := range paths {
+		uploadParams := services.NewUploadParams()
+		uploadParams.CommonParams, err = uploadSpec.Get(i).ToCommonParams()
+		assert.NoError(t, err)
+		uploadParams.Flat = true
+		var collected []string
+		assert.NoError(t, services.CollectFilesForUpload(uploadParams, nil, nil, func(data services.UploadData) {
+			assert.Equal(t, "repo/dir/"+relPath, data.Artifact.TargetPath)
+			content, err := os.ReadFile(data.Artifact.LocalPath)
+			assert.NoError(t, err)
+			collected = append(collected, string(content))
+		}))
+		assert.Equal(t, []string{relPath}, collected)
+	}
+}
+
+func TestSplitItemPath(t *testing.T) {
+	repo, itemPath, name := splitItemPath("repo/a/b/c*.txt")
+	assert.Equal(t, []string{"repo", "a/b", "c*.txt"}, []string{repo, itemPath, name})
+	repo, itemPath, name = splitItemPath("repo/c.txt")
+	assert.Equal(t, []string{"repo", ".", "c.txt"}, []string{repo, itemPath, name})
+}
@@ -0,0 +1,103 @@
+package provenance
+
+import "strings"
+
+// The builder ID used when the CLI doesn't run on a known CI server.
+const LocalBuilderId = "urn:jfrog-cli:local-builder"
+
+// The CI server which runs the build.
+type Builder struct {
+	Id string
+	// A URL of the specific CI run, if known.
+	InvocationId string
+	// The CI parameters which affected the build, such as the triggering event and the Git ref.
+	Parameters map[string]string
+}
+
+type ciDetector func(getenv func(string) string) *Builder
+
+var ciDetectors = []ciDetector{detectGitHubActions, detectGitLab, detectJenkins, detectAzurePipelines, detectCircleCi}
+
+// Detects the CI server by its environment variables.
+func DetectBuilder(getenv func(string) string) Builder {
+	for _, detect := range ciDetectors {
+		if builder := detect(getenv); builder != nil {
+			return *builder
+		}
+	}
+	return Builder{Id: LocalBuilderId, Parameters: map[string]string{}}
+}
+
+// Returns the values of the environment variables which are set, mapped by the provided parameter names.
+func getParameters(getenv func(string) string, envVars map[string]string) map[string]string {
+	parameters := map[string]string{}
+	for name, envVar := range envVars {
+		if value := getenv(envVar); value != "" {
+			parameters[name] = value
+		}
+	}
+	return parameters
+}
+
+func detectGitHubActions(getenv func(string) string) *Builder {
+	if getenv("GITHUB_ACTIONS") != "true" {
+		return nil
+	}
+	serverUrl := getenv("GITHUB_SERVER_URL")
+	repositoryUrl := serverUrl + "/" + getenv("GITHUB_REPOSITORY")
+	// The workflow ref includes the repository, such as "acme/app/.github/workflows/build.yml@refs/heads/main".
+	builder := &Builder{
+		Id:           serverUrl + "/" + strings.SplitN(getenv("GITHUB_WORKFLOW_REF"), "@", 2)[0],
+		InvocationId: repositoryUrl + "/actions/runs/" + getenv("GITHUB_RUN_ID") + "/attempts/" + getenv("GITHUB_RUN_ATTEMPT"),
+		Parameters:   getParameters(getenv, map[string]string{"workflow": "GITHUB_WORKFLOW_REF", "event": "GITHUB_EVENT_NAME", "ref": "GITHUB_REF", "repository": "GITHUB_REPOSITORY"}),
+	}
+	if getenv("GITHUB_WORKFLOW_REF") == "" {
+		builder.Id = "https://github.com/actions/runner"
+	}
+	return builder
+}
+
+func detectGitLab(getenv func(string) string) *Builder {
+	if getenv("GITLAB_CI") != "true" {
+		return nil
+	}
+	return &Builder{
+		Id:           getenv("CI_SERVER_URL") + "/" + getenv("CI_PROJECT_PATH") + "/-/runners/" + getenv("CI_RUNNER_ID"),
+		InvocationId: getenv("CI_JOB_URL"),
+		Parameters:   getParameters(getenv, map[string]string{"pipelineSource": "CI_PIPELINE_SOURCE", "ref": "CI_COMMIT_REF_NAME", "project": "CI_PROJECT_PATH", "job": "CI_JOB_NAME"}),
+	}
+}
+
+func detectJenkins(getenv func(string) string) *Builder {
+	if getenv("JENKINS_URL") == "" {
+		return nil
+	}
+	return &Builder{
+		Id:           getenv("JENKINS_URL"),
+		InvocationId: getenv("BUILD_URL"),
+		Parameters:   getParameters(getenv, map[string]string{"job": "JOB_NAME", "branch": "BRANCH_NAME", "node": "NODE_NAME"}),
+	}
+}
+
+func detectAzurePipelines(getenv func(string) string) *Builder {
+	if getenv("TF_BUILD") != "True" {
+		return nil
+	}
+	projectUrl := getenv("SYSTEM_TEAMFOUNDATIONCOLLECTIONURI") + getenv("SYSTEM_TEAMPROJECT")
+	return &Builder{
+		Id:           projectUrl + "/_build?definitionId=" + getenv("SYSTEM_DEFINITIONID"),
+		InvocationId: projectUrl + "/_build/results?buildId=" + getenv("BUILD_BUILDID"),
+		Parameters:   getParameters(getenv, map[string]string{"reason": "BUILD_REASON", "ref": "BUILD_SOURCEBRANCH", "pipeline": "BUILD_DEFINITIONNAME"}),
+	}
+}
+
+func detectCircleCi(getenv func(string) string) *Builder {
+	if getenv("CIRCLECI") != "true" {
+		return nil
+	}
+	return &Builder{
+		Id:           "https://circleci.com/" + getenv("CIRCLE_PROJECT_USERNAME") + "/" + getenv("CIRCLE_PROJECT_REPONAME"),
+		InvocationId: getenv("CIRCLE_BUILD_URL"),
+		Parameters:   getParameters(getenv, map[string]string{"job": "CIRCLE_JOB", "branch": "CIRCLE_BRANCH", "tag": "CIRCLE_TAG"}),
+	}
+}
@@ -0,0 +1,48 @@
+package provenance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected Builder
+	}{
+		{
+			name:     "local",
+			env:      map[string]string{},
+			expected: Builder{Id: LocalBuilderId, Parameters: map[string]string{}},
+		},
+		{
+			name: "github-actions",
+			env: map[string]string{
+				"GITHUB_ACTIONS": "true", "GITHUB_SERVER_URL": "https://github.com", "GITHUB_REPOSITORY": "acme/app",
+				"GITHUB_WORKFLOW_REF": "acme/app/.github/workflows/build.yml@refs/heads/main", "GITHUB_RUN_ID": "42", "GITHUB_RUN_ATTEMPT": "1",
+				"GITHUB_EVENT_NAME": "push", "GITHUB_REF": "refs/heads/main",
+			},
+			expected: Builder{
+				Id:           "https://github.com/acme/app/.github/workflows/build.yml",
+				InvocationId: "https://github.com/acme/app/actions/runs/42/attempts/1",
+				Parameters:   map[string]string{"workflow": "acme/app/.github/workflows/build.yml@refs/heads/main", "event": "push", "ref": "refs/heads/main", "repository": "acme/app"},
+			},
+		},
+		{
+			name: "jenkins",
+			env:  map[string]string{"JENKINS_URL": "https://jenkins.acme.com/", "BUILD_URL": "https://jenkins.acme.com/job/app/3/", "JOB_NAME": "app"},
+			expected: Builder{
+				Id:           "https://jenkins.acme.com/",
+				InvocationId: "https://jenkins.acme.com/job/app/3/",
+				Parameters:   map[string]string{"job": "app"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DetectBuilder(func(key string) string { return test.env[key] }))
+		})
+	}
+}
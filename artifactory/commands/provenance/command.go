@@ -0,0 +1,237 @@
+package provenance
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/attestation"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Creates the SLSA provenance of the build, uploads it next to the build's artifacts, and adds it to the build-info as an artifact.
+type BuildProvenanceCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	repository         string
+	signKey            string
+	dryRun             bool
+}
+
+func NewBuildProvenanceCommand() *BuildProvenanceCommand {
+	return &BuildProvenanceCommand{}
+}
+
+func (bpc *BuildProvenanceCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildProvenanceCommand {
+	bpc.serverDetails = serverDetails
+	return bpc
+}
+
+func (bpc *BuildProvenanceCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildProvenanceCommand {
+	bpc.buildConfiguration = buildConfiguration
+	return bpc
+}
+
+// Sets the repository to upload the provenance to. If not set, the provenance is uploaded to the repository of the build's artifacts.
+func (bpc *BuildProvenanceCommand) SetRepository(repository string) *BuildProvenanceCommand {
+	bpc.repository = repository
+	return bpc
+}
+
+// If set, the provenance statement is signed into a DSSE envelope. See attestation.NewSigner for the supported values.
+func (bpc *BuildProvenanceCommand) SetSignKey(signKey string) *BuildProvenanceCommand {
+	bpc.signKey = signKey
+	return bpc
+}
+
+// If set to true, the provenance is printed instead of uploaded.
+func (bpc *BuildProvenanceCommand) SetDryRun(dryRun bool) *BuildProvenanceCommand {
+	bpc.dryRun = dryRun
+	return bpc
+}
+
+func (bpc *BuildProvenanceCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bpc.serverDetails, nil
+}
+
+func (bpc *BuildProvenanceCommand) CommandName() string {
+	return "rt_build_provenance"
+}
+
+func (bpc *BuildProvenanceCommand) Run() error {
+	buildName, err := bpc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bpc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	// The number of a build configured in a config file is determined only when it is published.
+	if bpc.buildConfiguration.IsLoadedFromConfigFile() {
+		return errorutils.CheckErrorf("creating the build's provenance requires providing the build number")
+	}
+	build, err := utils.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, bpc.buildConfiguration.GetProject())
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	buildInfo, err := build.ToBuildInfo()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	statement, err := NewStatement(buildInfo, DetectBuilder(os.Getenv), bpc.buildConfiguration.GetProject(), time.Now())
+	if err != nil {
+		return err
+	}
+	fileName, content, err := bpc.createProvenanceFile(buildName, buildNumber, statement)
+	if err != nil {
+		return err
+	}
+	if bpc.dryRun {
+		log.Output(string(content))
+		return nil
+	}
+	return bpc.upload(buildInfo, fileName, content)
+}
+
+// Returns the name and content of the provenance file. A signed provenance is a DSSE envelope in the in-toto JSON lines format.
+func (bpc *BuildProvenanceCommand) createProvenanceFile(buildName, buildNumber string, statement *attestation.Statement) (fileName string, content []byte, err error) {
+	baseName := strings.ReplaceAll(buildName, "/", "-") + "-" + buildNumber
+	if bpc.signKey == "" {
+		content, err = json.MarshalIndent(statement, "", "  ")
+		return baseName + ".provenance.json", content, errorutils.CheckError(err)
+	}
+	signer, err := attestation.NewSigner(bpc.signKey)
+	if err != nil {
+		return "", nil, err
+	}
+	envelope, err := attestation.SignStatement(statement, signer)
+	if err != nil {
+		return "", nil, err
+	}
+	content, err = json.Marshal(envelope)
+	return baseName + ".intoto.jsonl", append(content, '\n'), errorutils.CheckError(err)
+}
+
+func (bpc *BuildProvenanceCommand) upload(buildInfo *buildinfo.BuildInfo, fileName string, content []byte) (err error) {
+	repository := bpc.repository
+	if repository == "" {
+		if repository, err = bpc.findRepository(buildInfo); err != nil {
+			return err
+		}
+	}
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := fileutils.RemoveTempDir(tempDir)
+		if err == nil {
+			err = e
+		}
+	}()
+	localPath := filepath.Join(tempDir, fileName)
+	if err = os.WriteFile(localPath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	target := path.Join(repository, getArtifactsDir(buildInfo), fileName)
+	log.Info("Uploading the provenance of build", buildInfo.Name+"/"+buildInfo.Number, "to", target+"...")
+	// Uploading with the build configuration adds the provenance file to the build-info.
+	uploadConfiguration := new(utils.UploadConfiguration)
+	uploadConfiguration.Threads = cliutils.Threads
+	uploadCmd := generic.NewUploadCommand()
+	uploadCmd.SetUploadConfiguration(uploadConfiguration).SetBuildConfiguration(bpc.buildConfiguration).
+		SetServerDetails(bpc.serverDetails).SetSpec(spec.NewBuilder().Pattern(localPath).Target(target).Flat(true).BuildSpec())
+	if err = uploadCmd.Run(); err != nil {
+		return err
+	}
+	if uploadCmd.Result().SuccessCount() != 1 {
+		return errorutils.CheckErrorf("failed to upload the provenance of build %s/%s to %s", buildInfo.Name, buildInfo.Number, target)
+	}
+	return nil
+}
+
+// Returns the deepest directory which includes all the build's artifacts. The paths of the artifacts in the build-info don't include their repository.
+func getArtifactsDir(buildInfo *buildinfo.BuildInfo) string {
+	var common []string
+	first := true
+	for _, subject := range getSubjects(buildInfo) {
+		dir := strings.Split(path.Dir(subject.Name), "/")
+		if dir[0] == "." {
+			return ""
+		}
+		if first {
+			common, first = dir, false
+			continue
+		}
+		i := 0
+		for ; i < len(common) && i < len(dir) && common[i] == dir[i]; i++ {
+		}
+		common = common[:i]
+	}
+	return strings.Join(common, "/")
+}
+
+// Finds the repository of the build's artifacts, by searching Artifactory for the first artifact with a SHA-1 checksum.
+func (bpc *BuildProvenanceCommand) findRepository(buildInfo *buildinfo.BuildInfo) (string, error) {
+	servicesManager, err := utils.CreateServiceManager(bpc.serverDetails, -1, 0, false)
+	if err != nil {
+		return "", err
+	}
+	for _, subject := range getSubjects(buildInfo) {
+		sha1 := subject.Digest[attestation.DigestSha1]
+		if sha1 == "" {
+			continue
+		}
+		return searchRepository(servicesManager, subject.Name, sha1)
+	}
+	return "", errorutils.CheckErrorf("cannot find the repository of the build's artifacts, since none of them has a SHA-1 checksum. Use the --provenance-repo option to set the repository")
+}
+
+func searchRepository(servicesManager artifactory.ArtifactoryServicesManager, artifactPath, sha1 string) (repository string, err error) {
+	query := `items.find({"path":` + quote(path.Dir(artifactPath)) + `,"name":` + quote(path.Base(artifactPath)) + `,"actual_sha1":` + quote(sha1) + `}).include("repo")`
+	reader, err := servicesManager.Aql(query)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		e := reader.Close()
+		if err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	result := &struct {
+		Results []struct {
+			Repo string `json:"repo"`
+		} `json:"results"`
+	}{}
+	if err = json.Unmarshal(content, result); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if len(result.Results) == 0 {
+		return "", errorutils.CheckErrorf("the artifact %s of the build was not found in Artifactory. Use the --provenance-repo option to set the repository to upload the provenance to", artifactPath)
+	}
+	return result.Results[0].Repo, nil
+}
+
+func quote(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
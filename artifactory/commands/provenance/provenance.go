@@ -0,0 +1,131 @@
+package provenance
+
+import (
+	"sort"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/attestation"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	PredicateType = "https://slsa.dev/provenance/v1"
+	BuildType     = "https://jfrog.com/jfrog-cli/build-info/v1"
+)
+
+// SLSA provenance v1, as defined by https://slsa.dev/spec/v1.0/provenance.
+type Predicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   map[string]string    `json:"externalParameters"`
+	InternalParameters   map[string]string    `json:"internalParameters,omitempty"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type ResourceDescriptor struct {
+	Uri    string            `json:"uri,omitempty"`
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+type RunDetails struct {
+	Builder  BuilderDetails `json:"builder"`
+	Metadata Metadata       `json:"metadata"`
+}
+
+type BuilderDetails struct {
+	Id string `json:"id"`
+}
+
+type Metadata struct {
+	InvocationId string `json:"invocationId,omitempty"`
+	StartedOn    string `json:"startedOn,omitempty"`
+	FinishedOn   string `json:"finishedOn,omitempty"`
+}
+
+// Creates the SLSA provenance statement of the build. The subjects are the build's artifacts, and the resolved dependencies
+// are the VCS revisions, collected by build-add-git, and the build's dependencies.
+func NewStatement(buildInfo *buildinfo.BuildInfo, builder Builder, projectKey string, finishedOn time.Time) (*attestation.Statement, error) {
+	subjects := getSubjects(buildInfo)
+	if len(subjects) == 0 {
+		return nil, errorutils.CheckErrorf("cannot create the provenance of build %s/%s, because the build has no artifacts", buildInfo.Name, buildInfo.Number)
+	}
+	externalParameters := map[string]string{"buildName": buildInfo.Name, "buildNumber": buildInfo.Number}
+	if projectKey != "" {
+		externalParameters["project"] = projectKey
+	}
+	for name, value := range builder.Parameters {
+		externalParameters[name] = value
+	}
+	predicate := Predicate{
+		BuildDefinition: BuildDefinition{
+			BuildType:            BuildType,
+			ExternalParameters:   externalParameters,
+			InternalParameters:   map[string]string{"cli": coreutils.GetCliUserAgentName() + "/" + coreutils.GetCliUserAgentVersion()},
+			ResolvedDependencies: getResolvedDependencies(buildInfo),
+		},
+		RunDetails: RunDetails{
+			Builder:  BuilderDetails{Id: builder.Id},
+			Metadata: Metadata{InvocationId: builder.InvocationId, FinishedOn: finishedOn.UTC().Format(time.RFC3339)},
+		},
+	}
+	if started, err := time.Parse(buildinfo.TimeFormat, buildInfo.Started); err == nil {
+		predicate.RunDetails.Metadata.StartedOn = started.UTC().Format(time.RFC3339)
+	}
+	return attestation.NewStatement(subjects, PredicateType, predicate)
+}
+
+// Returns the artifacts of all the modules, identified by their paths in the repository.
+func getSubjects(buildInfo *buildinfo.BuildInfo) []attestation.Subject {
+	subjects := []attestation.Subject{}
+	paths := map[string]bool{}
+	for _, module := range buildInfo.Modules {
+		for _, artifact := range module.Artifacts {
+			path := getArtifactPath(artifact)
+			if paths[path] {
+				continue
+			}
+			paths[path] = true
+			subjects = append(subjects, attestation.Subject{Name: path, Digest: attestation.NewDigest(artifact.Checksum)})
+		}
+	}
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i].Name < subjects[j].Name })
+	return subjects
+}
+
+func getArtifactPath(artifact buildinfo.Artifact) string {
+	if artifact.Path != "" {
+		return artifact.Path
+	}
+	return artifact.Name
+}
+
+func getResolvedDependencies(buildInfo *buildinfo.BuildInfo) []ResourceDescriptor {
+	var resolved []ResourceDescriptor
+	for _, vcs := range buildInfo.VcsList {
+		uri := "git+" + vcs.Url
+		if vcs.Branch != "" {
+			uri += "@refs/heads/" + vcs.Branch
+		}
+		resolved = append(resolved, ResourceDescriptor{Uri: uri, Digest: map[string]string{"gitCommit": vcs.Revision}})
+	}
+	var dependencies []ResourceDescriptor
+	ids := map[string]bool{}
+	for _, module := range buildInfo.Modules {
+		for _, dependency := range module.Dependencies {
+			if ids[dependency.Id] {
+				continue
+			}
+			ids[dependency.Id] = true
+			dependencies = append(dependencies, ResourceDescriptor{Name: dependency.Id, Digest: attestation.NewDigest(dependency.Checksum)})
+		}
+	}
+	sort.Slice(dependencies, func(i, j int) bool { return dependencies[i].Name < dependencies[j].Name })
+	return append(resolved, dependencies...)
+}
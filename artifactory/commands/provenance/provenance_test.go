@@ -0,0 +1,85 @@
+package provenance
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli/utils/attestation"
+	"github.com/stretchr/testify/assert"
+)
+
+func createBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:    "my-build",
+		Number:  "1",
+		Started: "2024-01-02T10:00:00.000+0000",
+		Modules: []buildinfo.Module{
+			{
+				Id: "org.acme:app:1.0.0",
+				Artifacts: []buildinfo.Artifact{
+					{Name: "app.jar", Path: "org/acme/app/1.0.0/app.jar", Checksum: buildinfo.Checksum{Sha1: "app1", Sha256: "app256"}},
+					{Name: "app.pom", Path: "org/acme/app/1.0.0/app.pom", Checksum: buildinfo.Checksum{Sha1: "pom1"}},
+				},
+				Dependencies: []buildinfo.Dependency{{Id: "junit:junit:4.13.2", Checksum: buildinfo.Checksum{Sha1: "junit1"}}},
+			},
+			{
+				Id:           "org.acme:lib:1.0.0",
+				Artifacts:    []buildinfo.Artifact{{Name: "lib.jar", Path: "org/acme/lib/1.0.0/lib.jar", Checksum: buildinfo.Checksum{Sha1: "lib1"}}},
+				Dependencies: []buildinfo.Dependency{{Id: "junit:junit:4.13.2", Checksum: buildinfo.Checksum{Sha1: "junit1"}}, {Id: "commons-io:commons-io:2.15.1", Checksum: buildinfo.Checksum{Sha1: "io1"}}},
+			},
+		},
+		VcsList: []buildinfo.Vcs{{Url: "https://github.com/acme/app.git", Revision: "abc123", Branch: "main"}},
+	}
+}
+
+func TestNewStatement(t *testing.T) {
+	builder := Builder{Id: "https://ci.acme.com", InvocationId: "https://ci.acme.com/runs/7", Parameters: map[string]string{"ref": "refs/heads/main"}}
+	finishedOn := time.Date(2024, 1, 2, 10, 5, 0, 0, time.UTC)
+	statement, err := NewStatement(createBuildInfo(), builder, "proj", finishedOn)
+	assert.NoError(t, err)
+	assert.Equal(t, attestation.StatementType, statement.Type)
+	assert.Equal(t, PredicateType, statement.PredicateType)
+	assert.Equal(t, []attestation.Subject{
+		{Name: "org/acme/app/1.0.0/app.jar", Digest: map[string]string{attestation.DigestSha1: "app1", attestation.DigestSha256: "app256"}},
+		{Name: "org/acme/app/1.0.0/app.pom", Digest: map[string]string{attestation.DigestSha1: "pom1"}},
+		{Name: "org/acme/lib/1.0.0/lib.jar", Digest: map[string]string{attestation.DigestSha1: "lib1"}},
+	}, statement.Subject)
+
+	var predicate Predicate
+	assert.NoError(t, json.Unmarshal(statement.Predicate, &predicate))
+	assert.Equal(t, BuildType, predicate.BuildDefinition.BuildType)
+	assert.Equal(t, map[string]string{"buildName": "my-build", "buildNumber": "1", "project": "proj", "ref": "refs/heads/main"}, predicate.BuildDefinition.ExternalParameters)
+	assert.Contains(t, predicate.BuildDefinition.InternalParameters, "cli")
+	assert.Equal(t, []ResourceDescriptor{
+		{Uri: "git+https://github.com/acme/app.git@refs/heads/main", Digest: map[string]string{"gitCommit": "abc123"}},
+		{Name: "commons-io:commons-io:2.15.1", Digest: map[string]string{attestation.DigestSha1: "io1"}},
+		{Name: "junit:junit:4.13.2", Digest: map[string]string{attestation.DigestSha1: "junit1"}},
+	}, predicate.BuildDefinition.ResolvedDependencies)
+	assert.Equal(t, RunDetails{
+		Builder:  BuilderDetails{Id: "https://ci.acme.com"},
+		Metadata: Metadata{InvocationId: "https://ci.acme.com/runs/7", StartedOn: "2024-01-02T10:00:00Z", FinishedOn: "2024-01-02T10:05:00Z"},
+	}, predicate.RunDetails)
+}
+
+func TestNewStatementWithoutArtifacts(t *testing.T) {
+	buildInfo := createBuildInfo()
+	for i := range buildInfo.Modules {
+		buildInfo.Modules[i].Artifacts = nil
+	}
+	_, err := NewStatement(buildInfo, DetectBuilder(func(string) string { return "" }), "", time.Now())
+	assert.ErrorContains(t, err, "the build has no artifacts")
+}
+
+func TestGetArtifactsDir(t *testing.T) {
+	buildInfo := createBuildInfo()
+	assert.Equal(t, "org/acme", getArtifactsDir(buildInfo))
+
+	buildInfo.Modules = buildInfo.Modules[:1]
+	assert.Equal(t, "org/acme/app/1.0.0", getArtifactsDir(buildInfo))
+
+	// Artifacts without a path are uploaded to the root of the repository.
+	buildInfo.Modules[0].Artifacts = append(buildInfo.Modules[0].Artifacts, buildinfo.Artifact{Name: "app.zip"})
+	assert.Equal(t, "", getArtifactsDir(buildInfo))
+}
@@ -0,0 +1,335 @@
+package repoapply
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/repository"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/repotemplate"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type ActionType string
+
+const (
+	Create ActionType = "create"
+	Update ActionType = "update"
+	Delete ActionType = "delete"
+)
+
+var actionSymbols = map[ActionType]string{Create: "+", Update: "~", Delete: "-"}
+
+// Repositories are created in this order, and deleted in the reverse order, so that virtual repositories never reference missing repositories.
+var rclassOrder = map[string]int{repository.Local: 0, repository.Remote: 1, repository.Virtual: 2}
+
+// Keys which can't be read back from Artifactory, and therefore can't be compared.
+var writeOnlyKeys = map[string]bool{repository.Password: true}
+
+// Replaces the values of secrets in the plan.
+const sensitiveValue = "(sensitive value)"
+
+// Keys which can't be modified once the repository is created.
+var immutableKeys = []string{repository.Rclass, repository.PackageType}
+
+// A repository template, after its variables were replaced.
+type Template struct {
+	Path   string
+	Key    string
+	Config map[string]interface{}
+}
+
+func (t *Template) rclass() string {
+	rclass, _ := t.Config[repository.Rclass].(string)
+	return rclass
+}
+
+type AttributeChange struct {
+	Name    string
+	Current string
+	Desired string
+}
+
+type Change struct {
+	Action     ActionType
+	Key        string
+	Rclass     string
+	Template   *Template
+	Attributes []AttributeChange
+}
+
+// Reads all the JSON templates in the directory, replacing the provided template vars.
+func ReadTemplates(dir, vars string) ([]*Template, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(paths) == 0 {
+		return nil, errorutils.CheckErrorf("no repository templates were found in %s", dir)
+	}
+	sort.Strings(paths)
+	var templates []*Template
+	keys := make(map[string]string, len(paths))
+	for _, path := range paths {
+		configMap, err := commandUtils.ConvertTemplateToMap(&templateReader{path: path, vars: vars})
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed to read the repository template %s: %s", path, err.Error())
+		}
+		key, _ := configMap[repository.Key].(string)
+		if key == "" {
+			return nil, errorutils.CheckErrorf("the repository template %s must include a '%s' string value", path, repository.Key)
+		}
+		if otherPath, exists := keys[key]; exists {
+			return nil, errorutils.CheckErrorf("the repository '%s' is defined by both %s and %s", key, otherPath, path)
+		}
+		keys[key] = path
+		templates = append(templates, &Template{Path: path, Key: key, Config: configMap})
+	}
+	return templates, nil
+}
+
+// Implements commandUtils.TemplateUserCommand, to read the templates the same way the repo-create and repo-update commands do.
+type templateReader struct {
+	path string
+	vars string
+}
+
+func (tr *templateReader) TemplatePath() string {
+	return tr.path
+}
+
+func (tr *templateReader) Vars() string {
+	return tr.vars
+}
+
+// Computes the changes required to make Artifactory match the templates.
+// currentConfigs holds the configuration of the existing repositories which are defined by the templates, mapped by their keys.
+// If a prune pattern is provided, the existing repositories which match it and aren't defined by the templates are deleted.
+func CreatePlan(templates []*Template, currentConfigs map[string]map[string]interface{}, existingRepos []services.RepositoryDetails, prunePattern string) ([]Change, error) {
+	var changes []Change
+	managed := make(map[string]bool, len(templates))
+	for _, template := range templates {
+		managed[template.Key] = true
+		current, exists := currentConfigs[template.Key]
+		if !exists {
+			changes = append(changes, Change{Action: Create, Key: template.Key, Rclass: template.rclass(), Template: template, Attributes: getAttributes(template.Config, nil)})
+			continue
+		}
+		for _, key := range immutableKeys {
+			if desired := repotemplate.FormatValue(key, template.Config[key]); desired != "" && desired != repotemplate.FormatValue(key, current[key]) {
+				return nil, errorutils.CheckErrorf("the '%s' of the repository '%s' can't be changed from '%s' to '%s'. Delete the repository first, if you'd like to recreate it",
+					key, template.Key, repotemplate.FormatValue(key, current[key]), desired)
+			}
+		}
+		if attributes := getAttributes(template.Config, current); len(attributes) > 0 {
+			changes = append(changes, Change{Action: Update, Key: template.Key, Rclass: template.rclass(), Template: template, Attributes: attributes})
+		}
+	}
+	if prunePattern != "" {
+		for _, repo := range existingRepos {
+			matched, err := filepath.Match(prunePattern, repo.Key)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			if matched && !managed[repo.Key] {
+				changes = append(changes, Change{Action: Delete, Key: repo.Key, Rclass: strings.ToLower(repo.GetRepoType())})
+			}
+		}
+	}
+	sortChanges(changes)
+	return changes, nil
+}
+
+// Returns the attributes of the template which differ from the current configuration, sorted by their names.
+// If the current configuration is nil, all the attributes are returned.
+func getAttributes(desired, current map[string]interface{}) []AttributeChange {
+	var attributes []AttributeChange
+	for name, value := range desired {
+		change := AttributeChange{Name: name, Desired: repotemplate.FormatValue(name, value)}
+		if current != nil {
+			if writeOnlyKeys[name] {
+				continue
+			}
+			change.Current = repotemplate.FormatValue(name, current[name])
+			if change.Current == change.Desired {
+				continue
+			}
+		}
+		attributes = append(attributes, change)
+	}
+	sort.Slice(attributes, func(i, j int) bool { return attributes[i].Name < attributes[j].Name })
+	return attributes
+}
+
+// Sorts the changes so that they can be applied in order: creations, updates and then deletions.
+func sortChanges(changes []Change) {
+	actionOrder := map[ActionType]int{Create: 0, Update: 1, Delete: 2}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Action != changes[j].Action {
+			return actionOrder[changes[i].Action] < actionOrder[changes[j].Action]
+		}
+		if changes[i].Rclass != changes[j].Rclass {
+			if changes[i].Action == Delete {
+				return rclassOrder[changes[i].Rclass] > rclassOrder[changes[j].Rclass]
+			}
+			return rclassOrder[changes[i].Rclass] < rclassOrder[changes[j].Rclass]
+		}
+		return changes[i].Key < changes[j].Key
+	})
+}
+
+// Returns the plan in a Terraform-like format.
+func FormatPlan(changes []Change) string {
+	if len(changes) == 0 {
+		return "No changes. The repositories match the templates."
+	}
+	var builder strings.Builder
+	counts := map[ActionType]int{}
+	for _, change := range changes {
+		counts[change.Action]++
+		builder.WriteString(fmt.Sprintf("  %s %s repository \"%s\"\n", actionSymbols[change.Action], change.Rclass, change.Key))
+		nameWidth := 0
+		for _, attribute := range change.Attributes {
+			if len(attribute.Name) > nameWidth {
+				nameWidth = len(attribute.Name)
+			}
+		}
+		for _, attribute := range change.Attributes {
+			current, desired := fmt.Sprintf("%q", attribute.Current), fmt.Sprintf("%q", attribute.Desired)
+			if repotemplate.IsSecretKey(attribute.Name) {
+				current, desired = sensitiveValue, sensitiveValue
+			}
+			if change.Action == Create {
+				builder.WriteString(fmt.Sprintf("      + %-*s = %s\n", nameWidth, attribute.Name, desired))
+			} else {
+				builder.WriteString(fmt.Sprintf("      ~ %-*s = %s -> %s\n", nameWidth, attribute.Name, current, desired))
+			}
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString(fmt.Sprintf("Plan: %d to create, %d to update, %d to delete.", counts[Create], counts[Update], counts[Delete]))
+	return builder.String()
+}
+
+type RepoApplyCommand struct {
+	serverDetails *config.ServerDetails
+	templatesDir  string
+	vars          string
+	prunePattern  string
+	approve       bool
+	changes       []Change
+}
+
+func NewRepoApplyCommand() *RepoApplyCommand {
+	return &RepoApplyCommand{}
+}
+
+func (rac *RepoApplyCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoApplyCommand {
+	rac.serverDetails = serverDetails
+	return rac
+}
+
+func (rac *RepoApplyCommand) SetTemplatesDir(templatesDir string) *RepoApplyCommand {
+	rac.templatesDir = templatesDir
+	return rac
+}
+
+func (rac *RepoApplyCommand) SetVars(vars string) *RepoApplyCommand {
+	rac.vars = vars
+	return rac
+}
+
+func (rac *RepoApplyCommand) SetPrunePattern(prunePattern string) *RepoApplyCommand {
+	rac.prunePattern = prunePattern
+	return rac
+}
+
+func (rac *RepoApplyCommand) SetApprove(approve bool) *RepoApplyCommand {
+	rac.approve = approve
+	return rac
+}
+
+func (rac *RepoApplyCommand) Changes() []Change {
+	return rac.changes
+}
+
+func (rac *RepoApplyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return rac.serverDetails, nil
+}
+
+func (rac *RepoApplyCommand) CommandName() string {
+	return "rt_repo_apply"
+}
+
+func (rac *RepoApplyCommand) Run() (err error) {
+	if info, err := os.Stat(rac.templatesDir); err != nil || !info.IsDir() {
+		return errorutils.CheckErrorf("the templates directory %s does not exist", rac.templatesDir)
+	}
+	templates, err := ReadTemplates(rac.templatesDir, rac.vars)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(rac.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	existingRepos, err := servicesManager.GetAllRepositories()
+	if err != nil {
+		return err
+	}
+	existingKeys := make(map[string]bool, len(*existingRepos))
+	for _, repo := range *existingRepos {
+		existingKeys[repo.Key] = true
+	}
+	currentConfigs := make(map[string]map[string]interface{})
+	for _, template := range templates {
+		if !existingKeys[template.Key] {
+			continue
+		}
+		current := make(map[string]interface{})
+		if err = servicesManager.GetRepository(template.Key, &current); err != nil {
+			return err
+		}
+		currentConfigs[template.Key] = current
+	}
+	if rac.changes, err = CreatePlan(templates, currentConfigs, *existingRepos, rac.prunePattern); err != nil {
+		return err
+	}
+	log.Output(FormatPlan(rac.changes))
+	if len(rac.changes) == 0 {
+		return nil
+	}
+	if !rac.approve {
+		log.Info("The plan was not applied. Run the command with the --approve option to apply it.")
+		return nil
+	}
+	for _, change := range rac.changes {
+		if err = rac.apply(change); err != nil {
+			return err
+		}
+	}
+	log.Info("The plan was applied successfully.")
+	return nil
+}
+
+func (rac *RepoApplyCommand) apply(change Change) error {
+	switch change.Action {
+	case Create:
+		return repository.NewRepoCreateCommand().SetTemplatePath(change.Template.Path).SetVars(rac.vars).SetServerDetails(rac.serverDetails).Run()
+	case Update:
+		return repository.NewRepoUpdateCommand().SetTemplatePath(change.Template.Path).SetVars(rac.vars).SetServerDetails(rac.serverDetails).Run()
+	default:
+		servicesManager, err := rtUtils.CreateServiceManager(rac.serverDetails, -1, 0, false)
+		if err != nil {
+			return err
+		}
+		return servicesManager.DeleteRepository(change.Key)
+	}
+}
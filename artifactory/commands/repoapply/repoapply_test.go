@@ -0,0 +1,90 @@
+package repoapply
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+)
+
+var templatesDir = filepath.Join("testdata", "templates")
+
+func TestReadTemplates(t *testing.T) {
+	templates, err := ReadTemplates(templatesDir, "prefix=team;password=secret")
+	assert.NoError(t, err)
+	if assert.Len(t, templates, 3) {
+		assert.Equal(t, "team-libs-local", templates[0].Key)
+		assert.Equal(t, "team-libs-remote", templates[1].Key)
+		assert.Equal(t, "secret", templates[1].Config["password"])
+		assert.Equal(t, "team-libs", templates[2].Key)
+	}
+}
+
+func TestCreatePlan(t *testing.T) {
+	templates, err := ReadTemplates(templatesDir, "prefix=team;password=secret")
+	assert.NoError(t, err)
+	currentConfigs := map[string]map[string]interface{}{
+		"team-libs-local": {"key": "team-libs-local", "rclass": "local", "packageType": "maven", "description": "Old description", "xrayIndex": true},
+		"team-libs-remote": {"key": "team-libs-remote", "rclass": "remote", "packageType": "maven", "url": "https://repo.maven.apache.org/maven2",
+			"password": "", "contentSynchronisation": map[string]interface{}{"enabled": false}},
+	}
+	existingRepos := []services.RepositoryDetails{
+		{Key: "team-libs-local", Type: "LOCAL"},
+		{Key: "team-libs-remote", Type: "REMOTE"},
+		{Key: "team-old-local", Type: "LOCAL"},
+		{Key: "team-old", Type: "VIRTUAL"},
+		{Key: "other-local", Type: "LOCAL"},
+	}
+	changes, err := CreatePlan(templates, currentConfigs, existingRepos, "team-*")
+	assert.NoError(t, err)
+	for i := range changes {
+		changes[i].Template = nil
+	}
+	assert.Equal(t, []Change{
+		{Action: Create, Key: "team-libs", Rclass: "virtual", Attributes: []AttributeChange{
+			{Name: "key", Desired: "team-libs"},
+			{Name: "packageType", Desired: "maven"},
+			{Name: "rclass", Desired: "virtual"},
+			{Name: "repositories", Desired: "team-libs-local,team-libs-remote"},
+		}},
+		{Action: Update, Key: "team-libs-local", Rclass: "local", Attributes: []AttributeChange{
+			{Name: "description", Current: "Old description", Desired: "Local Maven libraries"},
+		}},
+		{Action: Delete, Key: "team-old", Rclass: "virtual"},
+		{Action: Delete, Key: "team-old-local", Rclass: "local"},
+	}, changes)
+
+	// Without pruning, the unmanaged repositories are kept.
+	changes, err = CreatePlan(templates, currentConfigs, existingRepos, "")
+	assert.NoError(t, err)
+	assert.Len(t, changes, 2)
+}
+
+func TestCreatePlanImmutableKey(t *testing.T) {
+	templates, err := ReadTemplates(templatesDir, "prefix=team")
+	assert.NoError(t, err)
+	currentConfigs := map[string]map[string]interface{}{"team-libs-local": {"key": "team-libs-local", "rclass": "local", "packageType": "npm"}}
+	_, err = CreatePlan(templates, currentConfigs, nil, "")
+	assert.ErrorContains(t, err, "packageType")
+}
+
+func TestFormatPlan(t *testing.T) {
+	changes := []Change{
+		{Action: Create, Key: "libs-local", Rclass: "local", Attributes: []AttributeChange{{Name: "key", Desired: "libs-local"}, {Name: "password", Desired: "secret"}}},
+		{Action: Update, Key: "libs-remote", Rclass: "remote", Attributes: []AttributeChange{{Name: "url", Current: "https://a", Desired: "https://b"}}},
+		{Action: Delete, Key: "old-local", Rclass: "local"},
+	}
+	expected := `  + local repository "libs-local"
+      + key      = "libs-local"
+      + password = (sensitive value)
+
+  ~ remote repository "libs-remote"
+      ~ url = "https://a" -> "https://b"
+
+  - local repository "old-local"
+
+Plan: 1 to create, 1 to update, 1 to delete.`
+	assert.Equal(t, expected, FormatPlan(changes))
+	assert.Equal(t, "No changes. The repositories match the templates.", FormatPlan(nil))
+}
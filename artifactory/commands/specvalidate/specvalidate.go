@@ -0,0 +1,335 @@
+package specvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/schema"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+
+	// The path of the spec's root, as reported by the schema validation.
+	rootPath = "(root)"
+)
+
+type Issue struct {
+	Line     int
+	Severity Severity
+	Message  string
+}
+
+// The spec rules of a command, matching the spec.ValidateSpec arguments of the command,
+// and the fields which the command ignores.
+type commandRules struct {
+	isTargetMandatory bool
+	isSearchBased     bool
+	ignoredFields     []string
+}
+
+var rulesByCommand = map[string]commandRules{
+	"upload": {isTargetMandatory: true, ignoredFields: []string{"aql", "build", "bundle", "excludeArtifacts", "includeDeps", "excludeProps",
+		"sortBy", "sortOrder", "limit", "offset", "validateSymlinks", "gpg-key", "transitive", "project"}},
+	"download": {isSearchBased: true, ignoredFields: []string{"ant", "archive", "symlinks", "targetPathInArchive"}},
+	"copy": {isTargetMandatory: true, isSearchBased: true, ignoredFields: []string{"ant", "archive", "symlinks", "targetPathInArchive",
+		"explode", "bypassArchiveInspection", "validateSymlinks", "gpg-key", "transitive"}},
+	"move": {isTargetMandatory: true, isSearchBased: true, ignoredFields: []string{"ant", "archive", "symlinks", "targetPathInArchive",
+		"explode", "bypassArchiveInspection", "validateSymlinks", "gpg-key", "transitive"}},
+	"delete": {isSearchBased: true, ignoredFields: []string{"ant", "archive", "symlinks", "targetPathInArchive", "target", "targetProps",
+		"flat", "explode", "bypassArchiveInspection", "validateSymlinks", "gpg-key"}},
+	"search": {isSearchBased: true, ignoredFields: []string{"ant", "archive", "symlinks", "targetPathInArchive", "target", "targetProps",
+		"flat", "explode", "bypassArchiveInspection", "validateSymlinks", "gpg-key"}},
+	"set-props": {isSearchBased: true, ignoredFields: []string{"ant", "archive", "symlinks", "targetPathInArchive", "target", "targetProps",
+		"flat", "explode", "bypassArchiveInspection", "validateSymlinks", "gpg-key"}},
+	"release-bundle": {isSearchBased: true, ignoredFields: []string{"ant", "archive", "symlinks", "targetPathInArchive", "flat", "explode",
+		"bypassArchiveInspection", "validateSymlinks", "transitive"}},
+}
+
+// Keys which are allowed by the schema, but aren't read by JFrog CLI, mapped to the keys which should be used instead.
+var unreadKeys = map[string]string{
+	"include-dirs":              "includeDirs",
+	"bypass-archive-inspection": "bypassArchiveInspection",
+}
+
+var unresolvedVarRegexp = regexp.MustCompile(`\$\{[^}]*}`)
+
+// Returns the commands whose specs can be validated, sorted.
+func GetCommands() []string {
+	commands := make([]string, 0, len(rulesByCommand))
+	for command := range rulesByCommand {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+func ValidateCommand(command string) error {
+	if _, exists := rulesByCommand[command]; command != "" && !exists {
+		return errorutils.CheckErrorf("the --for option accepts the following values: %s, but received '%s'", strings.Join(GetCommands(), ", "), command)
+	}
+	return nil
+}
+
+// Validates the content of a File Spec, after the spec vars were replaced.
+// If a command is provided, the spec is also validated against the rules of the command.
+// The issues are sorted by their lines.
+func Validate(content []byte, command string) ([]Issue, error) {
+	var syntaxErr *json.SyntaxError
+	if err := json.Unmarshal(content, new(interface{})); errors.As(err, &syntaxErr) {
+		return []Issue{{Line: getLine(content, syntaxErr.Offset), Severity: Error, Message: "invalid JSON: " + syntaxErr.Error()}}, nil
+	}
+	lines, err := mapLines(content)
+	if err != nil {
+		return []Issue{{Line: getLine(content, int64(len(content))), Severity: Error, Message: "invalid JSON: " + err.Error()}}, nil
+	}
+	issues, err := validateSchema(content, lines)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, findUnresolvedVars(content)...)
+	for path, line := range lines {
+		key := path[strings.LastIndex(path, ".")+1:]
+		if replacement, exists := unreadKeys[key]; exists {
+			issues = append(issues, Issue{Line: line, Severity: Warning, Message: fmt.Sprintf("'%s' is ignored by JFrog CLI, use '%s' instead", key, replacement)})
+		}
+	}
+	specFiles := new(spec.SpecFiles)
+	if err = json.Unmarshal(content, specFiles); err != nil {
+		// The schema validation already reports the fields with unexpected types.
+		log.Debug("Skipping the spec rules validation, since the spec couldn't be parsed:", err.Error())
+		return sortIssues(issues), nil
+	}
+	for i, file := range specFiles.Files {
+		issues = append(issues, validateFile(file, i, command, lines)...)
+	}
+	return sortIssues(issues), nil
+}
+
+func validateSchema(content []byte, lines map[string]int) ([]Issue, error) {
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema.FileSpecSchema), gojsonschema.NewBytesLoader(content))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var issues []Issue
+	for _, resultErr := range result.Errors() {
+		if resultErr.Type() == "additional_property_not_allowed" {
+			property := fmt.Sprint(resultErr.Details()["property"])
+			issues = append(issues, Issue{Line: lines[joinPath(resultErr.Field(), property)], Severity: Warning, Message: fmt.Sprintf("unknown key '%s'", property)})
+			continue
+		}
+		message := resultErr.Description()
+		if !strings.Contains(message, resultErr.Field()) {
+			message = resultErr.Field() + ": " + message
+		}
+		issues = append(issues, Issue{Line: lines[resultErr.Field()], Severity: Error, Message: message})
+	}
+	return issues, nil
+}
+
+func validateFile(file spec.File, index int, command string, lines map[string]int) []Issue {
+	groupPath := joinPath("files", strconv.Itoa(index))
+	var issues []Issue
+	if isExplode, _ := file.IsExplode(false); isExplode && file.Flat == "true" {
+		issues = append(issues, Issue{Line: lines[joinPath(groupPath, "explode")], Severity: Warning,
+			Message: "'explode' is used with 'flat', so the content of all the extracted archives is written to the same target directory, and files with the same names override each other"})
+	}
+	rules, exists := rulesByCommand[command]
+	if !exists {
+		return issues
+	}
+	if err := spec.ValidateSpec([]spec.File{file}, rules.isTargetMandatory, rules.isSearchBased); err != nil {
+		issues = append(issues, Issue{Line: lines[groupPath], Severity: Error, Message: err.Error()})
+	}
+	for _, field := range rules.ignoredFields {
+		if line, exists := lines[joinPath(groupPath, field)]; exists {
+			issues = append(issues, Issue{Line: line, Severity: Warning, Message: fmt.Sprintf("'%s' is ignored by the %s command", field, command)})
+		}
+	}
+	return issues
+}
+
+func findUnresolvedVars(content []byte) []Issue {
+	var issues []Issue
+	for _, match := range unresolvedVarRegexp.FindAllIndex(content, -1) {
+		issues = append(issues, Issue{Line: getLine(content, int64(match[0])), Severity: Warning,
+			Message: fmt.Sprintf("the variable '%s' isn't resolved, it can be provided by the --spec-vars option", content[match[0]:match[1]])})
+	}
+	return issues
+}
+
+func sortIssues(issues []Issue) []Issue {
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return issues
+}
+
+// Maps the paths of all the values in the JSON content to their lines.
+// The paths are in the format of the schema validation results, for example 'files.0.pattern'.
+// A key's path is mapped to the line of the key.
+func mapLines(content []byte) (map[string]int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	mapper := &lineMapper{content: content, decoder: decoder, lines: map[string]int{}}
+	if err := mapper.walk(rootPath); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("unexpected content after the end of the spec")
+		}
+		return nil, err
+	}
+	return mapper.lines, nil
+}
+
+type lineMapper struct {
+	content []byte
+	decoder *json.Decoder
+	lines   map[string]int
+}
+
+func (lm *lineMapper) walk(path string) error {
+	token, err := lm.decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if _, exists := lm.lines[path]; !exists {
+		lm.lines[path] = lm.currentLine()
+	}
+	delim, isDelim := token.(json.Delim)
+	if !isDelim {
+		return nil
+	}
+	switch delim {
+	case '{':
+		for lm.decoder.More() {
+			key, err := lm.decoder.Token()
+			if err != nil {
+				return err
+			}
+			childPath := joinPath(path, fmt.Sprint(key))
+			lm.lines[childPath] = lm.currentLine()
+			if err = lm.walk(childPath); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; lm.decoder.More(); i++ {
+			if err = lm.walk(joinPath(path, strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+	}
+	// Consume the closing delimiter.
+	_, err = lm.decoder.Token()
+	return err
+}
+
+// Returns the line of the last read token.
+func (lm *lineMapper) currentLine() int {
+	return getLine(lm.content, lm.decoder.InputOffset()-1)
+}
+
+// Returns the 1-based line of the provided offset.
+func getLine(content []byte, offset int64) int {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
+
+func joinPath(path, key string) string {
+	if path == rootPath || path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+type SpecValidateCommand struct {
+	specPath string
+	specVars map[string]string
+	command  string
+	issues   []Issue
+}
+
+func NewSpecValidateCommand() *SpecValidateCommand {
+	return &SpecValidateCommand{}
+}
+
+func (svc *SpecValidateCommand) SetSpecPath(specPath string) *SpecValidateCommand {
+	svc.specPath = specPath
+	return svc
+}
+
+func (svc *SpecValidateCommand) SetSpecVars(specVars map[string]string) *SpecValidateCommand {
+	svc.specVars = specVars
+	return svc
+}
+
+func (svc *SpecValidateCommand) SetCommand(command string) *SpecValidateCommand {
+	svc.command = command
+	return svc
+}
+
+func (svc *SpecValidateCommand) Issues() []Issue {
+	return svc.issues
+}
+
+// The spec is validated offline, so no usage is reported.
+func (svc *SpecValidateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (svc *SpecValidateCommand) CommandName() string {
+	return "rt_spec_validate"
+}
+
+func (svc *SpecValidateCommand) Run() error {
+	if err := ValidateCommand(svc.command); err != nil {
+		return err
+	}
+	content, err := fileutils.ReadFile(svc.specPath)
+	if err != nil {
+		return err
+	}
+	if len(svc.specVars) > 0 {
+		content = coreutils.ReplaceVars(content, svc.specVars)
+	}
+	if svc.issues, err = Validate(content, svc.command); err != nil {
+		return err
+	}
+	errorsCount := 0
+	for _, issue := range svc.issues {
+		if issue.Severity == Error {
+			errorsCount++
+		}
+		log.Output(fmt.Sprintf("%s:%d: %s: %s", svc.specPath, issue.Line, issue.Severity, issue.Message))
+	}
+	if errorsCount > 0 {
+		return errorutils.CheckErrorf("the spec file %s has %d errors and %d warnings", svc.specPath, errorsCount, len(svc.issues)-errorsCount)
+	}
+	log.Info(fmt.Sprintf("The spec file %s is valid, with %d warnings.", svc.specPath, len(svc.issues)))
+	return nil
+}
@@ -0,0 +1,69 @@
+package specvalidate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapLines(t *testing.T) {
+	content := []byte("{\n  \"files\": [\n    {\n      \"pattern\": \"a/*\",\n      \"exclusions\": [\"b\",\n \"c\"]\n    }\n  ]\n}")
+	lines, err := mapLines(content)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"(root)":               1,
+		"files":                2,
+		"files.0":              3,
+		"files.0.pattern":      4,
+		"files.0.exclusions":   5,
+		"files.0.exclusions.0": 5,
+		"files.0.exclusions.1": 6,
+	}, lines)
+}
+
+func TestValidate(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "spec.json"))
+	assert.NoError(t, err)
+	tests := []struct {
+		command        string
+		expectedIssues []Issue
+	}{
+		{"", []Issue{
+			{4, Warning, "the variable '${dir}' isn't resolved, it can be provided by the --spec-vars option"},
+			{7, Warning, "'explode' is used with 'flat', so the content of all the extracted archives is written to the same target directory, and files with the same names override each other"},
+			{8, Warning, "unknown key 'foo'"},
+			{9, Warning, "'include-dirs' is ignored by JFrog CLI, use 'includeDirs' instead"},
+			{13, Error, "files.1.recursive must be one of the following: \"true\", \"false\""},
+		}},
+		{"upload", []Issue{
+			{4, Warning, "the variable '${dir}' isn't resolved, it can be provided by the --spec-vars option"},
+			{7, Warning, "'explode' is used with 'flat', so the content of all the extracted archives is written to the same target directory, and files with the same names override each other"},
+			{8, Warning, "unknown key 'foo'"},
+			{9, Warning, "'include-dirs' is ignored by JFrog CLI, use 'includeDirs' instead"},
+			{11, Error, "spec must include a pattern"},
+			{13, Error, "files.1.recursive must be one of the following: \"true\", \"false\""},
+			{14, Warning, "'build' is ignored by the upload command"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.command, func(t *testing.T) {
+			issues, err := Validate(content, test.command)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedIssues, issues)
+		})
+	}
+}
+
+func TestValidateInvalidJson(t *testing.T) {
+	issues, err := Validate([]byte("{\n  \"files\": [\n    {\"pattern\": \"a/*\",}\n  ]\n}"), "download")
+	assert.NoError(t, err)
+	assert.Equal(t, []Issue{{3, Error, "invalid JSON: invalid character '}' looking for beginning of object key string"}}, issues)
+}
+
+func TestValidateCommand(t *testing.T) {
+	assert.NoError(t, ValidateCommand(""))
+	assert.NoError(t, ValidateCommand("set-props"))
+	assert.Error(t, ValidateCommand("build-publish"))
+}
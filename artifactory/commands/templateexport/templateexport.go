@@ -0,0 +1,415 @@
+package templateexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/permissiontarget"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/replication"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/repository"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/repotemplate"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type Entity string
+
+const (
+	Repositories      Entity = "repo"
+	Replications      Entity = "replication"
+	PermissionTargets Entity = "permission-target"
+)
+
+// The repository configuration keys accepted by the repo-create and repo-update commands.
+var repoTemplateKeys = []string{
+	repository.Key, repository.Rclass, repository.PackageType, repository.Url, repository.Description, repository.Notes,
+	repository.IncludePatterns, repository.ExcludePatterns, repository.RepoLayoutRef, repository.ProjectKey, repository.HandleReleases,
+	repository.HandleSnapshots, repository.MaxUniqueSnapshots, repository.SuppressPomConsistencyChecks, repository.BlackedOut,
+	repository.DownloadRedirect, repository.BlockPushingSchema1, repository.DebianTrivialLayout, repository.ExternalDependenciesEnabled,
+	repository.ExternalDependenciesPatterns, repository.ChecksumPolicyType, repository.MaxUniqueTags, repository.SnapshotVersionBehavior,
+	repository.XrayIndex, repository.PropertySets, repository.ArchiveBrowsingEnabled, repository.CalculateYumMetadata,
+	repository.YumRootDepth, repository.DockerApiVersion, repository.EnableFileListsIndexing, repository.OptionalIndexCompressionFormats,
+	repository.Username, repository.Password, repository.Proxy, repository.RemoteRepoChecksumPolicyType, repository.HardFail,
+	repository.Offline, repository.StoreArtifactsLocally, repository.SocketTimeoutMillis, repository.LocalAddress,
+	repository.RetrievalCachePeriodSecs, repository.FailedRetrievalCachePeriodSecs, repository.MissedRetrievalCachePeriodSecs,
+	repository.UnusedArtifactsCleanupEnabled, repository.UnusedArtifactsCleanupPeriodHours, repository.AssumedOfflinePeriodSecs,
+	repository.FetchJarsEagerly, repository.FetchSourcesEagerly, repository.ShareConfiguration, repository.SynchronizeProperties,
+	repository.BlockMismatchingMimeTypes, repository.AllowAnyHostAuth, repository.EnableCookieManagement, repository.BowerRegistryUrl,
+	repository.ComposerRegistryUrl, repository.PyPIRegistryUrl, repository.VcsType, repository.VcsGitProvider,
+	repository.VcsGitDownloadUrl, repository.BypassHeadRequests, repository.ClientTlsCertificate, repository.FeedContextPath,
+	repository.DownloadContextPath, repository.V3FeedUrl, repository.ContentSynchronisation, repository.ListRemoteFolderItems,
+	repository.RejectInvalidJars, repository.PodsSpecsRepoUrl, repository.EnableTokenAuthentication, repository.Repositories,
+	repository.ArtifactoryRequestsCanRetrieveRemoteArtifacts, repository.KeyPair, repository.PomRepositoryReferencesCleanupPolicy,
+	repository.DefaultDeploymentRepo, repository.ForceMavenAuthentication, repository.ForceNugetAuthentication,
+	repository.ExternalDependenciesRemoteRepo,
+}
+
+// Converts the configuration of a repository, as returned by Artifactory, to a repository template.
+// Empty values are omitted, and secrets are replaced by template variables.
+func RepoConfigToTemplate(repoConfig map[string]interface{}) map[string]interface{} {
+	template := make(map[string]interface{})
+	for _, templateKey := range repoTemplateKeys {
+		if value := repotemplate.FormatValue(templateKey, repoConfig[templateKey]); value != "" {
+			template[templateKey] = value
+		}
+	}
+	repotemplate.RedactSecrets(repotemplate.FormatValue(repository.Key, repoConfig[repository.Key]), template)
+	return template
+}
+
+// Converts a replication to a replication template.
+// The target server of a push replication is identified by the configured server whose Artifactory URL prefixes the replication URL.
+// If no such server is configured, its ID is replaced by a template variable.
+func ReplicationToTemplate(params servicesUtils.ReplicationParams, servers []*config.ServerDetails) map[string]interface{} {
+	template := map[string]interface{}{
+		replication.RepoKey:                params.RepoKey,
+		replication.CronExp:                params.CronExp,
+		replication.EnableEventReplication: strconv.FormatBool(params.EnableEventReplication),
+		replication.Enabled:                strconv.FormatBool(params.Enabled),
+		replication.SyncDeletes:            strconv.FormatBool(params.SyncDeletes),
+		replication.SyncProperties:         strconv.FormatBool(params.SyncProperties),
+		replication.SyncStatistics:         strconv.FormatBool(params.SyncStatistics),
+	}
+	if params.SocketTimeoutMillis > 0 {
+		template[replication.SocketTimeoutMillis] = strconv.Itoa(params.SocketTimeoutMillis)
+	}
+	if pathPrefix := params.IncludePathPrefixPattern; pathPrefix != "" || params.PathPrefix != "" {
+		if pathPrefix == "" {
+			pathPrefix = params.PathPrefix
+		}
+		template[replication.IncludePathPrefixPattern] = pathPrefix
+	}
+	// Pull replications have no URL, since they replicate from the URL of the remote repository.
+	if params.Url == "" {
+		return template
+	}
+	replicationUrl := strings.TrimSuffix(params.Url, "/")
+	template[replication.ServerId] = "${" + params.RepoKey + "-target-server-id}"
+	template[replication.TargetRepoKey] = replicationUrl[strings.LastIndex(replicationUrl, "/")+1:]
+	for _, server := range servers {
+		artifactoryUrl := strings.TrimSuffix(server.ArtifactoryUrl, "/")
+		if artifactoryUrl != "" && strings.HasPrefix(replicationUrl, artifactoryUrl+"/") {
+			template[replication.ServerId] = server.ServerId
+			template[replication.TargetRepoKey] = strings.TrimPrefix(replicationUrl, artifactoryUrl+"/")
+			break
+		}
+	}
+	return template
+}
+
+// Converts a permission target to a permission target template.
+func PermissionTargetToTemplate(params *services.PermissionTargetParams) map[string]interface{} {
+	template := map[string]interface{}{permissiontarget.Name: params.Name}
+	sections := []struct {
+		key     string
+		section *services.PermissionTargetSection
+	}{{permissiontarget.Repo, params.Repo}, {permissiontarget.Build, params.Build}, {permissiontarget.ReleaseBundle, params.ReleaseBundle}}
+	for _, section := range sections {
+		if section.section == nil {
+			continue
+		}
+		answer := permissiontarget.PermissionSectionAnswer{
+			IncludePatterns: strings.Join(section.section.IncludePatterns, ","),
+			ExcludePatterns: strings.Join(section.section.ExcludePatterns, ","),
+		}
+		// The repositories of the build section can't be changed, so they aren't accepted by the template.
+		if section.key != permissiontarget.Build {
+			answer.Repositories = strings.Join(section.section.Repositories, ",")
+		}
+		if section.section.Actions != nil {
+			answer.ActionsUsers = joinActions(section.section.Actions.Users)
+			answer.ActionsGroups = joinActions(section.section.Actions.Groups)
+		}
+		template[section.key] = answer
+	}
+	return template
+}
+
+func joinActions(actions map[string][]string) map[string]string {
+	if len(actions) == 0 {
+		return nil
+	}
+	joined := make(map[string]string, len(actions))
+	for name, permissions := range actions {
+		joined[name] = strings.Join(permissions, ",")
+	}
+	return joined
+}
+
+var varPattern = regexp.MustCompile(`^\$\{[^}]*}$`)
+
+// Replaces the values of the provided variables in the template's string values with the variables, so that the template can be reused with different values.
+// This is the opposite of the variables replacement done by the commands which use the template. Longer values are replaced first.
+func ExtractVars(template map[string]interface{}, vars map[string]string) map[string]interface{} {
+	if len(vars) == 0 {
+		return template
+	}
+	names := make([]string, 0, len(vars))
+	for name, value := range vars {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(vars[names[i]]) != len(vars[names[j]]) {
+			return len(vars[names[i]]) > len(vars[names[j]])
+		}
+		return names[i] < names[j]
+	})
+	replace := func(value string) string {
+		// Values which are already variables, such as the exported secrets, are kept as is.
+		if varPattern.MatchString(value) {
+			return value
+		}
+		for _, name := range names {
+			value = strings.ReplaceAll(value, vars[name], "${"+name+"}")
+		}
+		return value
+	}
+	for key, value := range template {
+		switch typedValue := value.(type) {
+		case string:
+			template[key] = replace(typedValue)
+		case permissiontarget.PermissionSectionAnswer:
+			typedValue.Repositories = replace(typedValue.Repositories)
+			typedValue.IncludePatterns = replace(typedValue.IncludePatterns)
+			typedValue.ExcludePatterns = replace(typedValue.ExcludePatterns)
+			template[key] = typedValue
+		}
+	}
+	return template
+}
+
+type TemplateExportCommand struct {
+	serverDetails *config.ServerDetails
+	entity        Entity
+	outputDir     string
+	pattern       string
+	vars          string
+	exportedPaths []string
+}
+
+func NewTemplateExportCommand(entity Entity) *TemplateExportCommand {
+	return &TemplateExportCommand{entity: entity}
+}
+
+func (tec *TemplateExportCommand) SetServerDetails(serverDetails *config.ServerDetails) *TemplateExportCommand {
+	tec.serverDetails = serverDetails
+	return tec
+}
+
+func (tec *TemplateExportCommand) SetOutputDir(outputDir string) *TemplateExportCommand {
+	tec.outputDir = outputDir
+	return tec
+}
+
+// Only the entities whose keys match the pattern are exported. The pattern may include wildcards.
+func (tec *TemplateExportCommand) SetPattern(pattern string) *TemplateExportCommand {
+	tec.pattern = pattern
+	return tec
+}
+
+func (tec *TemplateExportCommand) SetVars(vars string) *TemplateExportCommand {
+	tec.vars = vars
+	return tec
+}
+
+func (tec *TemplateExportCommand) ExportedPaths() []string {
+	return tec.exportedPaths
+}
+
+func (tec *TemplateExportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return tec.serverDetails, nil
+}
+
+func (tec *TemplateExportCommand) CommandName() string {
+	return "rt_" + strings.ReplaceAll(string(tec.entity), "-", "_") + "_export"
+}
+
+func (tec *TemplateExportCommand) Run() (err error) {
+	servicesManager, err := rtUtils.CreateServiceManager(tec.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	var templates map[string]map[string]interface{}
+	switch tec.entity {
+	case Repositories:
+		templates, err = tec.getRepoTemplates(servicesManager)
+	case Replications:
+		templates, err = tec.getReplicationTemplates(servicesManager)
+	case PermissionTargets:
+		templates, err = tec.getPermissionTargetTemplates(servicesManager)
+	default:
+		err = errorutils.CheckErrorf("unsupported entity: %s", tec.entity)
+	}
+	if err != nil {
+		return err
+	}
+	if len(templates) == 0 {
+		log.Info("No matching configuration was found.")
+		return nil
+	}
+	if err = fileutils.CreateDirIfNotExist(tec.outputDir); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	vars := coreutils.SpecVarsStringToMap(tec.vars)
+	for _, name := range names {
+		// The secrets of all the entities are replaced by template variables, so that they're never written to the templates.
+		for _, secretKey := range repotemplate.RedactSecrets(name, templates[name]) {
+			log.Info(fmt.Sprintf("The %s of '%s' is exported as the %s variable.", secretKey, name, repotemplate.SecretVar(name, secretKey)))
+		}
+		if err = tec.writeTemplate(name, ExtractVars(templates[name], vars)); err != nil {
+			return err
+		}
+	}
+	log.Info(fmt.Sprintf("Exported %d templates to %s.", len(tec.exportedPaths), tec.outputDir))
+	return nil
+}
+
+func (tec *TemplateExportCommand) matches(key string) (bool, error) {
+	if tec.pattern == "" {
+		return true, nil
+	}
+	matched, err := filepath.Match(tec.pattern, key)
+	return matched, errorutils.CheckError(err)
+}
+
+func (tec *TemplateExportCommand) getRepoTemplates(servicesManager artifactory.ArtifactoryServicesManager) (map[string]map[string]interface{}, error) {
+	repos, err := servicesManager.GetAllRepositories()
+	if err != nil {
+		return nil, err
+	}
+	templates := make(map[string]map[string]interface{})
+	for _, repo := range *repos {
+		if matched, err := tec.matches(repo.Key); err != nil || !matched {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		repoConfig := make(map[string]interface{})
+		if err = servicesManager.GetRepository(repo.Key, &repoConfig); err != nil {
+			return nil, err
+		}
+		templates[repo.Key] = RepoConfigToTemplate(repoConfig)
+	}
+	return templates, nil
+}
+
+func (tec *TemplateExportCommand) getReplicationTemplates(servicesManager artifactory.ArtifactoryServicesManager) (map[string]map[string]interface{}, error) {
+	// Get the keys of the replicated repositories.
+	var replications []struct {
+		RepoKey string `json:"repoKey"`
+	}
+	if err := getJson(servicesManager, "api/replications", &replications); err != nil {
+		return nil, err
+	}
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return nil, err
+	}
+	templates := make(map[string]map[string]interface{})
+	exported := make(map[string]bool)
+	for _, repo := range replications {
+		if matched, err := tec.matches(repo.RepoKey); err != nil || !matched || exported[repo.RepoKey] {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		exported[repo.RepoKey] = true
+		repoReplications, err := servicesManager.GetReplication(repo.RepoKey)
+		if err != nil {
+			return nil, err
+		}
+		// A local repository may have multiple push replications, so a template is exported for each one.
+		for i, params := range repoReplications {
+			name := repo.RepoKey
+			if len(repoReplications) > 1 {
+				name += "-" + strconv.Itoa(i+1)
+			}
+			templates[name] = ReplicationToTemplate(params, servers)
+		}
+	}
+	return templates, nil
+}
+
+func (tec *TemplateExportCommand) getPermissionTargetTemplates(servicesManager artifactory.ArtifactoryServicesManager) (map[string]map[string]interface{}, error) {
+	var permissionTargets []struct {
+		Name string `json:"name"`
+	}
+	if err := getJson(servicesManager, "api/v2/security/permissions", &permissionTargets); err != nil {
+		return nil, err
+	}
+	templates := make(map[string]map[string]interface{})
+	for _, permissionTarget := range permissionTargets {
+		if matched, err := tec.matches(permissionTarget.Name); err != nil || !matched {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		params, err := servicesManager.GetPermissionTarget(permissionTarget.Name)
+		if err != nil {
+			return nil, err
+		}
+		if params != nil {
+			templates[permissionTarget.Name] = PermissionTargetToTemplate(params)
+		}
+	}
+	return templates, nil
+}
+
+// Sends a GET request to the provided Artifactory REST API, and unmarshals the response.
+func getJson(servicesManager artifactory.ArtifactoryServicesManager, restApi string, result interface{}) error {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(serviceDetails.GetUrl()+restApi, true, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return err
+	}
+	return errorutils.CheckError(json.Unmarshal(body, result))
+}
+
+func (tec *TemplateExportCommand) writeTemplate(name string, template map[string]interface{}) error {
+	templatePath := filepath.Join(tec.outputDir, name+".json")
+	exists, err := fileutils.IsFileExists(templatePath, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errorutils.CheckErrorf("the template %s already exists", templatePath)
+	}
+	content, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(templatePath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Debug("Exported", templatePath)
+	tec.exportedPaths = append(tec.exportedPaths, templatePath)
+	return nil
+}
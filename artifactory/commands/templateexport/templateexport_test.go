@@ -0,0 +1,94 @@
+package templateexport
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/permissiontarget"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoConfigToTemplate(t *testing.T) {
+	repoConfig := map[string]interface{}{
+		"key":                    "team-a-remote",
+		"rclass":                 "remote",
+		"packageType":            "npm",
+		"url":                    "https://registry.npmjs.org",
+		"username":               "admin",
+		"password":               "AES128:encrypted",
+		"description":            "",
+		"xrayIndex":              true,
+		"socketTimeoutMillis":    float64(15000),
+		"propertySets":           []interface{}{"artifactory", "custom"},
+		"contentSynchronisation": map[string]interface{}{"enabled": true, "statistics": map[string]interface{}{"enabled": false}, "source": map[string]interface{}{"originAbsenceDetection": true}},
+		"unknownKey":             "value",
+	}
+	assert.Equal(t, map[string]interface{}{
+		"key":                    "team-a-remote",
+		"rclass":                 "remote",
+		"packageType":            "npm",
+		"url":                    "https://registry.npmjs.org",
+		"username":               "${team-a-remote-username}",
+		"password":               "${team-a-remote-password}",
+		"xrayIndex":              "true",
+		"socketTimeoutMillis":    "15000",
+		"propertySets":           "artifactory,custom",
+		"contentSynchronisation": "true,false,false,true",
+	}, RepoConfigToTemplate(repoConfig))
+}
+
+func TestReplicationToTemplate(t *testing.T) {
+	params := servicesUtils.ReplicationParams{Url: "https://target.example.com/artifactory/libs-copy", RepoKey: "libs-local", CronExp: "0 0 12 * * ?",
+		Enabled: true, SyncDeletes: true, SocketTimeoutMillis: 15000, PathPrefix: "com/"}
+	expected := map[string]interface{}{
+		"repoKey":                  "libs-local",
+		"cronExp":                  "0 0 12 * * ?",
+		"enableEventReplication":   "false",
+		"enabled":                  "true",
+		"syncDeletes":              "true",
+		"syncProperties":           "false",
+		"syncStatistics":           "false",
+		"socketTimeoutMillis":      "15000",
+		"includePathPrefixPattern": "com/",
+		"serverId":                 "target",
+		"targetRepoKey":            "libs-copy",
+	}
+	servers := []*config.ServerDetails{{ServerId: "other", ArtifactoryUrl: "https://other.example.com/artifactory/"}, {ServerId: "target", ArtifactoryUrl: "https://target.example.com/artifactory/"}}
+	assert.Equal(t, expected, ReplicationToTemplate(params, servers))
+
+	// Without a matching server, the server ID is exported as a variable.
+	expected["serverId"] = "${libs-local-target-server-id}"
+	assert.Equal(t, expected, ReplicationToTemplate(params, servers[:1]))
+}
+
+func TestPermissionTargetToTemplate(t *testing.T) {
+	params := &services.PermissionTargetParams{
+		Name: "team-a-deployers",
+		Repo: &services.PermissionTargetSection{IncludePatterns: []string{"**"}, Repositories: []string{"team-a-local", "team-a-remote"},
+			Actions: &services.Actions{Users: map[string][]string{"ci": {"read", "write"}}, Groups: map[string][]string{"team-a": {"read"}}}},
+		Build: &services.PermissionTargetSection{IncludePatterns: []string{"team-a/**"}, Repositories: []string{"artifactory-build-info"}},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"name": "team-a-deployers",
+		"repo": permissiontarget.PermissionSectionAnswer{IncludePatterns: "**", Repositories: "team-a-local,team-a-remote",
+			ActionsUsers: map[string]string{"ci": "read,write"}, ActionsGroups: map[string]string{"team-a": "read"}},
+		"build": permissiontarget.PermissionSectionAnswer{IncludePatterns: "team-a/**"},
+	}, PermissionTargetToTemplate(params))
+}
+
+func TestExtractVars(t *testing.T) {
+	template := map[string]interface{}{
+		"key":      "team-a-remote",
+		"url":      "https://team-a.example.com/npm",
+		"password": "${team-a-remote-password}",
+		"repo":     permissiontarget.PermissionSectionAnswer{Repositories: "team-a-local,team-a-remote"},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"key":      "${team}-remote",
+		"url":      "https://${host}/npm",
+		"password": "${team-a-remote-password}",
+		"repo":     permissiontarget.PermissionSectionAnswer{Repositories: "${team}-local,${team}-remote"},
+	}, ExtractVars(template, map[string]string{"team": "team-a", "host": "team-a.example.com"}))
+}
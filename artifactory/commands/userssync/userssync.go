@@ -0,0 +1,469 @@
+package userssync
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jszwec/csvutil"
+	"gopkg.in/yaml.v2"
+)
+
+type ActionType string
+
+const (
+	CreateGroup ActionType = "create-group"
+	Create      ActionType = "create"
+	Update      ActionType = "update"
+	Deactivate  ActionType = "deactivate"
+)
+
+type ResultStatus string
+
+const (
+	Success ResultStatus = "success"
+	Failure ResultStatus = "failure"
+)
+
+// The only realm whose users are managed by Artifactory, and can therefore be deactivated.
+const internalRealm = "internal"
+
+// A user, as defined in the users file. Nil fields aren't managed by the sync.
+type UserEntry struct {
+	Name     string    `yaml:"username"`
+	Email    string    `yaml:"email"`
+	Password string    `yaml:"password"`
+	Admin    *bool     `yaml:"admin"`
+	Groups   *[]string `yaml:"groups"`
+}
+
+// The YAML users file format.
+type usersFile struct {
+	Users []UserEntry `yaml:"users"`
+}
+
+// The CSV users file format. The columns are the same as the columns of the users-create command's CSV,
+// with an additional groups column, holding comma-separated group names.
+type csvUser struct {
+	Name     string `csv:"username"`
+	Email    string `csv:"email,omitempty"`
+	Password string `csv:"password,omitempty"`
+	Admin    string `csv:"admin,omitempty"`
+	Groups   string `csv:"groups,omitempty"`
+}
+
+type Action struct {
+	Type    ActionType `col-name:"Action" json:"action"`
+	Name    string     `col-name:"Name" json:"name"`
+	Details string     `col-name:"Details" json:"details,omitempty"`
+	// The user details to create or update. Nil for group creations.
+	user *services.User
+}
+
+type Result struct {
+	Action ActionType   `col-name:"Action" json:"action"`
+	Name   string       `col-name:"Name" json:"name"`
+	Status ResultStatus `col-name:"Status" json:"status"`
+	Error  string       `col-name:"Error" json:"error,omitempty"`
+}
+
+// Reads the users file. The format is determined by the file extension, which should be .csv, .yaml or .yml.
+func ReadUsersFile(path string) ([]UserEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var users []UserEntry
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		file := new(usersFile)
+		if err = yaml.UnmarshalStrict(content, file); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the users file %s: %s", path, err.Error())
+		}
+		users = file.Users
+	case ".csv":
+		if users, err = parseCsv(content); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the users file %s: %s", path, err.Error())
+		}
+	default:
+		return nil, errorutils.CheckErrorf("unsupported users file %s. The file extension should be .csv, .yaml or .yml", path)
+	}
+	names := make(map[string]bool, len(users))
+	for _, user := range users {
+		if user.Name == "" {
+			return nil, errorutils.CheckErrorf("the users file %s includes a user without a username", path)
+		}
+		if names[user.Name] {
+			return nil, errorutils.CheckErrorf("the user '%s' is defined more than once in the users file %s", user.Name, path)
+		}
+		names[user.Name] = true
+	}
+	return users, nil
+}
+
+func parseCsv(content []byte) ([]UserEntry, error) {
+	var csvUsers []csvUser
+	if err := csvutil.Unmarshal(content, &csvUsers); err != nil {
+		return nil, err
+	}
+	users := make([]UserEntry, 0, len(csvUsers))
+	for _, csvUser := range csvUsers {
+		user := UserEntry{Name: csvUser.Name, Email: csvUser.Email, Password: csvUser.Password}
+		if csvUser.Admin != "" {
+			admin, err := strconv.ParseBool(csvUser.Admin)
+			if err != nil {
+				return nil, errorutils.CheckErrorf("invalid admin value '%s' of the user '%s'", csvUser.Admin, csvUser.Name)
+			}
+			user.Admin = &admin
+		}
+		if csvUser.Groups != "" {
+			groups := strings.Split(csvUser.Groups, ",")
+			for i := range groups {
+				groups[i] = strings.TrimSpace(groups[i])
+			}
+			user.Groups = &groups
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// Computes the actions required to make Artifactory match the users file.
+// currentUsers holds the details of all the existing users, mapped by their names, and existingGroups holds the names of the existing groups.
+// If deactivateMissing is true, the internal users which are missing from the file are deactivated, except for the protected users.
+func CreatePlan(users []UserEntry, currentUsers map[string]*services.User, existingGroups []string, deactivateMissing bool, protectedUsers []string) []Action {
+	var actions []Action
+	groups := make(map[string]bool, len(existingGroups))
+	for _, group := range existingGroups {
+		groups[group] = true
+	}
+	inFile := make(map[string]bool, len(users))
+	for _, user := range users {
+		inFile[user.Name] = true
+		if user.Groups != nil {
+			for _, group := range *user.Groups {
+				if !groups[group] {
+					groups[group] = true
+					actions = append(actions, Action{Type: CreateGroup, Name: group})
+				}
+			}
+		}
+		current, exists := currentUsers[user.Name]
+		if !exists {
+			actions = append(actions, createAction(user))
+			continue
+		}
+		if action := updateAction(user, current); action != nil {
+			actions = append(actions, *action)
+		}
+	}
+	if deactivateMissing {
+		protected := make(map[string]bool, len(protectedUsers))
+		for _, name := range protectedUsers {
+			protected[name] = true
+		}
+		for name, current := range currentUsers {
+			if inFile[name] || protected[name] || strings.HasPrefix(name, "_") || !strings.EqualFold(current.Realm, internalRealm) || isDeactivated(current) {
+				continue
+			}
+			actions = append(actions, Action{Type: Deactivate, Name: name, Details: "groups removed, password and UI access disabled",
+				user: &services.User{Name: name, Groups: &[]string{}, InternalPasswordDisabled: boolPtr(true), DisableUIAccess: boolPtr(true)}})
+		}
+	}
+	actionOrder := map[ActionType]int{CreateGroup: 0, Create: 1, Update: 2, Deactivate: 3}
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i].Type != actions[j].Type {
+			return actionOrder[actions[i].Type] < actionOrder[actions[j].Type]
+		}
+		return actions[i].Name < actions[j].Name
+	})
+	return actions
+}
+
+func createAction(user UserEntry) Action {
+	details := &services.User{Name: user.Name, Email: user.Email, Password: user.Password, Admin: user.Admin, Groups: user.Groups}
+	var descriptions []string
+	if user.Admin != nil && *user.Admin {
+		descriptions = append(descriptions, "admin")
+	}
+	if user.Groups != nil && len(*user.Groups) > 0 {
+		descriptions = append(descriptions, "groups: "+strings.Join(*user.Groups, ","))
+	}
+	return Action{Type: Create, Name: user.Name, Details: strings.Join(descriptions, "; "), user: details}
+}
+
+// Returns the update required for the user, or nil if the user matches the file.
+// The password isn't compared, since it can't be read from Artifactory. It is only sent with other updates.
+func updateAction(user UserEntry, current *services.User) *Action {
+	var descriptions []string
+	details := &services.User{Name: user.Name, Email: current.Email, Admin: current.Admin, Groups: current.Groups}
+	if user.Email != "" && user.Email != current.Email {
+		descriptions = append(descriptions, "email: "+current.Email+" -> "+user.Email)
+		details.Email = user.Email
+	}
+	if user.Admin != nil && *user.Admin != (current.Admin != nil && *current.Admin) {
+		descriptions = append(descriptions, "admin: "+strconv.FormatBool(!*user.Admin)+" -> "+strconv.FormatBool(*user.Admin))
+		details.Admin = user.Admin
+	}
+	if user.Groups != nil {
+		var currentGroups []string
+		if current.Groups != nil {
+			currentGroups = *current.Groups
+		}
+		added, removed := diffGroups(currentGroups, *user.Groups)
+		if len(added) > 0 {
+			descriptions = append(descriptions, "add groups: "+strings.Join(added, ","))
+		}
+		if len(removed) > 0 {
+			descriptions = append(descriptions, "remove groups: "+strings.Join(removed, ","))
+		}
+		details.Groups = user.Groups
+	}
+	if len(descriptions) == 0 {
+		return nil
+	}
+	details.Password = user.Password
+	return &Action{Type: Update, Name: user.Name, Details: strings.Join(descriptions, "; "), user: details}
+}
+
+// Returns an error if the plan creates users without passwords, since Artifactory requires a password for creating a user.
+func ValidatePlan(actions []Action) error {
+	var noPassword []string
+	for _, action := range actions {
+		if action.Type == Create && action.user.Password == "" {
+			noPassword = append(noPassword, action.Name)
+		}
+	}
+	if len(noPassword) > 0 {
+		return errorutils.CheckErrorf("the following users are created by the plan, but have no password in the users file: %s", strings.Join(noPassword, ", "))
+	}
+	return nil
+}
+
+// Returns the name of the user running the command. If the server is configured with an access token and no username,
+// the name is taken from the subject of the token. Returns an empty string if the user can't be determined.
+func CurrentUserName(serverDetails *config.ServerDetails) string {
+	if serverDetails.User != "" || serverDetails.AccessToken == "" {
+		return serverDetails.User
+	}
+	return auth.ExtractUsernameFromAccessToken(serverDetails.AccessToken)
+}
+
+// Returns the groups to add and remove, so that the current groups match the desired groups.
+func diffGroups(current, desired []string) (added, removed []string) {
+	currentSet := make(map[string]bool, len(current))
+	for _, group := range current {
+		currentSet[group] = true
+	}
+	desiredSet := make(map[string]bool, len(desired))
+	for _, group := range desired {
+		desiredSet[group] = true
+		if !currentSet[group] {
+			added = append(added, group)
+		}
+	}
+	for _, group := range current {
+		if !desiredSet[group] {
+			removed = append(removed, group)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return
+}
+
+func isDeactivated(user *services.User) bool {
+	return user.InternalPasswordDisabled != nil && *user.InternalPasswordDisabled &&
+		user.DisableUIAccess != nil && *user.DisableUIAccess &&
+		(user.Groups == nil || len(*user.Groups) == 0)
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+type UsersSyncCommand struct {
+	serverDetails     *config.ServerDetails
+	usersFilePath     string
+	deactivateMissing bool
+	dryRun            bool
+	quiet             bool
+	reportFilePath    string
+	actions           []Action
+	results           []Result
+}
+
+func NewUsersSyncCommand() *UsersSyncCommand {
+	return &UsersSyncCommand{}
+}
+
+func (usc *UsersSyncCommand) SetServerDetails(serverDetails *config.ServerDetails) *UsersSyncCommand {
+	usc.serverDetails = serverDetails
+	return usc
+}
+
+func (usc *UsersSyncCommand) SetUsersFilePath(usersFilePath string) *UsersSyncCommand {
+	usc.usersFilePath = usersFilePath
+	return usc
+}
+
+func (usc *UsersSyncCommand) SetDeactivateMissing(deactivateMissing bool) *UsersSyncCommand {
+	usc.deactivateMissing = deactivateMissing
+	return usc
+}
+
+func (usc *UsersSyncCommand) SetDryRun(dryRun bool) *UsersSyncCommand {
+	usc.dryRun = dryRun
+	return usc
+}
+
+func (usc *UsersSyncCommand) SetQuiet(quiet bool) *UsersSyncCommand {
+	usc.quiet = quiet
+	return usc
+}
+
+func (usc *UsersSyncCommand) SetReportFilePath(reportFilePath string) *UsersSyncCommand {
+	usc.reportFilePath = reportFilePath
+	return usc
+}
+
+func (usc *UsersSyncCommand) Actions() []Action {
+	return usc.actions
+}
+
+func (usc *UsersSyncCommand) Results() []Result {
+	return usc.results
+}
+
+func (usc *UsersSyncCommand) ServerDetails() (*config.ServerDetails, error) {
+	return usc.serverDetails, nil
+}
+
+func (usc *UsersSyncCommand) CommandName() string {
+	return "rt_users_sync"
+}
+
+func (usc *UsersSyncCommand) Run() error {
+	users, err := ReadUsersFile(usc.usersFilePath)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(usc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	currentUsers, err := getCurrentUsers(servicesManager, users, usc.deactivateMissing)
+	if err != nil {
+		return err
+	}
+	existingGroups, err := servicesManager.GetAllGroups()
+	if err != nil {
+		return err
+	}
+	// The anonymous user and the user running the command are never deactivated.
+	protectedUsers := []string{"anonymous"}
+	if usc.deactivateMissing {
+		userName := CurrentUserName(usc.serverDetails)
+		if userName == "" {
+			return errorutils.CheckErrorf("the user running the command couldn't be determined, and might be deactivated by the --deactivate-missing option. " +
+				"Configure the server with a username, or with an access token whose subject is the user")
+		}
+		protectedUsers = append(protectedUsers, userName)
+	}
+	usc.actions = CreatePlan(users, currentUsers, *existingGroups, usc.deactivateMissing, protectedUsers)
+	if err = coreutils.PrintTable(usc.actions, "Users Sync Plan", "The users match the users file", false); err != nil {
+		return err
+	}
+	if err = ValidatePlan(usc.actions); err != nil {
+		return err
+	}
+	if len(usc.actions) == 0 || usc.dryRun {
+		return nil
+	}
+	if !usc.quiet && !coreutils.AskYesNo("Are you sure you want to apply the plan?", false) {
+		return nil
+	}
+	failures := 0
+	for _, action := range usc.actions {
+		result := Result{Action: action.Type, Name: action.Name, Status: Success}
+		if err = apply(servicesManager, action); err != nil {
+			log.Error("Failed to " + string(action.Type) + " " + action.Name + ": " + err.Error())
+			result.Status, result.Error = Failure, err.Error()
+			failures++
+		}
+		usc.results = append(usc.results, result)
+	}
+	if err = coreutils.PrintTable(usc.results, "Users Sync Results", "No actions were applied", false); err != nil {
+		return err
+	}
+	if err = usc.writeReport(); err != nil {
+		return err
+	}
+	if failures > 0 {
+		return errorutils.CheckErrorf("%d of %d actions failed", failures, len(usc.results))
+	}
+	return nil
+}
+
+// Returns the details of the users in the file which exist in Artifactory.
+// If the missing users should be deactivated, the details of all the users are returned.
+func getCurrentUsers(servicesManager artifactory.ArtifactoryServicesManager, users []UserEntry, allUsers bool) (map[string]*services.User, error) {
+	existingUsers, err := servicesManager.GetAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	inFile := make(map[string]bool, len(users))
+	for _, user := range users {
+		inFile[user.Name] = true
+	}
+	currentUsers := make(map[string]*services.User)
+	for _, existingUser := range existingUsers {
+		if !allUsers && !inFile[existingUser.Name] {
+			continue
+		}
+		// The users list doesn't include the users' groups, so the details of each user are fetched.
+		user, err := servicesManager.GetUser(services.UserParams{UserDetails: services.User{Name: existingUser.Name}})
+		if err != nil {
+			return nil, err
+		}
+		if user != nil {
+			currentUsers[existingUser.Name] = user
+		}
+	}
+	return currentUsers, nil
+}
+
+func apply(servicesManager artifactory.ArtifactoryServicesManager, action Action) error {
+	switch action.Type {
+	case CreateGroup:
+		return servicesManager.CreateGroup(services.GroupParams{GroupDetails: services.Group{Name: action.Name}})
+	case Create:
+		return servicesManager.CreateUser(services.UserParams{UserDetails: *action.user})
+	default:
+		params := services.UserParams{UserDetails: *action.user}
+		params.ClearGroups = action.user.Groups != nil && len(*action.user.Groups) == 0
+		return servicesManager.UpdateUser(params)
+	}
+}
+
+func (usc *UsersSyncCommand) writeReport() error {
+	if usc.reportFilePath == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(usc.results, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(usc.reportFilePath, content, 0644))
+}
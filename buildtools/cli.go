@@ -23,8 +23,18 @@ import (
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
 	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/cargo"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/composer"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/conan"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/helm"
 	terraformdocs "github.com/jfrog/jfrog-cli/docs/artifactory/terraform"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/terraformconfig"
+	cargodocs "github.com/jfrog/jfrog-cli/docs/buildtools/cargo"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/cargoconfig"
+	composerdocs "github.com/jfrog/jfrog-cli/docs/buildtools/composer"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/composerconfig"
+	conandocs "github.com/jfrog/jfrog-cli/docs/buildtools/conan"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/conanconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/docker"
 	dotnetdocs "github.com/jfrog/jfrog-cli/docs/buildtools/dotnet"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/dotnetconfig"
@@ -33,6 +43,8 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/buildtools/gopublish"
 	gradledoc "github.com/jfrog/jfrog-cli/docs/buildtools/gradle"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/gradleconfig"
+	helmdocs "github.com/jfrog/jfrog-cli/docs/buildtools/helm"
+	"github.com/jfrog/jfrog-cli/docs/buildtools/helmconfig"
 	mvndoc "github.com/jfrog/jfrog-cli/docs/buildtools/mvn"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/mvnconfig"
 	"github.com/jfrog/jfrog-cli/docs/buildtools/npmcommand"
@@ -50,6 +62,9 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/common"
 	"github.com/jfrog/jfrog-cli/scan"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
+	"github.com/jfrog/jfrog-cli/utils/throttling"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
@@ -318,6 +333,114 @@ func GetCommands() []cli.Command {
 				return PoetryCmd(c)
 			},
 		},
+		{
+			Name:         "cargo-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.CargoConfig),
+			Aliases:      []string{"cac"},
+			Usage:        cargoconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("cargo-config", cargoconfig.GetDescription(), cargoconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Cargo)
+			},
+		},
+		{
+			Name:            "cargo",
+			Flags:           cliutils.GetCommandFlags(cliutils.Cargo),
+			Usage:           cargodocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("cargo", cargodocs.GetDescription(), cargodocs.Usage),
+			UsageText:       cargodocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Category:        buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return CargoCmd(c)
+			},
+		},
+		{
+			Name:         "composer-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.ComposerConfig),
+			Aliases:      []string{"coc"},
+			Usage:        composerconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("composer-config", composerconfig.GetDescription(), composerconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Composer)
+			},
+		},
+		{
+			Name:            "composer",
+			Flags:           cliutils.GetCommandFlags(cliutils.Composer),
+			Usage:           composerdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("composer", composerdocs.GetDescription(), composerdocs.Usage),
+			UsageText:       composerdocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Category:        buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return ComposerCmd(c)
+			},
+		},
+		{
+			Name:         "conan-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.ConanConfig),
+			Aliases:      []string{"cnc"},
+			Usage:        conanconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("conan-config", conanconfig.GetDescription(), conanconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Conan)
+			},
+		},
+		{
+			Name:            "conan",
+			Flags:           cliutils.GetCommandFlags(cliutils.Conan),
+			Usage:           conandocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("conan", conandocs.GetDescription(), conandocs.Usage),
+			UsageText:       conandocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Category:        buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return ConanCmd(c)
+			},
+		},
+		{
+			Name:         "helm-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.HelmConfig),
+			Aliases:      []string{"hc"},
+			Usage:        helmconfig.GetDescription(),
+			HelpName:     corecommon.CreateUsage("helm-config", helmconfig.GetDescription(), helmconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Category:     buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return cliutils.CreateProjectConfigCmd(c, projectconfig.Helm)
+			},
+		},
+		{
+			Name:            "helm",
+			Flags:           cliutils.GetCommandFlags(cliutils.Helm),
+			Usage:           helmdocs.GetDescription(),
+			HelpName:        corecommon.CreateUsage("helm", helmdocs.GetDescription(), helmdocs.Usage),
+			UsageText:       helmdocs.GetArguments(),
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Category:        buildToolsCategory,
+			Action: func(c *cli.Context) error {
+				return HelmCmd(c)
+			},
+		},
 		{
 			Name:         "npm-config",
 			Flags:        cliutils.GetCommandFlags(cliutils.NpmConfig),
@@ -407,6 +530,9 @@ func MvnCmd(c *cli.Context) (err error) {
 	if !exists {
 		return errors.New("no config file was found! Before running the mvn command on a project for the first time, the project should be configured with the mvn-config command")
 	}
+	if err = secretstore.CheckProjectConfigFile(configFilePath); err != nil {
+		return err
+	}
 	if c.NArg() < 1 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
@@ -463,6 +589,9 @@ func GradleCmd(c *cli.Context) (err error) {
 	if !exists {
 		return errors.New("no config file was found! Before running the gradle command on a project for the first time, the project should be configured with the gradle-config command")
 	}
+	if err = secretstore.CheckProjectConfigFile(configFilePath); err != nil {
+		return err
+	}
 	// Found a config file. Continue as native command.
 	if c.NArg() < 1 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
@@ -516,6 +645,9 @@ func YarnCmd(c *cli.Context) error {
 	if !exists {
 		return fmt.Errorf("no config file was found! Before running the yarn command on a project for the first time, the project should be configured using the yarn-config command")
 	}
+	if err = secretstore.CheckProjectConfigFile(configFilePath); err != nil {
+		return err
+	}
 
 	yarnCmd := yarn.NewYarnCommand().SetConfigFilePath(configFilePath).SetArgs(c.Args())
 	return commands.Exec(yarnCmd)
@@ -609,7 +741,7 @@ func getNugetAndDotnetConfigFields(configFilePath string) (rtDetails *coreConfig
 	if err != nil {
 		return nil, "", false, err
 	}
-	rtDetails, err = projectConfig.ServerDetails()
+	rtDetails, err = secretstore.GetRepositoryServerDetails(projectConfig)
 	if err != nil {
 		return nil, "", false, err
 	}
@@ -636,6 +768,22 @@ func extractThreadsFlag(args []string) (cleanArgs []string, threadsCount int, er
 	return
 }
 
+// Extracts the --max-bandwidth and --max-requests-per-second flags, which aren't passed to the docker client.
+func extractThrottlingFlags(args []string) (cleanArgs []string, limits *throttling.Limits, err error) {
+	cleanArgs = append([]string(nil), args...)
+	var values []string
+	for _, flag := range []string{"--max-bandwidth", "--max-requests-per-second"} {
+		flagIndex, valueIndex, value, e := coreutils.FindFlag(flag, cleanArgs)
+		if e != nil {
+			return nil, nil, e
+		}
+		coreutils.RemoveFlagFromCommand(&cleanArgs, flagIndex, valueIndex)
+		values = append(values, value)
+	}
+	limits, err = throttling.GetLimits(values[0], values[1])
+	return
+}
+
 func GoCmd(c *cli.Context) error {
 	configFilePath, err := goCmdVerification(c)
 	if err != nil {
@@ -682,6 +830,9 @@ func goCmdVerification(c *cli.Context) (string, error) {
 	if !exists {
 		return "", fmt.Errorf("no config file was found! Before running the go command on a project for the first time, the project should be configured using the go-config command")
 	}
+	if err = secretstore.CheckProjectConfigFile(configFilePath); err != nil {
+		return "", err
+	}
 	log.Debug("Go config file was found in:", configFilePath)
 	return configFilePath, nil
 }
@@ -750,7 +901,11 @@ func pushCmd(c *cli.Context, image string) (err error) {
 	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
 		return err
 	}
-	threads, rtDetails, detailedSummary, skipLogin, filteredDockerArgs, buildConfiguration, err := commandsUtils.ExtractDockerOptionsFromArgs(c.Args())
+	args, limits, err := extractThrottlingFlags(c.Args())
+	if err != nil {
+		return
+	}
+	threads, rtDetails, detailedSummary, skipLogin, filteredDockerArgs, buildConfiguration, err := commandsUtils.ExtractDockerOptionsFromArgs(args)
 	if err != nil {
 		return
 	}
@@ -764,6 +919,9 @@ func pushCmd(c *cli.Context, image string) (err error) {
 	if !supported {
 		return cliutils.NotSupportedNativeDockerCommand("docker-push")
 	}
+	if err = throttling.PushLayersThroughProxy(image, containerutils.DockerClient, rtDetails, limits); err != nil {
+		return
+	}
 	err = commands.Exec(PushCommand)
 	result := PushCommand.Result()
 	defer cliutils.CleanupResult(result, &err)
@@ -882,6 +1040,9 @@ func GetNpmConfigAndArgs(c *cli.Context) (configFilePath string, args []string,
 	if !exists {
 		return "", nil, errorutils.CheckError(errors.New("no config file was found! Before running the npm command on a project for the first time, the project should be configured using the npm-config command"))
 	}
+	if err = secretstore.CheckProjectConfigFile(configFilePath); err != nil {
+		return "", nil, err
+	}
 	_, args = getCommandName(c.Args())
 	return
 }
@@ -914,7 +1075,7 @@ func pythonCmd(c *cli.Context, projectType utils.ProjectType) error {
 	}
 
 	// Set arg values.
-	rtDetails, err := pythonConfig.ServerDetails()
+	rtDetails, err := secretstore.GetRepositoryServerDetails(pythonConfig)
 	if err != nil {
 		return err
 	}
@@ -938,6 +1099,98 @@ func pythonCmd(c *cli.Context, projectType utils.ProjectType) error {
 	return errorutils.CheckErrorf("%s is not supported", projectType)
 }
 
+func CargoCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	cargoConfig, err := projectconfig.GetResolverConfig(projectconfig.Cargo)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := secretstore.GetRepositoryServerDetails(cargoConfig)
+	if err != nil {
+		return err
+	}
+	cargoCmd := cargo.NewCargoCommand()
+	cargoCmd.SetServerDetails(rtDetails).SetRepo(cargoConfig.TargetRepo()).SetArgs(cliutils.ExtractCommand(c))
+	return commands.Exec(cargoCmd)
+}
+
+func ComposerCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	composerConfig, err := projectconfig.GetResolverConfig(projectconfig.Composer)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := secretstore.GetRepositoryServerDetails(composerConfig)
+	if err != nil {
+		return err
+	}
+	cmdName, filteredArgs := getCommandName(cliutils.ExtractCommand(c))
+	composerCmd := composer.NewComposerCommand()
+	composerCmd.SetServerDetails(rtDetails).SetRepo(composerConfig.TargetRepo()).SetCommandName(cmdName).SetArgs(filteredArgs)
+	return commands.Exec(composerCmd)
+}
+
+func ConanCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	conanConfig, err := projectconfig.GetResolverConfig(projectconfig.Conan)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := secretstore.GetRepositoryServerDetails(conanConfig)
+	if err != nil {
+		return err
+	}
+	cmdName, filteredArgs := getCommandName(cliutils.ExtractCommand(c))
+	conanCmd := conan.NewConanCommand()
+	conanCmd.SetServerDetails(rtDetails).SetRepo(conanConfig.TargetRepo()).SetCommandName(cmdName).SetArgs(filteredArgs)
+	return commands.Exec(conanCmd)
+}
+
+func HelmCmd(c *cli.Context) error {
+	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	resolverConfig, deployerConfig, err := projectconfig.GetRepositoriesConfig(projectconfig.Helm)
+	if err != nil {
+		return err
+	}
+	cmdName, filteredArgs := getCommandName(cliutils.ExtractCommand(c))
+	helmCmd := helm.NewHelmCommand().SetCommandName(cmdName).SetArgs(filteredArgs)
+	if resolverConfig != nil {
+		rtDetails, err := secretstore.GetRepositoryServerDetails(resolverConfig)
+		if err != nil {
+			return err
+		}
+		helmCmd.SetResolverDetails(rtDetails).SetResolverRepo(resolverConfig.TargetRepo())
+	}
+	if deployerConfig != nil {
+		rtDetails, err := secretstore.GetRepositoryServerDetails(deployerConfig)
+		if err != nil {
+			return err
+		}
+		helmCmd.SetDeployerDetails(rtDetails).SetDeployerRepo(deployerConfig.TargetRepo())
+	}
+	return commands.Exec(helmCmd)
+}
+
 func terraformCmd(c *cli.Context) error {
 	if show, err := cliutils.ShowCmdHelpIfNeeded(c, c.Args()); show || err != nil {
 		return err
@@ -965,6 +1218,9 @@ func getTerraformConfigAndArgs(c *cli.Context) (configFilePath string, args []st
 	if !exists {
 		return "", nil, errors.New("no config file was found! Before running the terraform command on a project for the first time, the project should be configured using the terraform-config command")
 	}
+	if err = secretstore.CheckProjectConfigFile(configFilePath); err != nil {
+		return "", nil, err
+	}
 	args = cliutils.ExtractCommand(c)
 	return
 }
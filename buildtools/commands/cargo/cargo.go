@@ -0,0 +1,185 @@
+package cargo
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The name of the registry which is configured for the Artifactory repository.
+const registryName = "artifactory"
+
+// Runs cargo with crates.io replaced by a Cargo repository in Artifactory, and collects the crates which are
+// listed in Cargo.lock as the dependencies of the build.
+type CargoCommand struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	args          []string
+}
+
+func NewCargoCommand() *CargoCommand {
+	return &CargoCommand{}
+}
+
+func (cc *CargoCommand) SetServerDetails(serverDetails *config.ServerDetails) *CargoCommand {
+	cc.serverDetails = serverDetails
+	return cc
+}
+
+func (cc *CargoCommand) SetRepo(repo string) *CargoCommand {
+	cc.repo = repo
+	return cc
+}
+
+func (cc *CargoCommand) SetArgs(args []string) *CargoCommand {
+	cc.args = args
+	return cc
+}
+
+func (cc *CargoCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+func (cc *CargoCommand) CommandName() string {
+	return "rt_cargo"
+}
+
+func (cc *CargoCommand) Run() (err error) {
+	var buildConfiguration *utils.BuildConfiguration
+	cc.args, buildConfiguration, err = utils.ExtractBuildDetailsFromArgs(cc.args)
+	if err != nil {
+		return
+	}
+	log.Info("Running cargo", strings.Join(cc.args, " "))
+	if err = errorutils.CheckError(gofrogcmd.RunCmd(cc)); err != nil {
+		return
+	}
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return
+	}
+	return cc.collectDependencies(buildConfiguration)
+}
+
+// Adds the crates of Cargo.lock to the build-info. Each member of the workspace is added as a separate module,
+// unless a module name was provided, in which case the crates of all the members are added to that module.
+func (cc *CargoCommand) collectDependencies(buildConfiguration *utils.BuildConfiguration) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	lockFile, err := ReadProjectLockFile(wd)
+	if err != nil {
+		return err
+	}
+	modules := map[string][]buildinfo.Dependency{}
+	var moduleIds []string
+	for _, pkg := range lockFile.LocalPackages() {
+		dependencies, err := lockFile.BuildInfoDependencies(pkg)
+		if err != nil {
+			return err
+		}
+		moduleId := pkg.Id()
+		if buildConfiguration.GetModule() != "" {
+			moduleId = buildConfiguration.GetModule()
+		}
+		if _, exists := modules[moduleId]; !exists {
+			moduleIds = append(moduleIds, moduleId)
+		}
+		modules[moduleId] = appendDependencies(modules[moduleId], dependencies)
+	}
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := buildConfiguration.GetProject()
+	if err = utils.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
+		return err
+	}
+	for _, moduleId := range moduleIds {
+		dependencies := modules[moduleId]
+		err = utils.SavePartialBuildInfo(buildName, buildNumber, project, func(partial *buildinfo.Partial) {
+			partial.ModuleType = buildinfo.ModuleType(Technology)
+			partial.ModuleId = moduleId
+			partial.Dependencies = dependencies
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Appends the dependencies which weren't already added.
+func appendDependencies(dependencies, newDependencies []buildinfo.Dependency) []buildinfo.Dependency {
+	for _, newDependency := range newDependencies {
+		exists := false
+		for _, dependency := range dependencies {
+			if dependency.Id == newDependency.Id {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			dependencies = append(dependencies, newDependency)
+		}
+	}
+	return dependencies
+}
+
+// Returns the URL of the sparse index of the Cargo repository.
+func (cc *CargoCommand) indexUrl() string {
+	return "sparse+" + clientutils.AddTrailingSlashIfNeeded(cc.serverDetails.ArtifactoryUrl) + "api/cargo/" + cc.repo + "/index/"
+}
+
+// The registry and the source replacement are configured by the --config option of cargo, which must precede the
+// cargo command. A toolchain override, such as +nightly, must precede all other arguments.
+func (cc *CargoCommand) GetCmd() *exec.Cmd {
+	var cmd []string
+	args := cc.args
+	if len(args) > 0 && strings.HasPrefix(args[0], "+") {
+		cmd = append(cmd, args[0])
+		args = args[1:]
+	}
+	cmd = append(cmd,
+		"--config", "registries."+registryName+".index=\""+cc.indexUrl()+"\"",
+		"--config", "source.crates-io.replace-with=\""+registryName+"\"")
+	cmd = append(cmd, args...)
+	return exec.Command("cargo", cmd...)
+}
+
+// The credentials of the registry are passed as an environment variable, so that they aren't logged or saved.
+func (cc *CargoCommand) GetEnv() map[string]string {
+	token := ""
+	if cc.serverDetails.AccessToken != "" {
+		token = "Bearer " + cc.serverDetails.AccessToken
+	} else if cc.serverDetails.User != "" {
+		token = "Basic " + base64.StdEncoding.EncodeToString([]byte(cc.serverDetails.User+":"+cc.serverDetails.Password))
+	}
+	if token == "" {
+		return map[string]string{}
+	}
+	return map[string]string{"CARGO_REGISTRIES_" + strings.ToUpper(registryName) + "_TOKEN": token}
+}
+
+func (cc *CargoCommand) GetStdWriter() io.WriteCloser {
+	return nil
+}
+
+func (cc *CargoCommand) GetErrWriter() io.WriteCloser {
+	return nil
+}
@@ -0,0 +1,35 @@
+package cargo
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCmd(t *testing.T) {
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory", AccessToken: "token"}
+	cargoCmd := NewCargoCommand().SetServerDetails(serverDetails).SetRepo("cargo-remote")
+
+	cargoCmd.SetArgs([]string{"build", "--release"})
+	assert.Equal(t, []string{"cargo",
+		"--config", `registries.artifactory.index="sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-remote/index/"`,
+		"--config", `source.crates-io.replace-with="artifactory"`,
+		"build", "--release"}, cargoCmd.GetCmd().Args)
+
+	// The toolchain override must be the first argument.
+	cargoCmd.SetArgs([]string{"+nightly", "build"})
+	assert.Equal(t, "+nightly", cargoCmd.GetCmd().Args[1])
+	assert.Equal(t, "build", cargoCmd.GetCmd().Args[6])
+}
+
+func TestGetEnv(t *testing.T) {
+	cargoCmd := NewCargoCommand().SetServerDetails(&config.ServerDetails{AccessToken: "token"})
+	assert.Equal(t, map[string]string{"CARGO_REGISTRIES_ARTIFACTORY_TOKEN": "Bearer token"}, cargoCmd.GetEnv())
+
+	cargoCmd.SetServerDetails(&config.ServerDetails{User: "user", Password: "password"})
+	assert.Equal(t, map[string]string{"CARGO_REGISTRIES_ARTIFACTORY_TOKEN": "Basic dXNlcjpwYXNzd29yZA=="}, cargoCmd.GetEnv())
+
+	cargoCmd.SetServerDetails(&config.ServerDetails{})
+	assert.Empty(t, cargoCmd.GetEnv())
+}
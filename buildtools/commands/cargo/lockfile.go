@@ -0,0 +1,173 @@
+package cargo
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/audit"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const (
+	Technology         coreutils.Technology = "cargo"
+	DescriptorFileName                      = "Cargo.toml"
+	LockFileName                            = "Cargo.lock"
+	// The prefix of the IDs of crates in Xray.
+	xrayPackagePrefix = "cargo://"
+)
+
+// A package of Cargo.lock. Packages which aren't downloaded from a registry or a git repository, such as the members
+// of the workspace, have no source.
+type Package struct {
+	Name     string `toml:"name"`
+	Version  string `toml:"version"`
+	Source   string `toml:"source"`
+	Checksum string `toml:"checksum"`
+	// The dependencies are referenced by their name, and also by their version and source when the name is ambiguous,
+	// for example "serde", "serde 1.0.160" or "serde 1.0.160 (registry+https://github.com/rust-lang/crates.io-index)".
+	Dependencies []string `toml:"dependencies"`
+}
+
+func (p Package) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+func (p Package) isLocal() bool {
+	return p.Source == ""
+}
+
+type LockFile struct {
+	Packages []Package `toml:"package"`
+}
+
+func ReadLockFile(path string) (*LockFile, error) {
+	lockFile := new(LockFile)
+	if _, err := toml.DecodeFile(path, lockFile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", path, err.Error())
+	}
+	return lockFile, nil
+}
+
+// Reads the Cargo.lock file of the project in the provided directory.
+func ReadProjectLockFile(projectDir string) (*LockFile, error) {
+	path := filepath.Join(projectDir, LockFileName)
+	exists, err := fileutils.IsFileExists(path, false)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errorutils.CheckErrorf("%s wasn't found in %s. Run 'cargo generate-lockfile' to create it", LockFileName, projectDir)
+	}
+	return ReadLockFile(path)
+}
+
+// Returns the local packages, which are the members of the workspace, sorted by their IDs.
+func (lockFile *LockFile) LocalPackages() (packages []Package) {
+	for _, pkg := range lockFile.Packages {
+		if pkg.isLocal() {
+			packages = append(packages, pkg)
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Id() < packages[j].Id() })
+	return
+}
+
+// Returns the direct dependencies of each package, mapped by the package ID.
+func (lockFile *LockFile) dependencyGraph() (map[string][]Package, error) {
+	graph := map[string][]Package{}
+	for _, pkg := range lockFile.Packages {
+		for _, dependency := range pkg.Dependencies {
+			dependencyPackage, err := lockFile.findPackage(dependency)
+			if err != nil {
+				return nil, err
+			}
+			graph[pkg.Id()] = append(graph[pkg.Id()], dependencyPackage)
+		}
+	}
+	return graph, nil
+}
+
+func (lockFile *LockFile) findPackage(dependency string) (Package, error) {
+	fields := strings.Fields(dependency)
+	if len(fields) == 0 {
+		return Package{}, errorutils.CheckErrorf("%s includes an empty dependency", LockFileName)
+	}
+	var matches []Package
+	for _, pkg := range lockFile.Packages {
+		if pkg.Name != fields[0] || len(fields) > 1 && pkg.Version != fields[1] {
+			continue
+		}
+		if len(fields) > 2 && "("+pkg.Source+")" != strings.Join(fields[2:], " ") {
+			continue
+		}
+		matches = append(matches, pkg)
+	}
+	if len(matches) != 1 {
+		return Package{}, errorutils.CheckErrorf("%s: the dependency '%s' matches %d packages", LockFileName, dependency, len(matches))
+	}
+	return matches[0], nil
+}
+
+// Builds the dependency trees of the local packages of the project, for scanning by Xray.
+func BuildDependencyTree(projectDir string) ([]*services.GraphNode, error) {
+	lockFile, err := ReadProjectLockFile(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := lockFile.dependencyGraph()
+	if err != nil {
+		return nil, err
+	}
+	treeHelper := map[string][]string{}
+	for id, dependencies := range graph {
+		for _, dependency := range dependencies {
+			treeHelper[xrayPackagePrefix+id] = append(treeHelper[xrayPackagePrefix+id], xrayPackagePrefix+dependency.Id())
+		}
+	}
+	var trees []*services.GraphNode
+	for _, pkg := range lockFile.LocalPackages() {
+		trees = append(trees, audit.BuildXrayDependencyTree(treeHelper, xrayPackagePrefix+pkg.Id()))
+	}
+	return trees, nil
+}
+
+// Returns the build-info dependencies of the local package, which are the packages it depends on directly or transitively.
+// Each dependency is requested by the shortest path from the local package to the dependency.
+func (lockFile *LockFile) BuildInfoDependencies(localPackage Package) ([]buildinfo.Dependency, error) {
+	graph, err := lockFile.dependencyGraph()
+	if err != nil {
+		return nil, err
+	}
+	requestedBy := map[string][]string{localPackage.Id(): {}}
+	dependencies := []buildinfo.Dependency{}
+	queue := []Package{localPackage}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, pkg := range graph[parent.Id()] {
+			if _, visited := requestedBy[pkg.Id()]; visited {
+				continue
+			}
+			requestedBy[pkg.Id()] = append([]string{parent.Id()}, requestedBy[parent.Id()]...)
+			queue = append(queue, pkg)
+			if pkg.isLocal() {
+				// Local packages are part of the project rather than dependencies.
+				continue
+			}
+			dependencies = append(dependencies, buildinfo.Dependency{
+				Id:          pkg.Id(),
+				Type:        "crate",
+				RequestedBy: [][]string{requestedBy[pkg.Id()]},
+				Checksum:    buildinfo.Checksum{Sha256: pkg.Checksum},
+			})
+		}
+	}
+	sort.Slice(dependencies, func(i, j int) bool { return dependencies[i].Id < dependencies[j].Id })
+	return dependencies, nil
+}
@@ -0,0 +1,77 @@
+package cargo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testdataDir = filepath.Join("..", "..", "..", "testdata", "cargo")
+
+func TestBuildDependencyTree(t *testing.T) {
+	trees, err := BuildDependencyTree(filepath.Join(testdataDir, "cargoproject"))
+	require.NoError(t, err)
+	require.Len(t, trees, 1)
+	assert.Equal(t, "cargo://cargoproject:0.1.0", trees[0].Id)
+	require.Len(t, trees[0].Nodes, 1)
+	regex := trees[0].Nodes[0]
+	assert.Equal(t, "cargo://regex:1.7.3", regex.Id)
+	assert.ElementsMatch(t, []string{"cargo://aho-corasick:0.7.20", "cargo://memchr:2.5.0", "cargo://regex-syntax:0.6.29"}, nodeIds(regex.Nodes))
+}
+
+func TestBuildDependencyTreeWorkspace(t *testing.T) {
+	trees, err := BuildDependencyTree(filepath.Join(testdataDir, "cargoworkspace"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cargo://app:0.2.0", "cargo://utils:0.1.0"}, nodeIds(trees))
+	// The dependencies with ambiguous names are referenced by their versions.
+	assert.Equal(t, []string{"cargo://rand_core:0.5.1"}, nodeIds(trees[1].Nodes))
+	rand := trees[0].Nodes[0]
+	assert.Equal(t, "cargo://rand:0.8.5", rand.Id)
+	assert.Contains(t, nodeIds(rand.Nodes), "cargo://rand_core:0.6.4")
+}
+
+func TestBuildDependencyTreeMissingLockFile(t *testing.T) {
+	_, err := BuildDependencyTree(t.TempDir())
+	assert.ErrorContains(t, err, "cargo generate-lockfile")
+}
+
+func TestBuildInfoDependencies(t *testing.T) {
+	lockFile, err := ReadProjectLockFile(filepath.Join(testdataDir, "cargoworkspace"))
+	require.NoError(t, err)
+	localPackages := lockFile.LocalPackages()
+	require.Len(t, localPackages, 2)
+
+	dependencies, err := lockFile.BuildInfoDependencies(localPackages[0])
+	require.NoError(t, err)
+	var ids []string
+	for _, dependency := range dependencies {
+		ids = append(ids, dependency.Id)
+		assert.Equal(t, "crate", dependency.Type)
+		assert.NotEmpty(t, dependency.Sha256)
+	}
+	// The local utils package isn't a dependency, but its dependencies are.
+	assert.Equal(t, []string{"cfg-if:1.0.0", "getrandom:0.2.8", "libc:0.2.140", "ppv-lite86:0.2.17", "rand:0.8.5", "rand_chacha:0.3.1",
+		"rand_core:0.5.1", "rand_core:0.6.4", "wasi:0.11.0+wasi-snapshot-preview1"}, ids)
+	assert.Equal(t, [][]string{{"rand:0.8.5", "app:0.2.0"}}, dependencies[5].RequestedBy)
+	assert.Equal(t, [][]string{{"utils:0.1.0", "app:0.2.0"}}, dependencies[6].RequestedBy)
+}
+
+func TestFindPackageAmbiguous(t *testing.T) {
+	lockFile, err := ReadProjectLockFile(filepath.Join(testdataDir, "cargoworkspace"))
+	require.NoError(t, err)
+	_, err = lockFile.findPackage("rand_core")
+	assert.ErrorContains(t, err, "matches 2 packages")
+	pkg, err := lockFile.findPackage("rand_core 0.6.4 (registry+https://github.com/rust-lang/crates.io-index)")
+	assert.NoError(t, err)
+	assert.Equal(t, "rand_core:0.6.4", pkg.Id())
+}
+
+func nodeIds(nodes []*services.GraphNode) (ids []string) {
+	for _, node := range nodes {
+		ids = append(ids, node.Id)
+	}
+	return
+}
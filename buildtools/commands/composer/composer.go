@@ -0,0 +1,219 @@
+package composer
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The Composer commands which resolve the dependencies of the project, and update composer.lock.
+var resolutionCommands = []string{"install", "update"}
+
+// Runs Composer with a Composer repository in Artifactory instead of packagist.org, and collects the packages which are
+// listed in composer.lock as the dependencies of the build.
+// The repository is configured in the global configuration of a temporary Composer home directory, so that the
+// project's composer.json and composer.lock aren't modified.
+type ComposerCommand struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	commandName   string
+	args          []string
+	composerHome  string
+	cacheDir      string
+	// The value of the COMPOSER_AUTH environment variable, which holds the credentials of the Artifactory repository.
+	auth string
+}
+
+func NewComposerCommand() *ComposerCommand {
+	return &ComposerCommand{}
+}
+
+func (cc *ComposerCommand) SetServerDetails(serverDetails *config.ServerDetails) *ComposerCommand {
+	cc.serverDetails = serverDetails
+	return cc
+}
+
+func (cc *ComposerCommand) SetRepo(repo string) *ComposerCommand {
+	cc.repo = repo
+	return cc
+}
+
+func (cc *ComposerCommand) SetCommandName(commandName string) *ComposerCommand {
+	cc.commandName = commandName
+	return cc
+}
+
+func (cc *ComposerCommand) SetArgs(args []string) *ComposerCommand {
+	cc.args = args
+	return cc
+}
+
+func (cc *ComposerCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+func (cc *ComposerCommand) CommandName() string {
+	return "rt_composer"
+}
+
+func (cc *ComposerCommand) Run() (err error) {
+	var buildConfiguration *utils.BuildConfiguration
+	cc.args, buildConfiguration, err = utils.ExtractBuildDetailsFromArgs(cc.args)
+	if err != nil {
+		return
+	}
+	// The cache directory is found before the Composer home directory is replaced, so that the cache is still used.
+	cc.cacheDir = getCacheDir()
+	if cc.composerHome, err = fileutils.CreateTempDir(); err != nil {
+		return
+	}
+	defer func() {
+		if e := fileutils.RemoveTempDir(cc.composerHome); err == nil {
+			err = e
+		}
+	}()
+	if err = cc.writeGlobalConfig(); err != nil {
+		return
+	}
+	if cc.auth, err = cc.createAuth(); err != nil {
+		return
+	}
+	log.Info("Running composer", cc.commandName)
+	if err = errorutils.CheckError(gofrogcmd.RunCmd(cc)); err != nil {
+		return
+	}
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo || !isResolutionCommand(cc.commandName) {
+		return
+	}
+	return collectDependencies(buildConfiguration)
+}
+
+func isResolutionCommand(commandName string) bool {
+	for _, resolutionCommand := range resolutionCommands {
+		if commandName == resolutionCommand {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the cache directory of Composer, or an empty string if it couldn't be found.
+func getCacheDir() string {
+	if cacheDir := os.Getenv("COMPOSER_CACHE_DIR"); cacheDir != "" {
+		return cacheDir
+	}
+	output, err := exec.Command("composer", "config", "--global", "cache-dir").Output()
+	if err != nil {
+		log.Debug("Couldn't find the cache directory of Composer:", err.Error())
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func (cc *ComposerCommand) repoUrl() string {
+	return clientutils.AddTrailingSlashIfNeeded(cc.serverDetails.ArtifactoryUrl) + "api/composer/" + cc.repo
+}
+
+// Writes the global configuration, which replaces packagist.org with the Artifactory repository.
+func (cc *ComposerCommand) writeGlobalConfig() error {
+	globalConfig := map[string]any{
+		"repositories": []any{
+			map[string]any{"type": "composer", "url": cc.repoUrl()},
+			map[string]any{"packagist.org": false},
+		},
+	}
+	content, err := json.MarshalIndent(globalConfig, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(cc.composerHome, "config.json"), content, 0600))
+}
+
+func (cc *ComposerCommand) createAuth() (string, error) {
+	repoUrl, err := url.Parse(cc.repoUrl())
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	var auth map[string]any
+	switch {
+	case cc.serverDetails.AccessToken != "":
+		auth = map[string]any{"bearer": map[string]string{repoUrl.Host: cc.serverDetails.AccessToken}}
+	case cc.serverDetails.User != "":
+		auth = map[string]any{"http-basic": map[string]any{repoUrl.Host: map[string]string{
+			"username": cc.serverDetails.User, "password": cc.serverDetails.Password}}}
+	default:
+		return "", nil
+	}
+	content, err := json.Marshal(auth)
+	return string(content), errorutils.CheckError(err)
+}
+
+// Adds the packages of composer.lock to the build-info, as a single module.
+func collectDependencies(buildConfiguration *utils.BuildConfiguration) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	project, err := ReadProject(wd)
+	if err != nil {
+		return err
+	}
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	moduleId := buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = project.Id()
+	}
+	if err = utils.SaveBuildGeneralDetails(buildName, buildNumber, buildConfiguration.GetProject()); err != nil {
+		return err
+	}
+	return utils.SavePartialBuildInfo(buildName, buildNumber, buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.ModuleType = buildinfo.ModuleType(Technology)
+		partial.ModuleId = moduleId
+		partial.Dependencies = project.BuildInfoDependencies()
+	})
+}
+
+func (cc *ComposerCommand) GetCmd() *exec.Cmd {
+	return exec.Command("composer", append([]string{cc.commandName}, cc.args...)...)
+}
+
+// The credentials are passed as an environment variable, so that they aren't saved.
+func (cc *ComposerCommand) GetEnv() map[string]string {
+	env := map[string]string{"COMPOSER_HOME": cc.composerHome}
+	if cc.cacheDir != "" {
+		env["COMPOSER_CACHE_DIR"] = cc.cacheDir
+	}
+	if cc.auth != "" {
+		env["COMPOSER_AUTH"] = cc.auth
+	}
+	return env
+}
+
+func (cc *ComposerCommand) GetStdWriter() io.WriteCloser {
+	return nil
+}
+
+func (cc *ComposerCommand) GetErrWriter() io.WriteCloser {
+	return nil
+}
@@ -0,0 +1,76 @@
+package composer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var projectDir = filepath.Join("..", "..", "..", "testdata", "composer", "composerproject")
+
+func TestBuildDependencyTree(t *testing.T) {
+	trees, err := BuildDependencyTree(projectDir)
+	require.NoError(t, err)
+	require.Len(t, trees, 1)
+	assert.Equal(t, "composer://jfrog/composerproject:0.0.0", trees[0].Id)
+	// The platform requirements, such as the PHP version, are ignored.
+	require.Len(t, trees[0].Nodes, 2)
+	assert.Equal(t, "composer://monolog/monolog:3.3.1", trees[0].Nodes[0].Id)
+	assert.Equal(t, "composer://symfony/polyfill-mbstring:v1.27.0", trees[0].Nodes[1].Id)
+	require.Len(t, trees[0].Nodes[0].Nodes, 1)
+	assert.Equal(t, "composer://psr/log:3.0.0", trees[0].Nodes[0].Nodes[0].Id)
+}
+
+func TestBuildDependencyTreeMissingLockFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DescriptorFileName), []byte(`{"require": {}}`), 0644))
+	_, err := BuildDependencyTree(dir)
+	assert.ErrorContains(t, err, "composer update")
+}
+
+func TestBuildInfoDependencies(t *testing.T) {
+	project, err := ReadProject(projectDir)
+	require.NoError(t, err)
+	dependencies := project.BuildInfoDependencies()
+	require.Len(t, dependencies, 3)
+
+	assert.Equal(t, "monolog/monolog:3.3.1", dependencies[0].Id)
+	assert.Equal(t, "zip", dependencies[0].Type)
+	assert.Equal(t, []string{"prod"}, dependencies[0].Scopes)
+	assert.Equal(t, "a3a7d1f0a4b0e6f8f5e1d2c3b4a5968778695a4b", dependencies[0].Sha1)
+	assert.Equal(t, [][]string{{"jfrog/composerproject:0.0.0"}}, dependencies[0].RequestedBy)
+
+	assert.Equal(t, "psr/log:3.0.0", dependencies[1].Id)
+	assert.Equal(t, [][]string{{"monolog/monolog:3.3.1", "jfrog/composerproject:0.0.0"}}, dependencies[1].RequestedBy)
+
+	assert.Equal(t, "symfony/polyfill-mbstring:v1.27.0", dependencies[2].Id)
+	assert.Equal(t, []string{"dev"}, dependencies[2].Scopes)
+}
+
+func TestWriteGlobalConfig(t *testing.T) {
+	composerCmd := NewComposerCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"}).SetRepo("php-remote")
+	composerCmd.composerHome = t.TempDir()
+	require.NoError(t, composerCmd.writeGlobalConfig())
+	content, err := os.ReadFile(filepath.Join(composerCmd.composerHome, "config.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"repositories": [{"type": "composer", "url": "https://acme.jfrog.io/artifactory/api/composer/php-remote"}, {"packagist.org": false}]}`, string(content))
+}
+
+func TestCreateAuth(t *testing.T) {
+	composerCmd := NewComposerCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token"})
+	auth, err := composerCmd.createAuth()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"bearer": {"acme.jfrog.io": "token"}}`, auth)
+
+	composerCmd.SetServerDetails(&config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io:8081/artifactory/", User: "user", Password: "password"})
+	auth, err = composerCmd.createAuth()
+	require.NoError(t, err)
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(auth), &parsed))
+	assert.Equal(t, map[string]any{"acme.jfrog.io:8081": map[string]any{"username": "user", "password": "password"}}, parsed["http-basic"])
+}
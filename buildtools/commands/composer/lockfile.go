@@ -0,0 +1,204 @@
+package composer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/audit"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const (
+	Technology         coreutils.Technology = "composer"
+	DescriptorFileName                      = "composer.json"
+	LockFileName                            = "composer.lock"
+	// The prefix of the IDs of Composer packages in Xray.
+	xrayPackagePrefix = "composer://"
+	// The version of the root package in Xray, if composer.json has no version.
+	defaultRootVersion = "0.0.0"
+)
+
+// The scopes of the dependencies in the build-info.
+const (
+	prodScope = "prod"
+	devScope  = "dev"
+)
+
+// The package of the project, as defined in composer.json.
+type RootPackage struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+type Dist struct {
+	Type   string `json:"type"`
+	Url    string `json:"url"`
+	Shasum string `json:"shasum"`
+}
+
+// A package which was resolved by Composer, as listed in composer.lock.
+type Package struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Dist    Dist              `json:"dist"`
+	Require map[string]string `json:"require"`
+}
+
+func (p Package) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+type LockFile struct {
+	Packages    []Package `json:"packages"`
+	PackagesDev []Package `json:"packages-dev"`
+}
+
+// A Composer project, which includes composer.json and composer.lock.
+type Project struct {
+	Root     RootPackage
+	LockFile LockFile
+	// The directory of the project.
+	Dir string
+}
+
+func ReadProject(projectDir string) (*Project, error) {
+	project := &Project{Dir: projectDir}
+	if err := readJsonFile(filepath.Join(projectDir, DescriptorFileName), &project.Root); err != nil {
+		return nil, err
+	}
+	lockFilePath := filepath.Join(projectDir, LockFileName)
+	exists, err := fileutils.IsFileExists(lockFilePath, false)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errorutils.CheckErrorf("%s wasn't found in %s. Run 'composer update' to create it", LockFileName, projectDir)
+	}
+	if err = readJsonFile(lockFilePath, &project.LockFile); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func readJsonFile(path string, v any) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, v); err != nil {
+		return errorutils.CheckErrorf("failed to parse %s: %s", path, err.Error())
+	}
+	return nil
+}
+
+// Returns the ID of the project's package. A project without a name is identified by its directory.
+func (project *Project) Id() string {
+	name := project.Root.Name
+	if name == "" {
+		name = filepath.Base(project.Dir)
+	}
+	version := project.Root.Version
+	if version == "" {
+		version = defaultRootVersion
+	}
+	return name + ":" + version
+}
+
+// Returns the locked packages mapped by their lowercase names, since the package names of Composer are case-insensitive.
+// Requirements which aren't locked packages, such as the PHP version and the PHP extensions, are ignored.
+func (project *Project) packages() map[string]Package {
+	packages := map[string]Package{}
+	for _, pkg := range append(append([]Package{}, project.LockFile.Packages...), project.LockFile.PackagesDev...) {
+		packages[strings.ToLower(pkg.Name)] = pkg
+	}
+	return packages
+}
+
+// Returns the locked packages which are required by the provided requirements, sorted by their names.
+func requiredPackages(require map[string]string, packages map[string]Package) (required []Package) {
+	for name := range require {
+		if pkg, exists := packages[strings.ToLower(name)]; exists {
+			required = append(required, pkg)
+		}
+	}
+	sort.Slice(required, func(i, j int) bool { return required[i].Name < required[j].Name })
+	return
+}
+
+// Builds the dependency tree of the project, for scanning by Xray.
+func BuildDependencyTree(projectDir string) ([]*services.GraphNode, error) {
+	project, err := ReadProject(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	packages := project.packages()
+	rootId := xrayPackagePrefix + project.Id()
+	treeHelper := map[string][]string{}
+	for _, requirements := range []map[string]string{project.Root.Require, project.Root.RequireDev} {
+		for _, pkg := range requiredPackages(requirements, packages) {
+			treeHelper[rootId] = append(treeHelper[rootId], xrayPackagePrefix+pkg.Id())
+		}
+	}
+	for _, pkg := range packages {
+		for _, dependency := range requiredPackages(pkg.Require, packages) {
+			treeHelper[xrayPackagePrefix+pkg.Id()] = append(treeHelper[xrayPackagePrefix+pkg.Id()], xrayPackagePrefix+dependency.Id())
+		}
+	}
+	return []*services.GraphNode{audit.BuildXrayDependencyTree(treeHelper, rootId)}, nil
+}
+
+// Returns the build-info dependencies of the project, which are the locked packages. The packages required by
+// require-dev are in the dev scope. Each dependency is requested by the shortest path from the project to the dependency.
+func (project *Project) BuildInfoDependencies() []buildinfo.Dependency {
+	packages := project.packages()
+	devPackages := map[string]bool{}
+	for _, pkg := range project.LockFile.PackagesDev {
+		devPackages[pkg.Id()] = true
+	}
+	requestedBy := map[string][]string{}
+	var queue []Package
+	for _, pkg := range append(requiredPackages(project.Root.Require, packages), requiredPackages(project.Root.RequireDev, packages)...) {
+		if _, visited := requestedBy[pkg.Id()]; !visited {
+			requestedBy[pkg.Id()] = []string{project.Id()}
+			queue = append(queue, pkg)
+		}
+	}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, pkg := range requiredPackages(parent.Require, packages) {
+			if _, visited := requestedBy[pkg.Id()]; !visited {
+				requestedBy[pkg.Id()] = append([]string{parent.Id()}, requestedBy[parent.Id()]...)
+				queue = append(queue, pkg)
+			}
+		}
+	}
+	dependencies := []buildinfo.Dependency{}
+	for _, pkg := range packages {
+		scope := prodScope
+		if devPackages[pkg.Id()] {
+			scope = devScope
+		}
+		dependency := buildinfo.Dependency{
+			Id:       pkg.Id(),
+			Type:     pkg.Dist.Type,
+			Scopes:   []string{scope},
+			Checksum: buildinfo.Checksum{Sha1: pkg.Dist.Shasum},
+		}
+		if path, exists := requestedBy[pkg.Id()]; exists {
+			dependency.RequestedBy = [][]string{path}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	sort.Slice(dependencies, func(i, j int) bool { return dependencies[i].Id < dependencies[j].Id })
+	return dependencies
+}
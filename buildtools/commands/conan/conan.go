@@ -0,0 +1,198 @@
+package conan
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The Conan commands which resolve the dependency graph of the project, and may create a lockfile.
+var resolutionCommands = []string{"install", "create", "build", "lock"}
+
+// Runs Conan with a Conan repository in Artifactory as the first remote, and collects the recipes which are listed
+// in the lockfile as the dependencies of the build.
+// The remote is named after the repository. The credentials aren't saved in the Conan home directory, and are passed to
+// Conan as environment variables instead.
+type ConanCommand struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	commandName   string
+	args          []string
+}
+
+func NewConanCommand() *ConanCommand {
+	return &ConanCommand{}
+}
+
+func (cc *ConanCommand) SetServerDetails(serverDetails *config.ServerDetails) *ConanCommand {
+	cc.serverDetails = serverDetails
+	return cc
+}
+
+func (cc *ConanCommand) SetRepo(repo string) *ConanCommand {
+	cc.repo = repo
+	return cc
+}
+
+func (cc *ConanCommand) SetCommandName(commandName string) *ConanCommand {
+	cc.commandName = commandName
+	return cc
+}
+
+func (cc *ConanCommand) SetArgs(args []string) *ConanCommand {
+	cc.args = args
+	return cc
+}
+
+func (cc *ConanCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+func (cc *ConanCommand) CommandName() string {
+	return "rt_conan"
+}
+
+func (cc *ConanCommand) Run() (err error) {
+	var buildConfiguration *utils.BuildConfiguration
+	cc.args, buildConfiguration, err = utils.ExtractBuildDetailsFromArgs(cc.args)
+	if err != nil {
+		return
+	}
+	if err = cc.addRemote(); err != nil {
+		return
+	}
+	log.Info("Running conan", cc.commandName)
+	if err = errorutils.CheckError(gofrogcmd.RunCmd(cc)); err != nil {
+		return
+	}
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo || !isResolutionCommand(cc.commandName) {
+		return
+	}
+	return cc.collectDependencies(buildConfiguration)
+}
+
+func isResolutionCommand(commandName string) bool {
+	for _, resolutionCommand := range resolutionCommands {
+		if commandName == resolutionCommand {
+			return true
+		}
+	}
+	return false
+}
+
+func (cc *ConanCommand) remoteUrl() string {
+	return clientutils.AddTrailingSlashIfNeeded(cc.serverDetails.ArtifactoryUrl) + "api/conan/" + cc.repo
+}
+
+// Adds the Artifactory repository as the first remote of Conan, or updates the remote if it already exists.
+func (cc *ConanCommand) addRemote() error {
+	log.Debug("Adding the Conan remote", cc.repo, "with URL", cc.remoteUrl())
+	output, err := exec.Command("conan", "remote", "add", "--force", "--index", "0", cc.repo, cc.remoteUrl()).CombinedOutput()
+	if err != nil {
+		return errorutils.CheckErrorf("failed to add the Conan remote '%s': %s\n%s", cc.repo, err.Error(), strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Returns the path of the lockfile which was used or created by the command. A lockfile which is created by the
+// command takes precedence over a lockfile which is used by it.
+func (cc *ConanCommand) lockFilePath() string {
+	for _, flag := range []string{"--lockfile-out", "--lockfile"} {
+		if value := getFlagValue(cc.args, flag); value != "" {
+			return value
+		}
+	}
+	return LockFileName
+}
+
+// Returns the value of the flag in the arguments, which is provided either as --flag=value or as --flag value.
+func getFlagValue(args []string, flag string) string {
+	for i, arg := range args {
+		if value, found := strings.CutPrefix(arg, flag+"="); found {
+			return value
+		}
+		if arg == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// Adds the recipes of the lockfile to the build-info, as a single module.
+func (cc *ConanCommand) collectDependencies(buildConfiguration *utils.BuildConfiguration) error {
+	lockFile, err := ReadLockFile(cc.lockFilePath())
+	if err != nil {
+		return err
+	}
+	dependencies, err := lockFile.BuildInfoDependencies()
+	if err != nil {
+		return err
+	}
+	moduleId := buildConfiguration.GetModule()
+	if moduleId == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		moduleId = filepath.Base(wd)
+	}
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	if err = utils.SaveBuildGeneralDetails(buildName, buildNumber, buildConfiguration.GetProject()); err != nil {
+		return err
+	}
+	return utils.SavePartialBuildInfo(buildName, buildNumber, buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.ModuleType = buildinfo.ModuleType(Technology)
+		partial.ModuleId = moduleId
+		partial.Dependencies = dependencies
+	})
+}
+
+func (cc *ConanCommand) GetCmd() *exec.Cmd {
+	return exec.Command("conan", append([]string{cc.commandName}, cc.args...)...)
+}
+
+// Conan reads the credentials of a remote from environment variables, which are named after the remote.
+func (cc *ConanCommand) GetEnv() map[string]string {
+	user, password := cc.serverDetails.User, cc.serverDetails.Password
+	if cc.serverDetails.AccessToken != "" {
+		password = cc.serverDetails.AccessToken
+		if user == "" {
+			user = auth.ExtractUsernameFromAccessToken(cc.serverDetails.AccessToken)
+		}
+	}
+	if user == "" {
+		return map[string]string{}
+	}
+	remoteName := strings.ToUpper(strings.ReplaceAll(cc.repo, "-", "_"))
+	return map[string]string{
+		"CONAN_LOGIN_USERNAME_" + remoteName: user,
+		"CONAN_PASSWORD_" + remoteName:       password,
+	}
+}
+
+func (cc *ConanCommand) GetStdWriter() io.WriteCloser {
+	return nil
+}
+
+func (cc *ConanCommand) GetErrWriter() io.WriteCloser {
+	return nil
+}
@@ -0,0 +1,90 @@
+package conan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var lockFilePath = filepath.Join("..", "..", "..", "testdata", "conan", "conanproject", LockFileName)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		reference  string
+		expectedId string
+		expected   Reference
+	}{
+		{"zlib/1.2.13", "zlib/1.2.13", Reference{Name: "zlib", Version: "1.2.13"}},
+		{"zlib/1.2.13#97d5730b529b4224045fe7090592d4c1%1692672717.68", "zlib/1.2.13#97d5730b529b4224045fe7090592d4c1",
+			Reference{Name: "zlib", Version: "1.2.13", Revision: "97d5730b529b4224045fe7090592d4c1"}},
+		{"poco/1.12.4@acme/stable#4c9b1e2f", "poco/1.12.4@acme/stable#4c9b1e2f",
+			Reference{Name: "poco", Version: "1.12.4", User: "acme", Channel: "stable", Revision: "4c9b1e2f"}},
+	}
+	for _, test := range tests {
+		t.Run(test.reference, func(t *testing.T) {
+			ref, err := ParseReference(test.reference)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ref)
+			assert.Equal(t, test.expectedId, ref.Id())
+		})
+	}
+	for _, reference := range []string{"zlib", "zlib/", "/1.2.13", "poco/1.12.4@acme"} {
+		_, err := ParseReference(reference)
+		assert.Error(t, err, reference)
+	}
+}
+
+func TestBuildInfoDependencies(t *testing.T) {
+	lockFile, err := ReadLockFile(lockFilePath)
+	require.NoError(t, err)
+	dependencies, err := lockFile.BuildInfoDependencies()
+	require.NoError(t, err)
+	require.Len(t, dependencies, 3)
+	assert.Equal(t, "zlib/1.2.13#97d5730b529b4224045fe7090592d4c1", dependencies[0].Id)
+	assert.Equal(t, "conan", dependencies[0].Type)
+	assert.Equal(t, []string{"host"}, dependencies[0].Scopes)
+	assert.Equal(t, "fmt/10.0.0#dd5e3eb81b512a1bb34a5aab88a07e82", dependencies[1].Id)
+	assert.Equal(t, "cmake/3.27.1#2fd2c1e3bd0c5ab2e4ac4c4cb5ec7b80", dependencies[2].Id)
+	assert.Equal(t, []string{"build"}, dependencies[2].Scopes)
+}
+
+func TestReadLockFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := ReadLockFile(filepath.Join(dir, LockFileName))
+	assert.ErrorContains(t, err, "--lockfile-out")
+
+	conan1LockFile := filepath.Join(dir, "conan1.lock")
+	require.NoError(t, os.WriteFile(conan1LockFile, []byte(`{"version": "0.4", "graph_lock": {"nodes": {}}}`), 0644))
+	_, err = ReadLockFile(conan1LockFile)
+	assert.ErrorContains(t, err, "Conan 1")
+}
+
+func TestLockFilePath(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"."}, LockFileName},
+		{[]string{".", "--lockfile", "base.lock"}, "base.lock"},
+		{[]string{".", "--lockfile=base.lock", "--lockfile-partial"}, "base.lock"},
+		{[]string{".", "--lockfile=base.lock", "--lockfile-out", "out.lock"}, "out.lock"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, NewConanCommand().SetArgs(test.args).lockFilePath(), test.args)
+	}
+}
+
+func TestGetEnv(t *testing.T) {
+	conanCmd := NewConanCommand().SetRepo("conan-remote").SetServerDetails(&config.ServerDetails{User: "user", Password: "password"})
+	assert.Equal(t, map[string]string{"CONAN_LOGIN_USERNAME_CONAN_REMOTE": "user", "CONAN_PASSWORD_CONAN_REMOTE": "password"}, conanCmd.GetEnv())
+
+	conanCmd.SetServerDetails(&config.ServerDetails{User: "user", AccessToken: "token"})
+	assert.Equal(t, map[string]string{"CONAN_LOGIN_USERNAME_CONAN_REMOTE": "user", "CONAN_PASSWORD_CONAN_REMOTE": "token"}, conanCmd.GetEnv())
+
+	conanCmd.SetServerDetails(&config.ServerDetails{})
+	assert.Empty(t, conanCmd.GetEnv())
+}
@@ -0,0 +1,131 @@
+package conan
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	Technology   coreutils.Technology = "conan"
+	LockFileName                      = "conan.lock"
+)
+
+// The scopes of the dependencies in the build-info, by the section of the lockfile which lists them.
+const (
+	hostScope   = "host"
+	buildScope  = "build"
+	pythonScope = "python"
+)
+
+// A reference of a Conan recipe, in the format name/version[@user/channel][#revision[%timestamp]].
+type Reference struct {
+	Name     string
+	Version  string
+	User     string
+	Channel  string
+	Revision string
+}
+
+func ParseReference(reference string) (Reference, error) {
+	ref := Reference{}
+	// The timestamp of the revision isn't recorded.
+	nameVersion, _, _ := strings.Cut(reference, "%")
+	nameVersion, ref.Revision, _ = strings.Cut(nameVersion, "#")
+	nameVersion, userChannel, hasUserChannel := strings.Cut(nameVersion, "@")
+	var found bool
+	ref.Name, ref.Version, found = strings.Cut(nameVersion, "/")
+	valid := found && ref.Name != "" && ref.Version != ""
+	if valid && hasUserChannel {
+		ref.User, ref.Channel, found = strings.Cut(userChannel, "/")
+		valid = found && ref.User != "" && ref.Channel != ""
+	}
+	if !valid {
+		return Reference{}, errorutils.CheckErrorf("invalid Conan reference '%s'. The expected format is name/version[@user/channel][#revision]", reference)
+	}
+	return ref, nil
+}
+
+// Returns the reference without the revision.
+func (ref Reference) String() string {
+	str := ref.Name + "/" + ref.Version
+	if ref.User != "" {
+		str += "@" + ref.User + "/" + ref.Channel
+	}
+	return str
+}
+
+// Returns the ID of the recipe in the build-info, which includes the recipe revision if it's known.
+func (ref Reference) Id() string {
+	if ref.Revision == "" {
+		return ref.String()
+	}
+	return ref.String() + "#" + ref.Revision
+}
+
+// The lockfile of Conan 2, which lists the references of the recipes in the dependency graph of the project.
+type LockFile struct {
+	Version        string   `json:"version"`
+	Requires       []string `json:"requires"`
+	BuildRequires  []string `json:"build_requires"`
+	PythonRequires []string `json:"python_requires"`
+	// Lockfiles of Conan 1 include the full graph, and have a different format.
+	GraphLock json.RawMessage `json:"graph_lock"`
+}
+
+func ReadLockFile(path string) (*LockFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("%s wasn't found. Use the --lockfile-out option of the conan command, or run 'conan lock create', to create it", path)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	lockFile := &LockFile{}
+	if err = json.Unmarshal(content, lockFile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", path, err.Error())
+	}
+	if lockFile.GraphLock != nil {
+		return nil, errorutils.CheckErrorf("%s was created by Conan 1. Only lockfiles of Conan 2 are supported", path)
+	}
+	return lockFile, nil
+}
+
+// Returns the build-info dependencies of the lockfile, which are the recipes locked in it, identified by their references
+// and recipe revisions. The scope of each dependency is determined by the context in which it's required.
+func (lockFile *LockFile) BuildInfoDependencies() ([]buildinfo.Dependency, error) {
+	dependencies := []buildinfo.Dependency{}
+	for _, section := range []struct {
+		references []string
+		scope      string
+	}{
+		{lockFile.Requires, hostScope},
+		{lockFile.BuildRequires, buildScope},
+		{lockFile.PythonRequires, pythonScope},
+	} {
+		for _, reference := range section.references {
+			ref, err := ParseReference(reference)
+			if err != nil {
+				return nil, err
+			}
+			dependencies = appendDependency(dependencies, buildinfo.Dependency{Id: ref.Id(), Type: string(Technology), Scopes: []string{section.scope}})
+		}
+	}
+	return dependencies, nil
+}
+
+// Appends the dependency, or adds its scope if a dependency with the same ID was already added, since a recipe may be
+// required in both the host and the build contexts.
+func appendDependency(dependencies []buildinfo.Dependency, newDependency buildinfo.Dependency) []buildinfo.Dependency {
+	for i := range dependencies {
+		if dependencies[i].Id == newDependency.Id {
+			dependencies[i].Scopes = append(dependencies[i].Scopes, newDependency.Scopes...)
+			return dependencies
+		}
+	}
+	return append(dependencies, newDependency)
+}
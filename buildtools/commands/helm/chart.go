@@ -0,0 +1,339 @@
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/audit"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	Technology         coreutils.Technology = "helm"
+	DescriptorFileName                      = "Chart.yaml"
+	LockFileName                            = "Chart.lock"
+	valuesFileName                          = "values.yaml"
+	// The directory of the chart which includes its subcharts.
+	subchartsDir = "charts"
+	// The prefixes of the IDs of Helm charts and Docker images in Xray.
+	xrayChartPrefix = "helm://"
+	xrayImagePrefix = "docker://"
+	// The tag of an image which isn't tagged.
+	defaultImageTag = "latest"
+)
+
+// A dependency of a chart, as listed in Chart.yaml or in Chart.lock.
+type ChartDependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+}
+
+func (dependency ChartDependency) Id() string {
+	return dependency.Name + ":" + dependency.Version
+}
+
+// The metadata of a chart, as defined in Chart.yaml.
+type Metadata struct {
+	Name         string            `yaml:"name"`
+	Version      string            `yaml:"version"`
+	AppVersion   string            `yaml:"appVersion"`
+	Dependencies []ChartDependency `yaml:"dependencies"`
+}
+
+type Lock struct {
+	Dependencies []ChartDependency `yaml:"dependencies"`
+}
+
+// A Helm chart, which is loaded from a directory or from a chart archive, with its subcharts.
+type Chart struct {
+	Metadata Metadata
+	// Nil if the chart has no Chart.lock.
+	Lock      *Lock
+	Values    map[interface{}]interface{}
+	Subcharts []*Chart
+}
+
+// The files of a chart which are needed by JFrog CLI, mapped by their slash separated paths relative to the chart.
+type chartFiles map[string][]byte
+
+// Returns true if the file is needed for loading the chart or its subcharts.
+func isChartFile(relativePath string) bool {
+	switch path.Base(relativePath) {
+	case DescriptorFileName, LockFileName, valuesFileName:
+		return true
+	}
+	return strings.HasPrefix(relativePath, subchartsDir+"/") && strings.HasSuffix(relativePath, ".tgz")
+}
+
+// Loads the chart in the provided path, which is either a chart directory or a chart archive.
+func LoadChart(chartPath string) (*Chart, error) {
+	isDir, err := fileutils.IsDirExists(chartPath, false)
+	if err != nil {
+		return nil, err
+	}
+	if !isDir {
+		content, err := os.ReadFile(chartPath)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		return loadChartArchive(content)
+	}
+	files := chartFiles{}
+	err = filepath.WalkDir(chartPath, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(chartPath, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if !isChartFile(relativePath) {
+			return nil
+		}
+		files[relativePath], err = os.ReadFile(filePath)
+		return err
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return newChart(files, chartPath)
+}
+
+// Loads a chart archive, in which the files of the chart are in a directory named after the chart.
+func loadChartArchive(content []byte) (*Chart, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the chart archive: %s", err.Error())
+	}
+	files := chartFiles{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed to read the chart archive: %s", err.Error())
+		}
+		_, relativePath, found := strings.Cut(path.Clean(header.Name), "/")
+		if header.Typeflag != tar.TypeReg || !found || !isChartFile(relativePath) {
+			continue
+		}
+		if files[relativePath], err = io.ReadAll(tarReader); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	return newChart(files, "chart archive")
+}
+
+// Creates the chart from its files. The location of the chart is used for error messages.
+func newChart(files chartFiles, location string) (*Chart, error) {
+	chart := &Chart{}
+	descriptor, exists := files[DescriptorFileName]
+	if !exists {
+		return nil, errorutils.CheckErrorf("%s wasn't found in %s", DescriptorFileName, location)
+	}
+	if err := yaml.Unmarshal(descriptor, &chart.Metadata); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s in %s: %s", DescriptorFileName, location, err.Error())
+	}
+	if lock, exists := files[LockFileName]; exists {
+		chart.Lock = &Lock{}
+		if err := yaml.Unmarshal(lock, chart.Lock); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse %s in %s: %s", LockFileName, location, err.Error())
+		}
+	}
+	if values, exists := files[valuesFileName]; exists {
+		if err := yaml.Unmarshal(values, &chart.Values); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse %s in %s: %s", valuesFileName, location, err.Error())
+		}
+	}
+	// The subcharts are either chart archives or chart directories in the charts directory. The content of
+	// a subchart archive is mapped to an empty path.
+	subchartsFiles := map[string]chartFiles{}
+	for relativePath, content := range files {
+		subchartPath, found := strings.CutPrefix(relativePath, subchartsDir+"/")
+		if !found {
+			continue
+		}
+		name, subchartFilePath, _ := strings.Cut(subchartPath, "/")
+		if subchartsFiles[name] == nil {
+			subchartsFiles[name] = chartFiles{}
+		}
+		subchartsFiles[name][subchartFilePath] = content
+	}
+	var subchartNames []string
+	for name := range subchartsFiles {
+		subchartNames = append(subchartNames, name)
+	}
+	sort.Strings(subchartNames)
+	for _, name := range subchartNames {
+		var subchart *Chart
+		var err error
+		if archive, isArchive := subchartsFiles[name][""]; isArchive {
+			subchart, err = loadChartArchive(archive)
+		} else {
+			subchart, err = newChart(subchartsFiles[name], path.Join(location, subchartsDir, name))
+		}
+		if err != nil {
+			return nil, err
+		}
+		chart.Subcharts = append(chart.Subcharts, subchart)
+	}
+	return chart, nil
+}
+
+func (chart *Chart) Id() string {
+	return chart.Metadata.Name + ":" + chart.Metadata.Version
+}
+
+// Returns the dependencies of the chart. The versions which were resolved by 'helm dependency update' are taken from
+// Chart.lock, and the versions in Chart.yaml, which may be ranges, are used if the chart has no Chart.lock.
+func (chart *Chart) Dependencies() []ChartDependency {
+	if chart.Lock != nil {
+		return chart.Lock.Dependencies
+	}
+	return chart.Metadata.Dependencies
+}
+
+// Returns the Docker images which are referenced by the values of the chart, sorted and without duplicates.
+// An image is referenced by a value named 'image', which is either the image reference, or a map with the
+// 'registry', 'repository' and 'tag' of the image. Images without a tag are tagged by the app version of the chart,
+// which is the common default in the templates of charts.
+func (chart *Chart) Images() []string {
+	images := map[string]bool{}
+	collectImages(chart.Values, chart.Metadata.AppVersion, images)
+	var sortedImages []string
+	for image := range images {
+		sortedImages = append(sortedImages, image)
+	}
+	sort.Strings(sortedImages)
+	return sortedImages
+}
+
+func collectImages(value interface{}, appVersion string, images map[string]bool) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		for key, child := range value {
+			if key == "image" {
+				if image := parseImage(child, appVersion); image != "" {
+					images[image] = true
+					continue
+				}
+			}
+			collectImages(child, appVersion, images)
+		}
+	case []interface{}:
+		for _, child := range value {
+			collectImages(child, appVersion, images)
+		}
+	}
+}
+
+// Returns the reference of the image which is defined by the value, or an empty string if the value doesn't define
+// an image. Values which are templates can't be resolved, and are ignored.
+func parseImage(value interface{}, appVersion string) string {
+	toString := func(value interface{}) string {
+		if value == nil {
+			return ""
+		}
+		return strings.TrimSpace(fmt.Sprint(value))
+	}
+	var image, tag string
+	switch value := value.(type) {
+	case string:
+		image = strings.TrimSpace(value)
+	case map[interface{}]interface{}:
+		image = toString(value["repository"])
+		if registry := toString(value["registry"]); image != "" && registry != "" {
+			image = registry + "/" + image
+		}
+		tag = toString(value["tag"])
+	default:
+		return ""
+	}
+	if image == "" || strings.Contains(image+tag, "{{") {
+		return ""
+	}
+	if tag != "" {
+		return image + ":" + tag
+	}
+	// The tag or digest of an image follows its last path element.
+	if strings.ContainsAny(path.Base(image), ":@") {
+		return image
+	}
+	if appVersion == "" {
+		appVersion = defaultImageTag
+	}
+	return image + ":" + appVersion
+}
+
+// Builds the dependency tree of the chart in the provided directory, for scanning by Xray. The tree includes the images
+// referenced by the chart and by its subcharts. The dependencies which weren't downloaded to the charts directory
+// are included without their own dependencies.
+func BuildDependencyTree(chartDir string) ([]*services.GraphNode, error) {
+	chart, err := LoadChart(chartDir)
+	if err != nil {
+		return nil, err
+	}
+	treeHelper := map[string][]string{}
+	chart.addToTree(treeHelper)
+	return []*services.GraphNode{audit.BuildXrayDependencyTree(treeHelper, xrayChartPrefix+chart.Id())}, nil
+}
+
+func (chart *Chart) addToTree(treeHelper map[string][]string) {
+	chartId := xrayChartPrefix + chart.Id()
+	var children []string
+	for _, image := range chart.Images() {
+		children = append(children, xrayImagePrefix+image)
+	}
+	subcharts := map[string]bool{}
+	for _, subchart := range chart.Subcharts {
+		subcharts[subchart.Metadata.Name] = true
+		children = append(children, xrayChartPrefix+subchart.Id())
+		subchart.addToTree(treeHelper)
+	}
+	for _, dependency := range chart.Dependencies() {
+		if !subcharts[dependency.Name] {
+			children = append(children, xrayChartPrefix+dependency.Id())
+		}
+	}
+	treeHelper[chartId] = children
+}
+
+// Returns the build-info dependencies of the chart in the provided directory, which are the dependencies of the chart.
+// The checksums of the dependencies are calculated if their archives were downloaded to the charts directory.
+func (chart *Chart) BuildInfoDependencies(chartDir string) ([]buildinfo.Dependency, error) {
+	dependencies := []buildinfo.Dependency{}
+	for _, chartDependency := range chart.Dependencies() {
+		dependency := buildinfo.Dependency{Id: chartDependency.Id(), Type: string(Technology)}
+		archivePath := filepath.Join(chartDir, subchartsDir, chartDependency.Name+"-"+chartDependency.Version+".tgz")
+		exists, err := fileutils.IsFileExists(archivePath, false)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			fileDetails, err := fileutils.GetFileDetails(archivePath, true)
+			if err != nil {
+				return nil, err
+			}
+			dependency.Checksum = buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
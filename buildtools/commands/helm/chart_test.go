@@ -0,0 +1,103 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var chartDir = filepath.Join("..", "..", "..", "testdata", "helm", "helmchart")
+
+func TestLoadChart(t *testing.T) {
+	chart, err := LoadChart(chartDir)
+	require.NoError(t, err)
+	assert.Equal(t, "webapp:1.2.0", chart.Id())
+	assert.Equal(t, "2.4.1", chart.Metadata.AppVersion)
+	// The resolved versions are taken from Chart.lock.
+	assert.Equal(t, []ChartDependency{
+		{Name: "redis", Version: "17.11.3", Repository: "https://acme.jfrog.io/artifactory/api/helm/helm-virtual"},
+		{Name: "common", Version: "2.4.0", Repository: "oci://acme.jfrog.io/helm-oci"},
+	}, chart.Dependencies())
+
+	// The subcharts are loaded from both the chart archive and the chart directory in the charts directory.
+	require.Len(t, chart.Subcharts, 2)
+	assert.Equal(t, "common:2.4.0", chart.Subcharts[0].Id())
+	assert.Equal(t, "redis:17.11.3", chart.Subcharts[1].Id())
+	assert.Equal(t, []string{"docker.io/bitnami/redis:7.0.11-debian-11-r12"}, chart.Subcharts[1].Images())
+
+	// A packaged chart is loaded the same way.
+	archive, err := LoadChart(filepath.Join(chartDir, "charts", "common-2.4.0.tgz"))
+	require.NoError(t, err)
+	assert.Equal(t, "common:2.4.0", archive.Id())
+	assert.Nil(t, archive.Lock)
+
+	_, err = LoadChart(t.TempDir())
+	assert.ErrorContains(t, err, DescriptorFileName)
+}
+
+func TestImages(t *testing.T) {
+	chart, err := LoadChart(chartDir)
+	require.NoError(t, err)
+	// The image without a tag is tagged by the app version, and the template of the proxy image is ignored.
+	assert.Equal(t, []string{"acme/webapp:2.4.1", "busybox:1.36", "docker.io/acme/migrations:3"}, chart.Images())
+
+	tests := []struct {
+		value      interface{}
+		appVersion string
+		expected   string
+	}{
+		{"nginx", "", "nginx:latest"},
+		{"registry:5000/nginx", "1.0", "registry:5000/nginx:1.0"},
+		{"nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31", "", "nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"},
+		{map[interface{}]interface{}{"repository": "nginx", "tag": 1.25}, "", "nginx:1.25"},
+		{map[interface{}]interface{}{"pullPolicy": "Always"}, "", ""},
+		{"{{ .Values.image }}", "", ""},
+		{true, "", ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, parseImage(test.value, test.appVersion), test.value)
+	}
+}
+
+func TestBuildDependencyTree(t *testing.T) {
+	trees, err := BuildDependencyTree(chartDir)
+	require.NoError(t, err)
+	require.Len(t, trees, 1)
+	assert.Equal(t, "helm://webapp:1.2.0", trees[0].Id)
+	var childIds []string
+	for _, child := range trees[0].Nodes {
+		childIds = append(childIds, child.Id)
+	}
+	assert.Equal(t, []string{"docker://acme/webapp:2.4.1", "docker://busybox:1.36", "docker://docker.io/acme/migrations:3",
+		"helm://common:2.4.0", "helm://redis:17.11.3"}, childIds)
+	require.Len(t, trees[0].Nodes[4].Nodes, 1)
+	assert.Equal(t, "docker://docker.io/bitnami/redis:7.0.11-debian-11-r12", trees[0].Nodes[4].Nodes[0].Id)
+}
+
+func TestBuildDependencyTreeUndownloadedDependencies(t *testing.T) {
+	dir := t.TempDir()
+	descriptor := "apiVersion: v2\nname: app\nversion: 0.1.0\ndependencies:\n- name: redis\n  version: 17.11.3\n  repository: https://charts.acme.io\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DescriptorFileName), []byte(descriptor), 0644))
+	trees, err := BuildDependencyTree(dir)
+	require.NoError(t, err)
+	require.Len(t, trees[0].Nodes, 1)
+	assert.Equal(t, "helm://redis:17.11.3", trees[0].Nodes[0].Id)
+}
+
+func TestBuildInfoDependencies(t *testing.T) {
+	chart, err := LoadChart(chartDir)
+	require.NoError(t, err)
+	dependencies, err := chart.BuildInfoDependencies(chartDir)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 2)
+	assert.Equal(t, "redis:17.11.3", dependencies[0].Id)
+	assert.Equal(t, "helm", dependencies[0].Type)
+	// Only the dependencies which were downloaded as chart archives have checksums.
+	assert.Empty(t, dependencies[0].Sha1)
+	assert.Equal(t, "common:2.4.0", dependencies[1].Id)
+	assert.NotEmpty(t, dependencies[1].Sha1)
+	assert.NotEmpty(t, dependencies[1].Sha256)
+}
@@ -0,0 +1,438 @@
+package helm
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	packageCommand    = "package"
+	pushCommand       = "push"
+	dependencyCommand = "dependency"
+	// The package type of classic Helm repositories. Charts in other repositories, such as OCI and Docker repositories,
+	// are stored as OCI artifacts.
+	classicPackageType = "helm"
+	// The environment variables of Helm, which set the paths of its configuration files.
+	registryConfigEnv   = "HELM_REGISTRY_CONFIG"
+	repositoryConfigEnv = "HELM_REPOSITORY_CONFIG"
+)
+
+// The options of the helm package, push and dependency commands which are followed by a value.
+var valueFlags = []string{"-d", "--destination", "--version", "--app-version", "--key", "--keyring", "--passphrase-file",
+	"--ca-file", "--cert-file", "--key-file", "--repository-config", "--repository-cache", "--registry-config",
+	"--kube-context", "--kubeconfig", "-n", "--namespace", "--burst-limit"}
+
+// Runs Helm with the Helm repositories in Artifactory. Charts are resolved from the resolution repository, and pushed to
+// the deployment repository, which are either classic Helm repositories or OCI repositories.
+// Helm runs with temporary copies of its registry and repository configuration files, to which the Artifactory
+// repositories are added, so that the credentials of the repositories aren't saved.
+// The build-info is collected by the following commands:
+// 'helm package' and 'helm dependency update' - the dependencies of the chart, as listed in Chart.lock.
+// 'helm push' - the pushed chart.
+type HelmCommand struct {
+	resolverDetails *config.ServerDetails
+	resolverRepo    string
+	deployerDetails *config.ServerDetails
+	deployerRepo    string
+	commandName     string
+	args            []string
+	// The directory of the temporary configuration files of Helm.
+	helmConfigDir string
+}
+
+func NewHelmCommand() *HelmCommand {
+	return &HelmCommand{}
+}
+
+func (hc *HelmCommand) SetResolverDetails(serverDetails *config.ServerDetails) *HelmCommand {
+	hc.resolverDetails = serverDetails
+	return hc
+}
+
+func (hc *HelmCommand) SetResolverRepo(repo string) *HelmCommand {
+	hc.resolverRepo = repo
+	return hc
+}
+
+func (hc *HelmCommand) SetDeployerDetails(serverDetails *config.ServerDetails) *HelmCommand {
+	hc.deployerDetails = serverDetails
+	return hc
+}
+
+func (hc *HelmCommand) SetDeployerRepo(repo string) *HelmCommand {
+	hc.deployerRepo = repo
+	return hc
+}
+
+func (hc *HelmCommand) SetCommandName(commandName string) *HelmCommand {
+	hc.commandName = commandName
+	return hc
+}
+
+func (hc *HelmCommand) SetArgs(args []string) *HelmCommand {
+	hc.args = args
+	return hc
+}
+
+func (hc *HelmCommand) ServerDetails() (*config.ServerDetails, error) {
+	if hc.commandName == pushCommand || hc.resolverDetails == nil {
+		return hc.deployerDetails, nil
+	}
+	return hc.resolverDetails, nil
+}
+
+func (hc *HelmCommand) CommandName() string {
+	return "rt_helm"
+}
+
+func (hc *HelmCommand) Run() (err error) {
+	var buildConfiguration *utils.BuildConfiguration
+	hc.args, buildConfiguration, err = utils.ExtractBuildDetailsFromArgs(hc.args)
+	if err != nil {
+		return
+	}
+	if hc.helmConfigDir, err = fileutils.CreateTempDir(); err != nil {
+		return
+	}
+	defer func() {
+		if e := fileutils.RemoveTempDir(hc.helmConfigDir); err == nil {
+			err = e
+		}
+	}()
+	if err = hc.copyHelmConfig(); err != nil {
+		return
+	}
+	if hc.commandName == pushCommand {
+		return hc.push(buildConfiguration)
+	}
+	if hc.resolverDetails != nil {
+		if err = hc.addResolutionRepository(); err != nil {
+			return
+		}
+	}
+	log.Info("Running helm", hc.commandName)
+	if err = errorutils.CheckError(gofrogcmd.RunCmd(hc)); err != nil {
+		return
+	}
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return
+	}
+	positionalArgs := getPositionalArgs(hc.args)
+	switch {
+	case hc.commandName == packageCommand:
+		return hc.collectDependencies(buildConfiguration, positionalArgs)
+	case hc.commandName == dependencyCommand && len(positionalArgs) > 0 && (positionalArgs[0] == "update" || positionalArgs[0] == "up"):
+		return hc.collectDependencies(buildConfiguration, positionalArgs[1:])
+	}
+	return
+}
+
+// Returns the arguments which aren't options or values of options.
+func getPositionalArgs(args []string) (positionalArgs []string) {
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			positionalArgs = append(positionalArgs, args[i])
+			continue
+		}
+		for _, flag := range valueFlags {
+			if args[i] == flag {
+				i++
+				break
+			}
+		}
+	}
+	return
+}
+
+// Copies the registry and repository configuration files of Helm, if they exist, to the temporary configuration directory.
+func (hc *HelmCommand) copyHelmConfig() error {
+	for _, env := range []string{registryConfigEnv, repositoryConfigEnv} {
+		configPath := getHelmEnv(env)
+		if configPath == "" {
+			continue
+		}
+		content, err := os.ReadFile(configPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err = os.WriteFile(hc.configPath(env), content, 0600); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+// Returns the value of the environment variable of Helm, or an empty string if it couldn't be found.
+func getHelmEnv(env string) string {
+	if value := os.Getenv(env); value != "" {
+		return value
+	}
+	output, err := exec.Command("helm", "env", env).Output()
+	if err != nil {
+		log.Debug("Couldn't get", env, "from Helm:", err.Error())
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// Returns the path of the temporary configuration file, which is set by the environment variable.
+func (hc *HelmCommand) configPath(env string) string {
+	if env == registryConfigEnv {
+		return filepath.Join(hc.helmConfigDir, "registry.json")
+	}
+	return filepath.Join(hc.helmConfigDir, "repositories.yaml")
+}
+
+// Returns true if the Artifactory repository stores charts as OCI artifacts.
+func isOciRepository(serverDetails *config.ServerDetails, repo string) (bool, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return false, err
+	}
+	repoDetails := &services.RepositoryDetails{}
+	if err = servicesManager.GetRepository(repo, repoDetails); err != nil {
+		return false, err
+	}
+	return repoDetails.PackageType != classicPackageType, nil
+}
+
+// Returns the host of the OCI registry of Artifactory.
+func registryHost(serverDetails *config.ServerDetails) (string, error) {
+	artifactoryUrl, err := url.Parse(serverDetails.ArtifactoryUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return artifactoryUrl.Host, nil
+}
+
+// Returns the credentials for Helm, which supports only basic authentication.
+func credentials(serverDetails *config.ServerDetails) (user, password string) {
+	user, password = serverDetails.User, serverDetails.Password
+	if serverDetails.AccessToken != "" {
+		password = serverDetails.AccessToken
+		if user == "" {
+			user = auth.ExtractUsernameFromAccessToken(serverDetails.AccessToken)
+		}
+	}
+	return
+}
+
+// Adds the resolution repository to the repository configuration of Helm if it's a classic Helm repository,
+// or logs in to the OCI registry of Artifactory otherwise.
+func (hc *HelmCommand) addResolutionRepository() error {
+	isOci, err := isOciRepository(hc.resolverDetails, hc.resolverRepo)
+	if err != nil {
+		return err
+	}
+	if isOci {
+		return hc.registryLogin(hc.resolverDetails)
+	}
+	user, password := credentials(hc.resolverDetails)
+	repoUrl := clientutils.AddTrailingSlashIfNeeded(hc.resolverDetails.ArtifactoryUrl) + "api/helm/" + hc.resolverRepo
+	return hc.runHelm(password, "repo", "add", hc.resolverRepo, repoUrl, "--force-update", "--username", user, "--password-stdin")
+}
+
+func (hc *HelmCommand) registryLogin(serverDetails *config.ServerDetails) error {
+	host, err := registryHost(serverDetails)
+	if err != nil {
+		return err
+	}
+	user, password := credentials(serverDetails)
+	return hc.runHelm(password, "registry", "login", host, "--username", user, "--password-stdin")
+}
+
+// Runs a Helm command which configures Helm, with the provided standard input.
+func (hc *HelmCommand) runHelm(stdin string, args ...string) error {
+	cmd := exec.Command("helm", args...)
+	cmd.Env = os.Environ()
+	for key, value := range hc.GetEnv() {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	cmd.Stdin = strings.NewReader(stdin)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errorutils.CheckErrorf("'helm %s %s' failed: %s\n%s", args[0], args[1], err.Error(), strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Pushes the chart archive to the deployment repository. Charts are pushed to OCI repositories by Helm, and uploaded
+// to classic Helm repositories, which Helm can't push to.
+func (hc *HelmCommand) push(buildConfiguration *utils.BuildConfiguration) error {
+	if hc.deployerDetails == nil {
+		return errorutils.CheckErrorf("a deployment repository must be configured for pushing charts. Run 'jf helm-config' with the --repo-deploy option")
+	}
+	positionalArgs := getPositionalArgs(hc.args)
+	if len(positionalArgs) != 1 {
+		return errorutils.CheckErrorf("the helm push command expects the path of the chart archive. The chart is pushed to the configured deployment repository")
+	}
+	chartPath := positionalArgs[0]
+	chart, err := LoadChart(chartPath)
+	if err != nil {
+		return err
+	}
+	isOci, err := isOciRepository(hc.deployerDetails, hc.deployerRepo)
+	if err != nil {
+		return err
+	}
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	if isOci {
+		err = hc.pushToOciRepository(chart)
+	} else {
+		err = hc.uploadToClassicRepository(chartPath, buildConfiguration, collectBuildInfo)
+	}
+	if err != nil || !collectBuildInfo {
+		return err
+	}
+	fileDetails, err := fileutils.GetFileDetails(chartPath, true)
+	if err != nil {
+		return err
+	}
+	artifact := buildinfo.Artifact{
+		Name:     filepath.Base(chartPath),
+		Type:     "tgz",
+		Path:     filepath.Base(chartPath),
+		Checksum: buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256},
+	}
+	if isOci {
+		artifact.Path = ociArtifactPath(chart, artifact.Sha256)
+		artifact.Name = path.Base(artifact.Path)
+	}
+	return saveModule(buildConfiguration, chart, func(partial *buildinfo.Partial) {
+		partial.Artifacts = []buildinfo.Artifact{artifact}
+	})
+}
+
+func (hc *HelmCommand) pushToOciRepository(chart *Chart) error {
+	if err := hc.registryLogin(hc.deployerDetails); err != nil {
+		return err
+	}
+	host, err := registryHost(hc.deployerDetails)
+	if err != nil {
+		return err
+	}
+	hc.args = append(hc.args, "oci://"+host+"/"+hc.deployerRepo)
+	log.Info("Pushing chart", chart.Id(), "to", hc.deployerRepo)
+	return errorutils.CheckError(gofrogcmd.RunCmd(hc))
+}
+
+// Returns the path of a chart which was pushed to an OCI repository in Artifactory, relative to the repository.
+// The chart archive is stored as a layer of the chart's manifest, which is named after the digest of the archive.
+func ociArtifactPath(chart *Chart, sha256 string) string {
+	// OCI tags can't include the '+' character of SemVer build metadata, which Helm replaces with '_'.
+	tag := strings.ReplaceAll(chart.Metadata.Version, "+", "_")
+	return path.Join(chart.Metadata.Name, tag, "sha256__"+sha256)
+}
+
+// Uploads the chart archive to the root of the classic Helm repository. The build properties are set on the chart
+// if the build-info is collected.
+func (hc *HelmCommand) uploadToClassicRepository(chartPath string, buildConfiguration *utils.BuildConfiguration, collectBuildInfo bool) (err error) {
+	buildProps := ""
+	if collectBuildInfo {
+		if buildProps, err = utils.CreateBuildPropsFromConfiguration(buildConfiguration); err != nil {
+			return
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(hc.deployerDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.CommonParams = &specutils.CommonParams{Pattern: chartPath, Target: hc.deployerRepo + "/"}
+	uploadParams.Flat = true
+	uploadParams.BuildProps = buildProps
+	log.Info("Uploading chart", chartPath, "to", hc.deployerRepo)
+	uploaded, failed, err := servicesManager.UploadFiles(uploadParams)
+	if err != nil {
+		return err
+	}
+	if uploaded != 1 || failed != 0 {
+		return errorutils.CheckErrorf("failed to upload the chart %s to %s", chartPath, hc.deployerRepo)
+	}
+	return nil
+}
+
+// Adds the dependencies of the chart, whose directory is the first positional argument, to the build-info.
+func (hc *HelmCommand) collectDependencies(buildConfiguration *utils.BuildConfiguration, positionalArgs []string) error {
+	chartDir := "."
+	if len(positionalArgs) > 0 {
+		chartDir = positionalArgs[0]
+	}
+	chart, err := LoadChart(chartDir)
+	if err != nil {
+		return err
+	}
+	dependencies, err := chart.BuildInfoDependencies(chartDir)
+	if err != nil {
+		return err
+	}
+	return saveModule(buildConfiguration, chart, func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+	})
+}
+
+// Saves the partial build-info of the chart's module. The module is named after the chart, unless a module name was provided.
+func saveModule(buildConfiguration *utils.BuildConfiguration, chart *Chart, populatePartial func(partial *buildinfo.Partial)) error {
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	moduleId := buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = chart.Id()
+	}
+	if err = utils.SaveBuildGeneralDetails(buildName, buildNumber, buildConfiguration.GetProject()); err != nil {
+		return err
+	}
+	return utils.SavePartialBuildInfo(buildName, buildNumber, buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+		partial.ModuleType = buildinfo.ModuleType(Technology)
+		partial.ModuleId = moduleId
+		populatePartial(partial)
+	})
+}
+
+func (hc *HelmCommand) GetCmd() *exec.Cmd {
+	return exec.Command("helm", append([]string{hc.commandName}, hc.args...)...)
+}
+
+func (hc *HelmCommand) GetEnv() map[string]string {
+	return map[string]string{
+		registryConfigEnv:   hc.configPath(registryConfigEnv),
+		repositoryConfigEnv: hc.configPath(repositoryConfigEnv),
+	}
+}
+
+func (hc *HelmCommand) GetStdWriter() io.WriteCloser {
+	return nil
+}
+
+func (hc *HelmCommand) GetErrWriter() io.WriteCloser {
+	return nil
+}
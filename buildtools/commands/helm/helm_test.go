@@ -0,0 +1,38 @@
+package helm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPositionalArgs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"mychart"}, []string{"mychart"}},
+		{[]string{"-d", "out", "mychart", "--version", "1.0.0"}, []string{"mychart"}},
+		{[]string{"--destination=out", "--dependency-update", "mychart"}, []string{"mychart"}},
+		{[]string{"update", "--skip-refresh", "mychart"}, []string{"update", "mychart"}},
+		{[]string{"--debug"}, nil},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getPositionalArgs(test.args), test.args)
+	}
+}
+
+func TestOciArtifactPath(t *testing.T) {
+	chart := &Chart{Metadata: Metadata{Name: "webapp", Version: "1.2.0+build.7"}}
+	assert.Equal(t, "webapp/1.2.0_build.7/sha256__abc", ociArtifactPath(chart, "abc"))
+}
+
+func TestGetEnv(t *testing.T) {
+	helmCmd := NewHelmCommand()
+	helmCmd.helmConfigDir = t.TempDir()
+	assert.Equal(t, map[string]string{
+		"HELM_REGISTRY_CONFIG":   filepath.Join(helmCmd.helmConfigDir, "registry.json"),
+		"HELM_REPOSITORY_CONFIG": filepath.Join(helmCmd.helmConfigDir, "repositories.yaml"),
+	}, helmCmd.GetEnv())
+}
@@ -4,23 +4,34 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jfrog/jfrog-client-go/auth"
 	"github.com/jfrog/jfrog-client-go/auth/cert"
 
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/diff"
+	"github.com/jfrog/jfrog-cli/config/configbundle"
+	"github.com/jfrog/jfrog-cli/config/configtest"
 	"github.com/jfrog/jfrog-cli/docs/config/add"
 	"github.com/jfrog/jfrog-cli/docs/config/edit"
 	"github.com/jfrog/jfrog-cli/docs/config/remove"
 	"github.com/jfrog/jfrog-cli/docs/config/use"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
 
 	"github.com/jfrog/jfrog-cli/docs/config/exportcmd"
 	"github.com/jfrog/jfrog-cli/docs/config/importcmd"
 	"github.com/jfrog/jfrog-cli/docs/config/show"
+	"github.com/jfrog/jfrog-cli/docs/config/testcmd"
+	"github.com/jfrog/jfrog-cli/docs/config/which"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/profile"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
 )
 
 func GetCommands() []cli.Command {
@@ -69,6 +80,7 @@ func GetCommands() []cli.Command {
 		{
 			Name:         "import",
 			Aliases:      []string{"im"},
+			Flags:        cliutils.GetCommandFlags(cliutils.ImportConfig),
 			Usage:        importcmd.GetDescription(),
 			HelpName:     corecommon.CreateUsage("c import", importcmd.GetDescription(), importcmd.Usage),
 			BashComplete: corecommon.CreateBashCompletionFunc(),
@@ -79,6 +91,7 @@ func GetCommands() []cli.Command {
 		{
 			Name:         "export",
 			Aliases:      []string{"ex"},
+			Flags:        cliutils.GetCommandFlags(cliutils.ExportConfig),
 			Usage:        exportcmd.GetDescription(),
 			HelpName:     corecommon.CreateUsage("c export", exportcmd.GetDescription(), exportcmd.Usage),
 			BashComplete: corecommon.CreateBashCompletionFunc(commands.GetAllServerIds()...),
@@ -95,6 +108,27 @@ func GetCommands() []cli.Command {
 				return useCmd(c)
 			},
 		},
+		{
+			Name:         "test",
+			Aliases:      []string{"t"},
+			Flags:        cliutils.GetCommandFlags(cliutils.TestConfig),
+			Usage:        testcmd.GetDescription(),
+			HelpName:     corecommon.CreateUsage("c test", testcmd.GetDescription(), testcmd.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(commands.GetAllServerIds()...),
+			Action: func(c *cli.Context) error {
+				return testCmd(c)
+			},
+		},
+		{
+			Name:         "which",
+			Flags:        cliutils.GetCommandFlags(cliutils.WhichConfig),
+			Usage:        which.GetDescription(),
+			HelpName:     corecommon.CreateUsage("c which", which.GetDescription(), which.Usage),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return whichCmd(c)
+			},
+		},
 	})
 }
 
@@ -148,9 +182,49 @@ func addOrEdit(c *cli.Context, operation configOperation) error {
 	if err != nil {
 		return err
 	}
+	store, err := createServerStore(c, configCommandConfiguration, serverId)
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		// Move the secrets to the secret store before the configuration is saved, so that they never reach the config file.
+		details := configCommandConfiguration.ServerDetails
+		details.ServerId = serverId
+		if details.AccessToken != "" && details.User == "" {
+			// Extract the username from the access token, as done by the config command, since the token is removed from the details.
+			details.User = auth.ExtractUsernameFromAccessToken(details.AccessToken)
+		}
+		if err = secretstore.MoveSecretsToStore(details, store); err != nil {
+			return err
+		}
+	}
 	configCmd := commands.NewConfigCommand(commands.AddOrEdit, serverId).SetDetails(configCommandConfiguration.ServerDetails).SetInteractive(configCommandConfiguration.Interactive).
 		SetEncPassword(configCommandConfiguration.EncPassword).SetUseBasicAuthOnly(configCommandConfiguration.BasicAuthOnly)
-	return configCmd.Run()
+	if err = configCmd.Run(); err != nil || store != nil {
+		return err
+	}
+	// The server uses the default file store. Erase any secrets kept by its previous secret store.
+	details, err := configCmd.ServerDetails()
+	if err != nil {
+		return err
+	}
+	return secretstore.RemoveServer(details)
+}
+
+// Returns the secret store requested by the --secret-store option, or nil for the default file store.
+func createServerStore(c *cli.Context, configCommandConfiguration *commands.ConfigCommandConfiguration, serverId string) (*secretstore.ServerStore, error) {
+	store, err := secretstore.NewServerStore(c.String(cliutils.SecretStore), c.String(cliutils.SecretHelper))
+	if err != nil || store == nil {
+		return nil, err
+	}
+	// In interactive mode, the secrets are saved by the config command, so they can't be kept out of the config file.
+	if configCommandConfiguration.Interactive {
+		return nil, errorutils.CheckErrorf("the '%s' secret store can only be used along with the --interactive=false option", store.Type)
+	}
+	if serverId == "" {
+		return nil, errorutils.CheckErrorf("a server ID must be provided when using the '%s' secret store", store.Type)
+	}
+	return store, nil
 }
 
 func showCmd(c *cli.Context) error {
@@ -164,6 +238,33 @@ func showCmd(c *cli.Context) error {
 	return commands.ShowConfig(serverId)
 }
 
+func testCmd(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	format := c.String("format")
+	if err := diff.ValidateFormat(format); err != nil {
+		return err
+	}
+	testCommand := configtest.NewConfigTestCommand().SetFormat(format)
+	if c.NArg() == 1 {
+		testCommand.SetServerId(c.Args()[0])
+	}
+	return testCommand.Run()
+}
+
+func whichCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	resolution, err := profile.Resolve(c.String("server-id"))
+	if err != nil {
+		return err
+	}
+	log.Output(profile.FormatResolution(resolution))
+	return nil
+}
+
 func deleteCmd(c *cli.Context) error {
 	if c.NArg() > 1 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
@@ -172,7 +273,11 @@ func deleteCmd(c *cli.Context) error {
 
 	// Clear all configurations
 	if c.NArg() == 0 {
-		return commands.NewConfigCommand(commands.Clear, "").SetInteractive(!quiet).Run()
+		if err := commands.NewConfigCommand(commands.Clear, "").SetInteractive(!quiet).Run(); err != nil {
+			return err
+		}
+		// Erase the secrets of the removed servers from their secret stores.
+		return secretstore.RemoveUnconfiguredServers(commands.GetAllServerIds())
 	}
 
 	// Delete single configuration
@@ -180,14 +285,40 @@ func deleteCmd(c *cli.Context) error {
 	if !quiet && !coreutils.AskYesNo("Are you sure you want to delete \""+serverId+"\" configuration?", false) {
 		return nil
 	}
-	return commands.NewConfigCommand(commands.Delete, serverId).Run()
+	// The server details are needed to erase the server's secrets, after its configuration is deleted.
+	details, err := commands.GetConfig(serverId, false)
+	if err != nil {
+		details = &coreConfig.ServerDetails{ServerId: serverId}
+	}
+	if err = commands.NewConfigCommand(commands.Delete, serverId).Run(); err != nil {
+		return err
+	}
+	return secretstore.RemoveServer(details)
 }
 
 func importCmd(c *cli.Context) error {
 	if c.NArg() != 1 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
-	return commands.Import(c.Args()[0])
+	// The argument is either a bundle file, created by 'config export --out', or a Config Token.
+	isBundle, err := fileutils.IsFileExists(c.Args()[0], false)
+	if err != nil {
+		return err
+	}
+	if !isBundle {
+		if c.Bool("merge") || c.Bool("replace") || c.Bool("passphrase-stdin") {
+			return errorutils.CheckErrorf("the --merge, --replace and --passphrase-stdin options are supported only when importing a bundle file, but the file %s does not exist", c.Args()[0])
+		}
+		return commands.Import(c.Args()[0])
+	}
+	if c.Bool("merge") && c.Bool("replace") {
+		return errorutils.CheckErrorf("the --merge and --replace options are mutually exclusive")
+	}
+	passphrase, err := getBundlePassphrase(c, false)
+	if err != nil {
+		return err
+	}
+	return configbundle.NewImportBundleCommand().SetBundlePath(c.Args()[0]).SetPassphrase(passphrase).SetReplace(c.Bool("replace")).Run()
 }
 
 func exportCmd(c *cli.Context) error {
@@ -199,7 +330,44 @@ func exportCmd(c *cli.Context) error {
 	if c.NArg() == 1 {
 		serverId = c.Args()[0]
 	}
-	return commands.Export(serverId)
+	outputPath := c.String("out")
+	if outputPath == "" {
+		if c.Bool("all") || c.Bool("include-projects") || c.Bool("passphrase-stdin") {
+			return errorutils.CheckErrorf("the --all, --include-projects and --passphrase-stdin options require the --out option")
+		}
+		return commands.Export(serverId)
+	}
+	if c.Bool("all") == (serverId != "") {
+		return errorutils.CheckErrorf("exporting to a bundle file requires either a server ID argument or the --all option")
+	}
+	passphrase, err := getBundlePassphrase(c, true)
+	if err != nil {
+		return err
+	}
+	return configbundle.NewExportBundleCommand().SetServerId(serverId).SetOutputPath(outputPath).SetPassphrase(passphrase).
+		SetIncludeProjects(c.Bool("include-projects")).Run()
+}
+
+// Reads the passphrase of a bundle file from the standard input, or prompts for it.
+func getBundlePassphrase(c *cli.Context, confirm bool) (string, error) {
+	if c.Bool("passphrase-stdin") {
+		return cliutils.ReadSecretFromStdin("passphrase")
+	}
+	passphrase, err := ioutils.ScanPasswordFromConsole("Bundle passphrase: ")
+	if err != nil || !confirm {
+		return passphrase, err
+	}
+	if err = configbundle.ValidatePassphrase(passphrase); err != nil {
+		return "", err
+	}
+	confirmation, err := ioutils.ScanPasswordFromConsole("Confirm the passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if confirmation != passphrase {
+		return "", errorutils.CheckErrorf("the passphrases do not match")
+	}
+	return passphrase, nil
 }
 
 func useCmd(c *cli.Context) error {
@@ -0,0 +1,200 @@
+package configbundle
+
+import (
+	"os"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type ExportBundleCommand struct {
+	serverId        string
+	outputPath      string
+	passphrase      string
+	includeProjects bool
+}
+
+func NewExportBundleCommand() *ExportBundleCommand {
+	return &ExportBundleCommand{}
+}
+
+// Sets the ID of the server to export. If not set, all the configured servers are exported.
+func (ebc *ExportBundleCommand) SetServerId(serverId string) *ExportBundleCommand {
+	ebc.serverId = serverId
+	return ebc
+}
+
+func (ebc *ExportBundleCommand) SetOutputPath(outputPath string) *ExportBundleCommand {
+	ebc.outputPath = outputPath
+	return ebc
+}
+
+func (ebc *ExportBundleCommand) SetPassphrase(passphrase string) *ExportBundleCommand {
+	ebc.passphrase = passphrase
+	return ebc
+}
+
+func (ebc *ExportBundleCommand) SetIncludeProjects(includeProjects bool) *ExportBundleCommand {
+	ebc.includeProjects = includeProjects
+	return ebc
+}
+
+func (ebc *ExportBundleCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (ebc *ExportBundleCommand) CommandName() string {
+	return "config_export_bundle"
+}
+
+func (ebc *ExportBundleCommand) Run() error {
+	bundle, err := ebc.createBundle()
+	if err != nil {
+		return err
+	}
+	content, err := Encrypt(bundle, ebc.passphrase)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(ebc.outputPath, content, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Exported", len(bundle.Servers), "server configurations and", len(bundle.Projects), "project configurations to", ebc.outputPath)
+	return nil
+}
+
+func (ebc *ExportBundleCommand) createBundle() (*Bundle, error) {
+	servers, err := config.GetAllServersConfigs()
+	if err != nil {
+		return nil, err
+	}
+	bundle := &Bundle{Version: bundleVersion}
+	for _, server := range servers {
+		if ebc.serverId != "" && server.ServerId != ebc.serverId {
+			continue
+		}
+		// The bundle includes the secrets, also of servers which keep them in a secret store.
+		if err = secretstore.ResolveSecrets(server); err != nil {
+			return nil, err
+		}
+		bundle.Servers = append(bundle.Servers, server)
+	}
+	if len(bundle.Servers) == 0 {
+		if ebc.serverId != "" {
+			return nil, errorutils.CheckErrorf("Server ID '%s' does not exist.", ebc.serverId)
+		}
+		return nil, errorutils.CheckErrorf("cannot export the configuration, because no servers are configured. Run '%s c add' and then export again", coreutils.GetCliExecutableName())
+	}
+	if ebc.includeProjects {
+		if bundle.Projects, err = readProjects(); err != nil {
+			return nil, err
+		}
+	}
+	return bundle, nil
+}
+
+type ImportBundleCommand struct {
+	bundlePath string
+	passphrase string
+	replace    bool
+	results    []ImportResult
+}
+
+func NewImportBundleCommand() *ImportBundleCommand {
+	return &ImportBundleCommand{}
+}
+
+func (ibc *ImportBundleCommand) SetBundlePath(bundlePath string) *ImportBundleCommand {
+	ibc.bundlePath = bundlePath
+	return ibc
+}
+
+func (ibc *ImportBundleCommand) SetPassphrase(passphrase string) *ImportBundleCommand {
+	ibc.passphrase = passphrase
+	return ibc
+}
+
+// If set to true, the existing configuration is replaced by the bundle. Otherwise, the bundle is merged into the existing configuration.
+func (ibc *ImportBundleCommand) SetReplace(replace bool) *ImportBundleCommand {
+	ibc.replace = replace
+	return ibc
+}
+
+func (ibc *ImportBundleCommand) Results() []ImportResult {
+	return ibc.results
+}
+
+func (ibc *ImportBundleCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (ibc *ImportBundleCommand) CommandName() string {
+	return "config_import_bundle"
+}
+
+func (ibc *ImportBundleCommand) Run() error {
+	content, err := os.ReadFile(ibc.bundlePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	bundle, err := Decrypt(content, ibc.passphrase)
+	if err != nil {
+		return err
+	}
+	existing, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	var resolved []*config.ServerDetails
+	for _, server := range existing {
+		resolvedServer := *server
+		if err = secretstore.ResolveSecrets(&resolvedServer); err != nil {
+			return err
+		}
+		resolved = append(resolved, &resolvedServer)
+	}
+	merged, results := MergeServers(existing, resolved, bundle.Servers, ibc.replace)
+	if err = config.SaveServersConf(merged); err != nil {
+		return err
+	}
+	if err = ibc.updateSecretStores(merged, results); err != nil {
+		return err
+	}
+	projectResults, err := importProjects(bundle.Projects, ibc.replace)
+	if err != nil {
+		return err
+	}
+	ibc.results = append(results, projectResults...)
+	if err = coreutils.PrintTable(ibc.results, "Configuration Import", "The bundle is empty", false); err != nil {
+		return err
+	}
+	conflicts := 0
+	for _, result := range ibc.results {
+		if result.Action == Conflict {
+			conflicts++
+		}
+	}
+	if conflicts > 0 {
+		return errorutils.CheckErrorf("%d of the imported configurations conflict with the existing configurations, and were not imported. Use --replace to replace the existing configurations", conflicts)
+	}
+	return nil
+}
+
+// Removes the replaced and removed servers from their secret stores, since the bundle's secrets are kept in the configuration file.
+func (ibc *ImportBundleCommand) updateSecretStores(merged []*config.ServerDetails, results []ImportResult) error {
+	for _, result := range results {
+		if result.Action == Replaced {
+			if err := secretstore.RemoveServer(&config.ServerDetails{ServerId: result.Name}); err != nil {
+				return err
+			}
+		}
+	}
+	var serverIds []string
+	for _, server := range merged {
+		serverIds = append(serverIds, server.ServerId)
+	}
+	return secretstore.RemoveUnconfiguredServers(serverIds)
+}
@@ -0,0 +1,319 @@
+package configbundle
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	bundleVersion = 1
+	kdfScrypt     = "scrypt"
+	// The scrypt parameters recommended for interactive logins.
+	scryptN             = 32768
+	scryptR             = 8
+	scryptP             = 1
+	keyLength           = 32
+	saltLength          = 16
+	projectsDir         = "projects"
+	minPassphraseLength = 8
+)
+
+// The content of a configuration bundle.
+type Bundle struct {
+	Version int                     `json:"version"`
+	Servers []*config.ServerDetails `json:"servers"`
+	// The global project configurations from the JFrog CLI home directory, mapped by their file names.
+	Projects map[string]string `json:"projects,omitempty"`
+}
+
+// The bundle file. The bundle is encrypted using AES-GCM, with a key derived from the passphrase using scrypt.
+type encryptedBundle struct {
+	Version int    `json:"version"`
+	Kdf     string `json:"kdf"`
+	N       int    `json:"n"`
+	R       int    `json:"r"`
+	P       int    `json:"p"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+func ValidatePassphrase(passphrase string) error {
+	if len(passphrase) < minPassphraseLength {
+		return errorutils.CheckErrorf("the passphrase must be at least %d characters long", minPassphraseLength)
+	}
+	return nil
+}
+
+func newCipher(passphrase string, salt []byte, n, r, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, n, r, p, keyLength)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	return gcm, errorutils.CheckError(err)
+}
+
+func Encrypt(bundle *Bundle, passphrase string) ([]byte, error) {
+	if err := ValidatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	encrypted := &encryptedBundle{Version: bundleVersion, Kdf: kdfScrypt, N: scryptN, R: scryptR, P: scryptP, Salt: make([]byte, saltLength)}
+	if _, err = rand.Read(encrypted.Salt); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	gcm, err := newCipher(passphrase, encrypted.Salt, encrypted.N, encrypted.R, encrypted.P)
+	if err != nil {
+		return nil, err
+	}
+	encrypted.Nonce = make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(encrypted.Nonce); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	encrypted.Data = gcm.Seal(nil, encrypted.Nonce, content, nil)
+	content, err = json.MarshalIndent(encrypted, "", "  ")
+	return content, errorutils.CheckError(err)
+}
+
+func Decrypt(content []byte, passphrase string) (*Bundle, error) {
+	encrypted := new(encryptedBundle)
+	if err := json.Unmarshal(content, encrypted); err != nil {
+		return nil, errorutils.CheckErrorf("the file isn't a valid configuration bundle: %s", err.Error())
+	}
+	if encrypted.Version != bundleVersion || encrypted.Kdf != kdfScrypt {
+		return nil, errorutils.CheckErrorf("unsupported configuration bundle version %d", encrypted.Version)
+	}
+	// Bundles are encrypted with fixed scrypt parameters. Larger parameters aren't accepted, since they could make the key derivation exhaust the memory and CPU.
+	if encrypted.N > scryptN || encrypted.R > scryptR || encrypted.P > scryptP {
+		return nil, errorutils.CheckErrorf("the configuration bundle is corrupted: unsupported scrypt parameters")
+	}
+	gcm, err := newCipher(passphrase, encrypted.Salt, encrypted.N, encrypted.R, encrypted.P)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted.Nonce) != gcm.NonceSize() {
+		return nil, errorutils.CheckErrorf("the configuration bundle is corrupted")
+	}
+	content, err = gcm.Open(nil, encrypted.Nonce, encrypted.Data, nil)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to decrypt the configuration bundle. Make sure the passphrase is correct")
+	}
+	bundle := new(Bundle)
+	return bundle, errorutils.CheckError(json.Unmarshal(content, bundle))
+}
+
+func getProjectsDir() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, projectsDir), nil
+}
+
+// Reads the global project configurations from the JFrog CLI home directory.
+func readProjects() (map[string]string, error) {
+	dir, err := getProjectsDir()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsDirExists(dir, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	files, err := fileutils.ListFiles(dir, false)
+	if err != nil {
+		return nil, err
+	}
+	projects := map[string]string{}
+	for _, path := range files {
+		if !strings.HasSuffix(path, ".yaml") {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		projects[filepath.Base(path)] = string(content)
+	}
+	return projects, nil
+}
+
+type ImportAction string
+
+const (
+	Added     ImportAction = "added"
+	Unchanged ImportAction = "unchanged"
+	Replaced  ImportAction = "replaced"
+	Removed   ImportAction = "removed"
+	// The imported item differs from the existing one, which was kept.
+	Conflict ImportAction = "conflict"
+)
+
+type ImportResult struct {
+	Type   string       `json:"type" col-name:"Type"`
+	Name   string       `json:"name" col-name:"Name"`
+	Action ImportAction `json:"action" col-name:"Action"`
+}
+
+func isSameServer(first, second *config.ServerDetails) bool {
+	firstCopy, secondCopy := *first, *second
+	firstCopy.IsDefault, secondCopy.IsDefault = false, false
+	return reflect.DeepEqual(firstCopy, secondCopy)
+}
+
+// Merges the imported servers into the existing servers.
+// The existing servers are compared to the imported servers after their secrets are resolved, and are kept as they are, unless replaced.
+// If replace is true, the existing servers are replaced by the imported servers.
+// Otherwise, servers which already exist are kept, and reported as conflicts if they differ from the imported servers.
+func MergeServers(existing, resolved, imported []*config.ServerDetails, replace bool) ([]*config.ServerDetails, []ImportResult) {
+	resolvedById := map[string]*config.ServerDetails{}
+	for _, server := range resolved {
+		resolvedById[server.ServerId] = server
+	}
+	existingById := map[string]*config.ServerDetails{}
+	for _, server := range existing {
+		existingById[server.ServerId] = server
+	}
+	importedIds := map[string]bool{}
+	var results []ImportResult
+	var merged []*config.ServerDetails
+	if replace {
+		for _, server := range imported {
+			importedIds[server.ServerId] = true
+			action := Added
+			importedServer := *server
+			mergedServer := &importedServer
+			if current, exists := resolvedById[server.ServerId]; exists {
+				action = Replaced
+				if isSameServer(current, server) {
+					// Keep the existing server, since its secrets may be kept in a secret store.
+					existingServer := *existingById[server.ServerId]
+					existingServer.IsDefault = server.IsDefault
+					action, mergedServer = Unchanged, &existingServer
+				}
+			}
+			merged = append(merged, mergedServer)
+			results = append(results, ImportResult{Type: "server", Name: server.ServerId, Action: action})
+		}
+		for _, server := range existing {
+			if !importedIds[server.ServerId] {
+				results = append(results, ImportResult{Type: "server", Name: server.ServerId, Action: Removed})
+			}
+		}
+	} else {
+		merged = append(merged, existing...)
+		for _, server := range imported {
+			current, exists := resolvedById[server.ServerId]
+			switch {
+			case !exists:
+				importedServer := *server
+				merged = append(merged, &importedServer)
+				results = append(results, ImportResult{Type: "server", Name: server.ServerId, Action: Added})
+			case isSameServer(current, server):
+				results = append(results, ImportResult{Type: "server", Name: server.ServerId, Action: Unchanged})
+			default:
+				results = append(results, ImportResult{Type: "server", Name: server.ServerId, Action: Conflict})
+			}
+		}
+	}
+	setSingleDefault(merged, existing, replace)
+	return merged, results
+}
+
+// Makes sure a single server is set as default. When merging, the existing default server is kept.
+func setSingleDefault(merged, existing []*config.ServerDetails, replace bool) {
+	if len(merged) == 0 {
+		return
+	}
+	defaultId := ""
+	if !replace {
+		for _, server := range existing {
+			if server.IsDefault {
+				defaultId = server.ServerId
+			}
+		}
+	}
+	if defaultId == "" {
+		for _, server := range merged {
+			if server.IsDefault {
+				defaultId = server.ServerId
+				break
+			}
+		}
+	}
+	if defaultId == "" {
+		defaultId = merged[0].ServerId
+	}
+	for _, server := range merged {
+		server.IsDefault = server.ServerId == defaultId
+	}
+}
+
+// Writes the imported project configurations to the projects directory. Existing different configurations are kept, unless replace is true.
+func importProjects(projects map[string]string, replace bool) ([]ImportResult, error) {
+	if len(projects) == 0 {
+		return nil, nil
+	}
+	dir, err := getProjectsDir()
+	if err != nil {
+		return nil, err
+	}
+	if err = fileutils.CreateDirIfNotExist(dir); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(projects))
+	for name := range projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var results []ImportResult
+	for _, name := range names {
+		// Prevent writing outside the projects directory.
+		if filepath.Base(name) != name || !strings.HasSuffix(name, ".yaml") {
+			return nil, errorutils.CheckErrorf("the configuration bundle includes an invalid project configuration file name: %s", name)
+		}
+		path := filepath.Join(dir, name)
+		action := Added
+		current, err := os.ReadFile(path)
+		if err == nil {
+			switch {
+			case string(current) == projects[name]:
+				action = Unchanged
+			case replace:
+				action = Replaced
+			default:
+				action = Conflict
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, errorutils.CheckError(err)
+		}
+		if action == Added || action == Replaced {
+			if err = os.WriteFile(path, []byte(projects[name]), 0644); err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+		}
+		results = append(results, ImportResult{Type: "project", Name: name, Action: action})
+	}
+	return results, nil
+}
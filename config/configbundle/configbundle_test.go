@@ -0,0 +1,153 @@
+package configbundle
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	bundle := &Bundle{
+		Version:  bundleVersion,
+		Servers:  []*config.ServerDetails{{ServerId: "server1", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "token", IsDefault: true}},
+		Projects: map[string]string{"maven.yaml": "version: 1\n"},
+	}
+	content, err := Encrypt(bundle, "passphrase")
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "token")
+
+	decrypted, err := Decrypt(content, "passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, bundle, decrypted)
+
+	_, err = Decrypt(content, "wrong-passphrase")
+	assert.ErrorContains(t, err, "Make sure the passphrase is correct")
+	_, err = Decrypt([]byte("not a bundle"), "passphrase")
+	assert.ErrorContains(t, err, "isn't a valid configuration bundle")
+	_, err = Encrypt(bundle, "short")
+	assert.Error(t, err)
+
+	// Scrypt parameters larger than the ones bundles are encrypted with are rejected before the key is derived.
+	encrypted := new(encryptedBundle)
+	assert.NoError(t, json.Unmarshal(content, encrypted))
+	encrypted.N = 1 << 30
+	content, err = json.Marshal(encrypted)
+	assert.NoError(t, err)
+	_, err = Decrypt(content, "passphrase")
+	assert.ErrorContains(t, err, "unsupported scrypt parameters")
+}
+
+func createServers() (existing, imported []*config.ServerDetails) {
+	existing = []*config.ServerDetails{
+		{ServerId: "same", ArtifactoryUrl: "https://same.jfrog.io/artifactory/"},
+		{ServerId: "different", ArtifactoryUrl: "https://old.jfrog.io/artifactory/", IsDefault: true},
+		{ServerId: "local", ArtifactoryUrl: "https://local.jfrog.io/artifactory/"},
+	}
+	imported = []*config.ServerDetails{
+		{ServerId: "same", ArtifactoryUrl: "https://same.jfrog.io/artifactory/", IsDefault: true},
+		{ServerId: "different", ArtifactoryUrl: "https://new.jfrog.io/artifactory/"},
+		{ServerId: "new", ArtifactoryUrl: "https://new.jfrog.io/artifactory/"},
+	}
+	return
+}
+
+func getServerIds(servers []*config.ServerDetails) (serverIds []string, defaultId string) {
+	for _, server := range servers {
+		serverIds = append(serverIds, server.ServerId)
+		if server.IsDefault {
+			defaultId = server.ServerId
+		}
+	}
+	return
+}
+
+func TestMergeServers(t *testing.T) {
+	existing, imported := createServers()
+	merged, results := MergeServers(existing, existing, imported, false)
+	assert.Equal(t, []ImportResult{
+		{Type: "server", Name: "same", Action: Unchanged},
+		{Type: "server", Name: "different", Action: Conflict},
+		{Type: "server", Name: "new", Action: Added},
+	}, results)
+	serverIds, defaultId := getServerIds(merged)
+	assert.Equal(t, []string{"same", "different", "local", "new"}, serverIds)
+	// The existing default server is kept.
+	assert.Equal(t, "different", defaultId)
+	assert.Equal(t, "https://old.jfrog.io/artifactory/", merged[1].ArtifactoryUrl)
+}
+
+func TestReplaceServers(t *testing.T) {
+	existing, imported := createServers()
+	merged, results := MergeServers(existing, existing, imported, true)
+	assert.Equal(t, []ImportResult{
+		{Type: "server", Name: "same", Action: Unchanged},
+		{Type: "server", Name: "different", Action: Replaced},
+		{Type: "server", Name: "new", Action: Added},
+		{Type: "server", Name: "local", Action: Removed},
+	}, results)
+	serverIds, defaultId := getServerIds(merged)
+	assert.Equal(t, []string{"same", "different", "new"}, serverIds)
+	assert.Equal(t, "same", defaultId)
+	assert.Equal(t, "https://new.jfrog.io/artifactory/", merged[1].ArtifactoryUrl)
+}
+
+func TestImportProjects(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv(coreutils.HomeDir, homeDir)
+	projectsPath := filepath.Join(homeDir, projectsDir)
+	assert.NoError(t, os.MkdirAll(projectsPath, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(projectsPath, "npm.yaml"), []byte("local"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(projectsPath, "go.yaml"), []byte("same"), 0644))
+
+	projects := map[string]string{"npm.yaml": "imported", "go.yaml": "same", "maven.yaml": "imported"}
+	results, err := importProjects(projects, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []ImportResult{
+		{Type: "project", Name: "go.yaml", Action: Unchanged},
+		{Type: "project", Name: "maven.yaml", Action: Added},
+		{Type: "project", Name: "npm.yaml", Action: Conflict},
+	}, results)
+	exported, err := readProjects()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"npm.yaml": "local", "go.yaml": "same", "maven.yaml": "imported"}, exported)
+
+	results, err = importProjects(projects, true)
+	assert.NoError(t, err)
+	assert.Equal(t, ImportResult{Type: "project", Name: "npm.yaml", Action: Replaced}, results[2])
+	exported, err = readProjects()
+	assert.NoError(t, err)
+	assert.Equal(t, projects, exported)
+
+	_, err = importProjects(map[string]string{"../jfrog-cli.conf.v6": "content"}, true)
+	assert.ErrorContains(t, err, "invalid project configuration file name")
+}
+
+func TestExportImportBundle(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv(coreutils.HomeDir, homeDir)
+	existing, imported := createServers()
+	assert.NoError(t, config.SaveServersConf(existing))
+	bundlePath := filepath.Join(t.TempDir(), "bundle.jfc")
+	assert.NoError(t, NewExportBundleCommand().SetOutputPath(bundlePath).SetPassphrase("passphrase").Run())
+
+	assert.NoError(t, config.SaveServersConf(imported))
+	importCommand := NewImportBundleCommand().SetBundlePath(bundlePath).SetPassphrase("passphrase")
+	assert.ErrorContains(t, importCommand.Run(), "1 of the imported configurations conflict")
+	servers, err := config.GetAllServersConfigs()
+	assert.NoError(t, err)
+	serverIds, defaultId := getServerIds(servers)
+	assert.Equal(t, []string{"same", "different", "new", "local"}, serverIds)
+	assert.Equal(t, "same", defaultId)
+
+	assert.NoError(t, importCommand.SetReplace(true).Run())
+	servers, err = config.GetAllServersConfigs()
+	assert.NoError(t, err)
+	serverIds, defaultId = getServerIds(servers)
+	assert.Equal(t, []string{"same", "different", "local"}, serverIds)
+	assert.Equal(t, "different", defaultId)
+}
@@ -0,0 +1,167 @@
+package configtest
+
+import (
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func createToken(expiry time.Time) string {
+	claims := `{"sub":"jfac@01/users/admin","iat":1600000000,"exp":` + strconv.FormatInt(expiry.Unix(), 10) + `}`
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func createTestServer(t *testing.T, password string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifactory/api/system/ping", "/xray/api/v1/system/ping":
+			_, _ = w.Write([]byte("OK"))
+		case "/artifactory/api/system/version", "/xray/api/v1/system/version":
+			if _, pass, ok := r.BasicAuth(); !ok || pass != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"version":"7.0.0"}`))
+		case "/distribution/api/v1/system/info":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func runTester(t *testing.T, details *config.ServerDetails) []CheckResult {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	tester, results := newServerTester(details)
+	return tester.run(results)
+}
+
+func getStatuses(results []CheckResult) map[string]Status {
+	statuses := map[string]Status{}
+	for _, result := range results {
+		statuses[result.Check] = result.Status
+	}
+	return statuses
+}
+
+func TestServerTester(t *testing.T) {
+	server := createTestServer(t, "password")
+	details := &config.ServerDetails{
+		ServerId:          "server1",
+		ArtifactoryUrl:    server.URL + "/artifactory/",
+		XrayUrl:           server.URL + "/xray",
+		DistributionUrl:   server.URL + "/distribution/",
+		MissionControlUrl: server.URL + "/mc/",
+		User:              "admin",
+		Password:          "password",
+	}
+	results := runTester(t, details)
+	assert.Equal(t, map[string]Status{
+		"Artifactory Connectivity":       Ok,
+		"Artifactory Authentication":     Ok,
+		"Xray Connectivity":              Ok,
+		"Xray Authentication":            Ok,
+		"Distribution Authentication":    Warning,
+		"Mission Control Authentication": Failure,
+	}, getStatuses(results))
+	for _, result := range results {
+		assert.Equal(t, "server1", result.ServerId)
+		assert.NotEmpty(t, result.Latency)
+	}
+
+	details.Password = "wrong"
+	assert.Equal(t, Failure, getStatuses(runTester(t, details))["Artifactory Authentication"])
+}
+
+func TestServerTesterConnectionFailure(t *testing.T) {
+	server := createTestServer(t, "password")
+	url := server.URL
+	server.Close()
+	results := runTester(t, &config.ServerDetails{ServerId: "server1", ArtifactoryUrl: url + "/artifactory/"})
+	// The authentication isn't checked if the service isn't reachable.
+	assert.Equal(t, map[string]Status{"Credentials": Warning, "Artifactory Connectivity": Failure}, getStatuses(results))
+	assert.Contains(t, results[1].Message, "Failed to connect")
+	assert.NotEmpty(t, results[1].Hint)
+}
+
+func TestServerTesterNoUrls(t *testing.T) {
+	results := runTester(t, &config.ServerDetails{ServerId: "server1", User: "admin", Password: "password"})
+	assert.Equal(t, map[string]Status{"Configuration": Failure}, getStatuses(results))
+}
+
+func TestServerTesterTls(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	details := &config.ServerDetails{ServerId: "server1", ArtifactoryUrl: server.URL + "/artifactory/", AccessToken: createToken(time.Now().Add(time.Hour * 24 * 365))}
+	results := runTester(t, details)
+	assert.Equal(t, Failure, getStatuses(results)["Artifactory Connectivity"])
+	assert.Contains(t, results[1].Message, "unknown authority")
+
+	details.InsecureTls = true
+	assert.Equal(t, Ok, getStatuses(runTester(t, details))["Artifactory Connectivity"])
+}
+
+func TestClientCertificateMismatch(t *testing.T) {
+	details := &config.ServerDetails{ServerId: "server1", ClientCertPath: "missing.crt", ClientCertKeyPath: "missing.key"}
+	results := runTester(t, details)
+	assert.Equal(t, Failure, getStatuses(results)["Client Certificate"])
+}
+
+func TestCheckCredentials(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		details  *config.ServerDetails
+		expected Status
+		reported bool
+	}{
+		{"password", &config.ServerDetails{User: "admin", Password: "password"}, "", false},
+		{"anonymous", &config.ServerDetails{}, Warning, true},
+		{"reference token", &config.ServerDetails{AccessToken: "cmVmdGtuOjAxOjE2"}, Ok, true},
+		{"valid token", &config.ServerDetails{AccessToken: createToken(now.Add(30 * 24 * time.Hour))}, Ok, true},
+		{"expiring token", &config.ServerDetails{AccessToken: createToken(now.Add(time.Hour))}, Warning, true},
+		{"expired token", &config.ServerDetails{AccessToken: createToken(now.Add(-time.Hour))}, Failure, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tester := &serverTester{details: test.details, now: func() time.Time { return now }}
+			result, reported := tester.checkCredentials()
+			assert.Equal(t, test.reported, reported)
+			assert.Equal(t, test.expected, result.Status)
+		})
+	}
+}
+
+func TestDiagnoseStatus(t *testing.T) {
+	status, _, _ := diagnoseStatus(http.StatusOK, true, "server1")
+	assert.Equal(t, Ok, status)
+	status, _, hint := diagnoseStatus(http.StatusUnauthorized, true, "server1")
+	assert.Equal(t, Failure, status)
+	assert.Contains(t, hint, "jf c edit server1")
+	status, _, _ = diagnoseStatus(http.StatusForbidden, true, "server1")
+	assert.Equal(t, Warning, status)
+	status, message, _ := diagnoseStatus(http.StatusProxyAuthRequired, false, "server1")
+	assert.Equal(t, Failure, status)
+	assert.Contains(t, message, "proxy")
+}
+
+func TestDiagnoseError(t *testing.T) {
+	message, _ := diagnoseError(&net.DNSError{Name: "acme.jfrog.io", Err: "no such host"}, "")
+	assert.Equal(t, "Failed to resolve the host name acme.jfrog.io.", message)
+	message, hint := diagnoseError(errors.New("proxyconnect tcp: dial tcp 127.0.0.1:3128: connect: connection refused"), "http://127.0.0.1:3128")
+	assert.Contains(t, message, "http://127.0.0.1:3128 proxy")
+	assert.Contains(t, hint, "NO_PROXY")
+	message, _ = diagnoseError(errors.New("remote error: tls: bad certificate"), "")
+	assert.Contains(t, message, "client certificate")
+}
@@ -0,0 +1,12 @@
+package accesstokeninspect
+
+var Usage = []string{"rt ati", "rt ati <access token>"}
+
+func GetDescription() string {
+	return "Decodes an access token locally, and displays its subject, scopes, audience and expiry. The token's signature isn't verified."
+}
+
+func GetArguments() string {
+	return `	access token
+		The access token to inspect. If not specified, the access token of the configured server is inspected.`
+}
@@ -0,0 +1,12 @@
+package accesstokenlist
+
+var Usage = []string{"rt atl", "rt atl <user name>"}
+
+func GetDescription() string {
+	return "Lists the access tokens. Administrators can list the tokens of all users, while other users can only list their own tokens."
+}
+
+func GetArguments() string {
+	return `	user name
+		If specified, only the tokens of this user are listed.`
+}
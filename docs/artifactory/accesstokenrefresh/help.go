@@ -0,0 +1,15 @@
+package accesstokenrefresh
+
+var Usage = []string{"rt atr <refresh token>", "rt atr <refresh token> <access token>"}
+
+func GetDescription() string {
+	return "Refreshes an access token, using its refresh token. The new access token and refresh token are returned."
+}
+
+func GetArguments() string {
+	return `	refresh token
+		The refresh token, which was returned when the access token was created or last refreshed.
+
+	access token
+		The access token to refresh. If not specified, the access token of the configured server is refreshed.`
+}
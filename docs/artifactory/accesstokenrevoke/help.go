@@ -0,0 +1,12 @@
+package accesstokenrevoke
+
+var Usage = []string{"rt atrv <token id or token>"}
+
+func GetDescription() string {
+	return "Revokes an access token."
+}
+
+func GetArguments() string {
+	return `	token id or token
+		The ID of the token to revoke, as displayed by the access-token-list command, or the token itself.`
+}
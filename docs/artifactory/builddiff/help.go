@@ -0,0 +1,25 @@
+package builddiff
+
+var Usage = []string{"rt build-diff [command options] <build name> <source build number> <target build number>",
+	"rt build-diff [command options] <source build name> <source build number> <target build name> <target build number>"}
+
+func GetDescription() string {
+	return "Show the differences between two published builds."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name, if both builds have the same name.
+
+	source build name
+		The name of the source build, if the builds have different names.
+
+	source build number
+		The number of the build to compare from.
+
+	target build name
+		The name of the target build, if the builds have different names.
+
+	target build number
+		The number of the build to compare to.`
+}
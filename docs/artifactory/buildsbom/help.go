@@ -0,0 +1,15 @@
+package buildsbom
+
+var Usage = []string{"rt build-sbom [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Create a CycloneDX or SPDX SBOM from build info."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name.
+
+	build number
+		Build number.`
+}
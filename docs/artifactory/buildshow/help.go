@@ -0,0 +1,15 @@
+package buildshow
+
+var Usage = []string{"rt build-show [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Show or validate the build info collected locally, before it is published."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name.
+
+	build number
+		Build number.`
+}
@@ -0,0 +1,15 @@
+package buildverify
+
+var Usage = []string{"rt build-verify [command options] <build name> <build number>"}
+
+func GetDescription() string {
+	return "Verify the signature of a published build info."
+}
+
+func GetArguments() string {
+	return `	build name
+		Build name.
+
+	build number
+		Build number.`
+}
@@ -0,0 +1,19 @@
+package diff
+
+var Usage = []string{"rt diff [command options] <source pattern> <target pattern>",
+	"rt diff --source-build=<build name>/<build number> --target-build=<build name>/<build number> [command options]"}
+
+func GetDescription() string {
+	return "Compare the files of two repository paths or two builds, by their paths, checksums and properties."
+}
+
+func GetArguments() string {
+	return `	source pattern
+		Specifies the source path in Artifactory, in the following format: <repository name>/<repository path>.
+		You can use wildcards to specify multiple artifacts. The files are compared by their paths relative to the pattern, up to its first wildcard.
+		Can be omitted if --source-build is provided. If both patterns are omitted, the artifacts of the builds are matched by their module IDs, without the versions, and their names.
+
+	target pattern
+		Specifies the target path in Artifactory, in the same format as the source pattern.
+		Can be omitted if --target-build is provided.`
+}
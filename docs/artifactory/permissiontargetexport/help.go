@@ -0,0 +1,15 @@
+package permissiontargetexport
+
+var Usage = []string{"rt permission-target-export [command options] <output dir> [permission target name pattern]"}
+
+func GetDescription() string {
+	return "Export the permission targets of an Artifactory server as permission target templates."
+}
+
+func GetArguments() string {
+	return `	output dir
+		Specifies the local file system path of the directory, to which the templates are written. A template is written for each exported permission target.
+
+	permission target name pattern
+		Specifies the permission targets to export. You can use wildcards to specify multiple permission targets. If not specified, all the permission targets are exported.`
+}
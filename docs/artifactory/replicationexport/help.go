@@ -0,0 +1,15 @@
+package replicationexport
+
+var Usage = []string{"rt replication-export [command options] <output dir> [repository key pattern]"}
+
+func GetDescription() string {
+	return "Export the replication jobs of an Artifactory server as replication templates."
+}
+
+func GetArguments() string {
+	return `	output dir
+		Specifies the local file system path of the directory, to which the templates are written. A template is written for each exported replication.
+
+	repository key pattern
+		Specifies the repositories whose replication jobs are exported. You can use wildcards to specify multiple repositories. If not specified, all the replication jobs are exported.`
+}
@@ -0,0 +1,14 @@
+package repoapply
+
+import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+
+var Usage = []string{"rt repo-apply [command options] <templates dir>"}
+
+func GetDescription() string {
+	return "Create, update and delete repositories in Artifactory, to match a directory of repository templates."
+}
+
+func GetArguments() string {
+	return `	templates dir
+		Specifies the local file system path of a directory with the repository templates, one template per repository, with the .json extension. The templates can be created using the "` + coreutils.GetCliExecutableName() + ` rt rpt" command.`
+}
@@ -0,0 +1,15 @@
+package repoexport
+
+var Usage = []string{"rt repo-export [command options] <output dir> [repository key pattern]"}
+
+func GetDescription() string {
+	return "Export the repositories of an Artifactory server as repository templates."
+}
+
+func GetArguments() string {
+	return `	output dir
+		Specifies the local file system path of the directory, to which the templates are written. A template is written for each exported repository.
+
+	repository key pattern
+		Specifies the repositories to export. You can use wildcards to specify multiple repositories. If not specified, all the repositories are exported.`
+}
@@ -0,0 +1,12 @@
+package specvalidate
+
+var Usage = []string{"rt spec-validate [command options] <spec file>"}
+
+func GetDescription() string {
+	return "Validate a File Spec offline, against the File Spec schema and the rules of the command it is intended for."
+}
+
+func GetArguments() string {
+	return `	spec file
+		Path to the File Spec. The spec vars provided by the --spec-vars option are replaced before the validation.`
+}
@@ -0,0 +1,15 @@
+package sync
+
+var Usage = []string{"rt sync [command options] <local directory> <repository path>"}
+
+func GetDescription() string {
+	return "Sync a local directory and a repository path, by comparing the checksums of their files."
+}
+
+func GetArguments() string {
+	return `	local directory
+		The local directory to sync.
+
+	repository path
+		The path in Artifactory to sync, in the following format: <repository name>/<repository path>. Wildcards are not supported.`
+}
@@ -0,0 +1,16 @@
+package transferjournal
+
+var Usage = []string{"rt transfer-journal list",
+	"rt transfer-journal clean [command options] [journal ID]"}
+
+func GetDescription() string {
+	return "List or clean the transfer journals, which are used by the upload and download commands with the --resume option."
+}
+
+func GetArguments() string {
+	return `	list
+		List all the transfer journals.
+
+	clean
+		Remove the transfer journal with the provided ID, or all the transfer journals if no ID is provided.`
+}
@@ -5,7 +5,7 @@ import "github.com/jfrog/jfrog-cli/docs/common"
 var Usage = []string{"rt u [command options] <source pattern> <target pattern>",
 	"rt u --spec=<File Spec path> [command options]"}
 
-var EnvVar = []string{common.JfrogCliMinChecksumDeploySizeKb, common.JfrogCliFailNoOp}
+var EnvVar = []string{common.JfrogCliMinChecksumDeploySizeKb, common.JfrogCliFailNoOp, common.JfrogCliMaxBandwidth, common.JfrogCliMaxRequestsPerSecond}
 
 func GetDescription() string {
 	return "Upload files."
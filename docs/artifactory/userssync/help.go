@@ -0,0 +1,7 @@
+package userssync
+
+var Usage = []string{"rt us --file <users details file path>"}
+
+func GetDescription() string {
+	return "Create and update users and groups, to match a CSV or YAML file."
+}
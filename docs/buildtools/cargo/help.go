@@ -0,0 +1,12 @@
+package cargo
+
+var Usage = []string{"cargo <cargo args> [command options]"}
+
+func GetDescription() string {
+	return "Run cargo command"
+}
+
+func GetArguments() string {
+	return `	cargo sub-command
+		Arguments and options for the cargo command.`
+}
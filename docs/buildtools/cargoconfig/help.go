@@ -0,0 +1,7 @@
+package cargoconfig
+
+var Usage = []string{"cargo-config"}
+
+func GetDescription() string {
+	return "Generate cargo build configuration."
+}
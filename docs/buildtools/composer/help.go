@@ -0,0 +1,12 @@
+package composer
+
+var Usage = []string{"composer <composer sub-command> <composer args> [command options]"}
+
+func GetDescription() string {
+	return "Run composer command"
+}
+
+func GetArguments() string {
+	return `	composer sub-command
+		Arguments and options for the composer command. The build-info is collected by the install and update commands.`
+}
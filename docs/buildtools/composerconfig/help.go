@@ -0,0 +1,7 @@
+package composerconfig
+
+var Usage = []string{"composer-config"}
+
+func GetDescription() string {
+	return "Generate composer build configuration."
+}
@@ -0,0 +1,12 @@
+package conan
+
+var Usage = []string{"conan <conan sub-command> <conan args> [command options]"}
+
+func GetDescription() string {
+	return "Run conan command"
+}
+
+func GetArguments() string {
+	return `	conan sub-command
+		Arguments and options for the conan command. The build-info is collected from the lockfile by the install, create, build and lock commands.`
+}
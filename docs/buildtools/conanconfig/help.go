@@ -0,0 +1,7 @@
+package conanconfig
+
+var Usage = []string{"conan-config"}
+
+func GetDescription() string {
+	return "Generate conan build configuration."
+}
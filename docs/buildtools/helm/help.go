@@ -0,0 +1,13 @@
+package helm
+
+var Usage = []string{"helm <helm sub-command> <helm args> [command options]"}
+
+func GetDescription() string {
+	return "Run helm command"
+}
+
+func GetArguments() string {
+	return `	helm sub-command
+		Arguments and options for the helm command. The build-info is collected by the package, push and dependency update commands.
+		The push command accepts the path of the chart archive, and pushes it to the configured deployment repository.`
+}
@@ -0,0 +1,7 @@
+package helmconfig
+
+var Usage = []string{"helm-config"}
+
+func GetDescription() string {
+	return "Generate helm configuration."
+}
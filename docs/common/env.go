@@ -77,6 +77,16 @@ const (
 		Set to true if you'd like the command to return exit code 2 in case of no files are affected.
 		Support by the following commands: copy, delete, delete-props, set-props, download, move, search and upload`
 
+	JfrogCliMaxBandwidth = `	JFROG_CLI_MAX_BANDWIDTH
+		The maximum total bandwidth of the file transfers, shared by all the threads. For example, 20MB/s.
+		Used as the default value of the --max-bandwidth option.
+		Support by the following commands: upload, download, docker-push, container-push and transfer-files`
+
+	JfrogCliMaxRequestsPerSecond = `	JFROG_CLI_MAX_REQUESTS_PER_SECOND
+		The maximum number of file transfer requests per second, shared by all the threads.
+		Used as the default value of the --max-requests-per-second option.
+		Support by the following commands: upload, download, docker-push, container-push and transfer-files`
+
 	JfrogCliEncryptionKey = `   	JFROG_CLI_ENCRYPTION_KEY
 		If provided, encrypt the sensitive data stored in the config with the provided key. Must be exactly 32 characters.`
 )
@@ -114,6 +124,8 @@ func GetGlobalEnvVars() string {
 		JfrogCliBuildUrl,
 		JfrogCliEnvExclude,
 		JfrogCliFailNoOp,
+		JfrogCliMaxBandwidth,
+		JfrogCliMaxRequestsPerSecond,
 		JfrogCliEncryptionKey)
 }
 
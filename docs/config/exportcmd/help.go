@@ -2,8 +2,10 @@ package exportcmd
 
 import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 
-var Usage = []string{"config export [server ID]"}
+var Usage = []string{"config export [server ID]",
+	"config export [server ID] --out <bundle file>",
+	"config export --all --out <bundle file>"}
 
 func GetDescription() string {
-	return `Creates a server configuration token. The generated Config Token can be imported by the "` + coreutils.GetCliExecutableName() + ` config import <Config Token>" command.`
+	return `Creates a server configuration token. The generated Config Token can be imported by the "` + coreutils.GetCliExecutableName() + ` config import <Config Token>" command. When the --out option is used, the configuration is exported to a passphrase-encrypted bundle file instead, which can be imported by the "` + coreutils.GetCliExecutableName() + ` config import <bundle file>" command.`
 }
@@ -0,0 +1,7 @@
+package testcmd
+
+var Usage = []string{"config test [server ID]"}
+
+func GetDescription() string {
+	return `Tests the connectivity and credentials of a server configuration. In case no server ID is provided, all the configured servers are tested.`
+}
@@ -0,0 +1,7 @@
+package which
+
+var Usage = []string{"config which"}
+
+func GetDescription() string {
+	return `Shows the server used by the commands in the current directory, the source from which it was resolved, and the directory's profile.`
+}
@@ -24,6 +24,7 @@ import (
 	utilsConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
 	buildinfocmd "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientConfig "github.com/jfrog/jfrog-client-go/config"
@@ -236,7 +237,7 @@ func saveIfNoError(errCheck error, conf *cisetup.CiSetupData) error {
 }
 
 func runIdePhase() error {
-	serverDetails, err := utilsConfig.GetSpecificConfig(cisetup.ConfigServerId, false, false)
+	serverDetails, err := secretstore.GetSpecificConfig(cisetup.ConfigServerId, false, false)
 	if err != nil {
 		return err
 	}
@@ -317,7 +318,7 @@ func runConfigCmd() (err error) {
 			continue
 		}
 		// Validate JFrog credentials by execute get repo command
-		serviceDetails, err := utilsConfig.GetSpecificConfig(cisetup.ConfigServerId, false, false)
+		serviceDetails, err := secretstore.GetSpecificConfig(cisetup.ConfigServerId, false, false)
 		if err != nil {
 			return err
 		}
@@ -424,7 +425,7 @@ func (cc *CiSetupCommand) saveCiConfigToFile(ciConfig []byte, fileName string) e
 }
 
 func (cc *CiSetupCommand) getPipelinesCompletionInstruction(pipelinesFileName string) ([]string, error) {
-	serviceDetails, err := utilsConfig.GetSpecificConfig(cisetup.ConfigServerId, false, false)
+	serviceDetails, err := secretstore.GetSpecificConfig(cisetup.ConfigServerId, false, false)
 	if err != nil {
 		return []string{}, err
 	}
@@ -528,7 +529,7 @@ func (cc *CiSetupCommand) publishFirstBuild() (err error) {
 		return err
 	}
 	// Run BP Command.
-	serviceDetails, err := utilsConfig.GetSpecificConfig(cisetup.ConfigServerId, false, false)
+	serviceDetails, err := secretstore.GetSpecificConfig(cisetup.ConfigServerId, false, false)
 	if err != nil {
 		return err
 	}
@@ -538,7 +539,7 @@ func (cc *CiSetupCommand) publishFirstBuild() (err error) {
 }
 
 func (cc *CiSetupCommand) xrayConfigPhase() (err error) {
-	serviceDetails, err := utilsConfig.GetSpecificConfig(cisetup.ConfigServerId, false, false)
+	serviceDetails, err := secretstore.GetSpecificConfig(cisetup.ConfigServerId, false, false)
 	if err != nil {
 		return err
 	}
@@ -672,7 +673,7 @@ func handleNewLocalRepository(serviceDetails *utilsConfig.ServerDetails, technol
 }
 
 func (cc *CiSetupCommand) interactivelyCreateRepos(technologyType coreutils.Technology) (err error) {
-	serviceDetails, err := utilsConfig.GetSpecificConfig(cisetup.ConfigServerId, false, false)
+	serviceDetails, err := secretstore.GetSpecificConfig(cisetup.ConfigServerId, false, false)
 	if err != nil {
 		return err
 	}
@@ -996,7 +997,7 @@ func (cc *CiSetupCommand) ciProviderPhase() (err error) {
 		}
 		if ciType == cisetup.Pipelines {
 			// validate that pipelines is available.
-			serviceDetails, err := utilsConfig.GetSpecificConfig(cisetup.ConfigServerId, false, false)
+			serviceDetails, err := secretstore.GetSpecificConfig(cisetup.ConfigServerId, false, false)
 			if err != nil {
 				log.Error(err)
 				continue
@@ -14,6 +14,8 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/missioncontrol/licensedeploy"
 	"github.com/jfrog/jfrog-cli/docs/missioncontrol/licenserelease"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/profile"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
@@ -219,8 +221,13 @@ func createMissionControlDetails(c *cli.Context) (*config.ServerDetails, error)
 		return details, nil
 	}
 
-	// Else, use details from config for requested serverId, or for default server if empty.
-	confDetails, err := coreCommonCommands.GetConfig(details.ServerId, true)
+	// Else, use details from config for requested serverId, for the server pinned by the directory's profile, or for default server if empty.
+	if details.ServerId == "" {
+		if details.ServerId, err = profile.GetProfileServerId(); err != nil {
+			return nil, err
+		}
+	}
+	confDetails, err := secretstore.GetSpecificConfig(details.ServerId, true, true)
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
@@ -112,7 +113,7 @@ func getServerDetails() (string, config.ServerDetails, error) {
 		return commandsUtils.PluginsOfficialRegistryUrl, config.ServerDetails{ArtifactoryUrl: commandsUtils.PluginsOfficialRegistryUrl}, nil
 	}
 
-	rtDetails, err := config.GetSpecificConfig(serverId, false, true)
+	rtDetails, err := secretstore.GetSpecificConfig(serverId, false, true)
 	if err != nil {
 		return "", config.ServerDetails{}, err
 	}
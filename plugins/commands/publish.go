@@ -12,6 +12,7 @@ import (
 	"github.com/jfrog/jfrog-cli/plugins/commands/utils"
 	pluginsutils "github.com/jfrog/jfrog-cli/plugins/utils"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -147,7 +148,7 @@ func getRtDetails(c *cli.Context) (*config.ServerDetails, error) {
 		return nil, cliutils.PrintHelpAndReturnError("the "+utils.PluginsServerEnv+" env var is mandatory for the 'publish' command", c)
 	}
 
-	confDetails, err := config.GetSpecificConfig(serverId, false, true)
+	confDetails, err := secretstore.GetSpecificConfig(serverId, false, true)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,148 @@
+package scan
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	audit "github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/generic"
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-cli/utils/xrayignore"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// Audits the project like the generic audit command of jfrog-cli-core, while applying the Xray ignore file to the results
+// before they are printed, and optionally saving the results as a baseline. Technologies which aren't supported by
+// jfrog-cli-core, such as Cargo, are audited by JFrog CLI.
+type auditCommand struct {
+	*audit.GenericAuditCommand
+	auditParams *audit.Params
+	// The requested technologies which are audited by JFrog CLI rather than by jfrog-cli-core.
+	cliTechnologies []coreutils.Technology
+	ignoreFile      *xrayignore.IgnoreFile
+	baselinePath    string
+	progress        ioUtils.ProgressMgr
+}
+
+func newAuditCommand() *auditCommand {
+	return &auditCommand{GenericAuditCommand: audit.NewGenericAuditCommand(), auditParams: audit.NewAuditParams()}
+}
+
+func (ac *auditCommand) SetTechnologies(technologies []string) *auditCommand {
+	var coreTechnologies []string
+	for _, technology := range technologies {
+		if getCliTechnology(coreutils.Technology(technology)) != nil {
+			ac.cliTechnologies = append(ac.cliTechnologies, coreutils.Technology(technology))
+		} else {
+			coreTechnologies = append(coreTechnologies, technology)
+		}
+	}
+	ac.auditParams.SetTechnologies(coreTechnologies...)
+	return ac
+}
+
+func (ac *auditCommand) SetIgnoreFile(ignoreFile *xrayignore.IgnoreFile) *auditCommand {
+	ac.ignoreFile = ignoreFile
+	return ac
+}
+
+// Sets the path of a file to save the findings of the audit to, as a baseline.
+func (ac *auditCommand) SetBaselinePath(baselinePath string) *auditCommand {
+	ac.baselinePath = baselinePath
+	return ac
+}
+
+func (ac *auditCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	ac.progress = progress
+}
+
+func (ac *auditCommand) Run() (err error) {
+	serverDetails, err := ac.ServerDetails()
+	if err != nil {
+		return
+	}
+	ac.auditParams.SetXrayGraphScanParams(ac.CreateXrayGraphScanParams()).
+		SetServerDetails(serverDetails).
+		SetProgressBar(ac.progress)
+	results, isMultipleRootProject, auditErr := ac.audit()
+
+	if ac.progress != nil {
+		if err = ac.progress.Quit(); err != nil {
+			return
+		}
+	}
+	// Print Scan results on all cases except if errors accrued on Generic Audit command and no security/license issues found.
+	if auditErr != nil && xrutils.IsEmptyScanResponse(results) {
+		return auditErr
+	}
+	now := time.Now()
+	if ac.baselinePath != "" {
+		baseline := xrayignore.NewBaseline(results, now)
+		if err = baseline.Write(ac.baselinePath); err != nil {
+			return
+		}
+		log.Info(fmt.Sprintf("The baseline of %d findings was saved to %s", len(baseline.Findings), ac.baselinePath))
+	}
+	results, suppressed := ac.ignoreFile.Filter(results, now)
+	err = xrutils.PrintScanResults(results,
+		nil,
+		ac.OutputFormat,
+		ac.IncludeVulnerabilities,
+		ac.IncludeLicenses,
+		isMultipleRootProject,
+		ac.PrintExtendedTable, false,
+	)
+	if err != nil {
+		return
+	}
+	if err = xrayignore.PrintSuppressedFindings(suppressed, ac.OutputFormat); err != nil {
+		return
+	}
+	// Only in case Xray's context was given (!IncludeVulnerabilities) and the user asked to fail the build accordingly, do so.
+	if auditErr == nil && ac.Fail && !ac.IncludeVulnerabilities && xrutils.CheckIfFailBuild(results) {
+		auditErr = xrutils.NewFailBuildError()
+	}
+	return ac.ignoreFile.ReportExpiredRules(auditErr, now)
+}
+
+// Audits the technologies of jfrog-cli-core by its generic audit, and the technologies of JFrog CLI.
+func (ac *auditCommand) audit() (results []services.ScanResponse, isMultipleRoot bool, err error) {
+	workingDirs, err := ac.absWorkingDirs()
+	if err != nil {
+		return
+	}
+	dirsTechnologies, auditCoreTechnologies, err := ac.technologiesToAudit(workingDirs)
+	if err != nil {
+		return
+	}
+	var errs []error
+	if auditCoreTechnologies {
+		results, isMultipleRoot, err = audit.GenericAudit(ac.auditParams)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	cliResults, cliMultipleRoot, err := ac.auditCliTechnologies(dirsTechnologies, workingDirs)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(cliResults) > 0 {
+		isMultipleRoot = isMultipleRoot || cliMultipleRoot || len(results) > 0
+		results = append(results, cliResults...)
+	}
+	return results, isMultipleRoot, errors.Join(errs...)
+}
+
+// Returns the arguments passed to npm when building the dependency tree, by the --dep-type option.
+func getNpmScopeArgs(depType string) []string {
+	switch depType {
+	case "devOnly":
+		return []string{"--dev"}
+	case "prodOnly":
+		return []string{"--prod"}
+	}
+	return nil
+}
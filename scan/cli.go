@@ -13,7 +13,6 @@ import (
 	corecommondocs "github.com/jfrog/jfrog-cli-core/v2/docs/common"
 	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
-	audit "github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/generic"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/scan"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	auditdocs "github.com/jfrog/jfrog-cli/docs/scan/audit"
@@ -26,6 +25,7 @@ import (
 	buildscandocs "github.com/jfrog/jfrog-cli/docs/scan/buildscan"
 	scandocs "github.com/jfrog/jfrog-cli/docs/scan/scan"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/xrayignore"
 	"github.com/urfave/cli"
 
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -179,6 +179,11 @@ func AuditCmd(c *cli.Context) error {
 			technologies = append(technologies, tech.ToString())
 		}
 	}
+	for _, cliTech := range cliTechnologies {
+		if c.Bool(cliTech.technology.ToString()) {
+			technologies = append(technologies, cliTech.technology.ToString())
+		}
+	}
 	auditCmd.SetTechnologies(technologies)
 	return progressbar.ExecWithProgress(auditCmd)
 }
@@ -194,8 +199,8 @@ func AuditSpecificCmd(c *cli.Context, technology coreutils.Technology) error {
 	return progressbar.ExecWithProgress(auditCmd)
 }
 
-func createGenericAuditCmd(c *cli.Context) (*audit.GenericAuditCommand, error) {
-	auditCmd := audit.NewGenericAuditCommand()
+func createGenericAuditCmd(c *cli.Context) (*auditCommand, error) {
+	auditCmd := newAuditCommand()
 	err := validateXrayContext(c)
 	if err != nil {
 		return nil, err
@@ -212,6 +217,10 @@ func createGenericAuditCmd(c *cli.Context) (*audit.GenericAuditCommand, error) {
 	if err != nil {
 		return nil, err
 	}
+	ignoreFile, err := xrayignore.FindIgnoreFile()
+	if err != nil {
+		return nil, err
+	}
 	auditCmd.SetServerDetails(serverDetails).
 		SetOutputFormat(format).
 		SetTargetRepoPath(addTrailingSlashToRepoPathIfNeeded(c)).
@@ -219,24 +228,24 @@ func createGenericAuditCmd(c *cli.Context) (*audit.GenericAuditCommand, error) {
 		SetIncludeVulnerabilities(shouldIncludeVulnerabilities(c)).
 		SetIncludeLicenses(c.Bool("licenses")).
 		SetFail(c.BoolT("fail")).
-		SetPrintExtendedTable(c.Bool(cliutils.ExtendedTable)).
-		SetMinSeverityFilter(minSeverity).
-		SetFixableOnly(c.Bool(cliutils.FixableOnly))
+		SetPrintExtendedTable(c.Bool(cliutils.ExtendedTable))
 
 	if c.String("watches") != "" {
 		auditCmd.SetWatches(splitAndTrim(c.String("watches"), ","))
 	}
 
 	if c.String("working-dirs") != "" {
-		auditCmd.SetWorkingDirs(splitAndTrim(c.String("working-dirs"), ","))
+		auditCmd.auditParams.SetWorkingDirs(splitAndTrim(c.String("working-dirs"), ","))
 	}
 
-	return auditCmd.SetExcludeTestDependencies(c.Bool(cliutils.ExcludeTestDeps)).
-			SetUseWrapper(c.BoolT(cliutils.UseWrapper)).
-			SetInsecureTls(c.Bool(cliutils.InsecureTls)).
-			SetNpmScope(c.String(cliutils.DepType)).
-			SetPipRequirementsFile(c.String(cliutils.RequirementsFile)),
-		err
+	auditCmd.auditParams.SetExcludeTestDeps(c.Bool(cliutils.ExcludeTestDeps)).
+		SetUseWrapper(c.BoolT(cliutils.UseWrapper)).
+		SetInsecureTLS(c.Bool(cliutils.InsecureTls)).
+		SetArgs(getNpmScopeArgs(c.String(cliutils.DepType))).
+		SetRequirementsFile(c.String(cliutils.RequirementsFile)).
+		SetMinSeverityFilter(minSeverity).
+		SetFixableOnly(c.Bool(cliutils.FixableOnly))
+	return auditCmd.SetIgnoreFile(ignoreFile).SetBaselinePath(c.String(cliutils.Baseline)), nil
 }
 
 func ScanCmd(c *cli.Context) error {
@@ -293,7 +302,20 @@ func ScanCmd(c *cli.Context) error {
 	if c.String("watches") != "" {
 		scanCmd.SetWatches(splitAndTrim(c.String("watches"), ","))
 	}
-	return commands.Exec(scanCmd)
+	ignoreFile, err := xrayignore.FindIgnoreFile()
+	if err != nil {
+		return err
+	}
+	if ignoreFile == nil {
+		return commands.Exec(scanCmd)
+	}
+	scanCmd.SetOutputFormat(xrutils.Json).SetFail(false)
+	return commands.Exec(newIgnoringScanCommand(scanCmd, ignoreFile).
+		SetOutputFormat(format).
+		SetIncludeVulnerabilities(shouldIncludeVulnerabilities(c)).
+		SetIncludeLicenses(c.Bool("licenses")).
+		SetPrintExtendedTable(c.Bool(cliutils.ExtendedTable)).
+		SetFail(c.BoolT("fail")))
 }
 
 // Scan published builds with Xray
@@ -325,11 +347,27 @@ func BuildScan(c *cli.Context) error {
 		SetOutputFormat(format).
 		SetPrintExtendedTable(c.Bool(cliutils.ExtendedTable)).
 		SetRescan(c.Bool("rescan"))
+	includeVulnerabilities := false
 	if format != xrutils.Sarif {
 		// Sarif shouldn't include the additional all-vulnerabilities info that received by adding the vuln flag
-		buildScanCmd.SetIncludeVulnerabilities(c.Bool("vuln"))
+		includeVulnerabilities = c.Bool("vuln")
+		buildScanCmd.SetIncludeVulnerabilities(includeVulnerabilities)
+	}
+	ignoreFile, err := xrayignore.FindIgnoreFile()
+	if err != nil {
+		return err
+	}
+	if ignoreFile == nil {
+		return commands.Exec(buildScanCmd)
 	}
-	return commands.Exec(buildScanCmd)
+	// The fail build error of the build scan command is needed, to determine whether Xray failed the build.
+	buildScanCmd.SetOutputFormat(xrutils.Json).SetFailBuild(true)
+	return commands.Exec(newIgnoringScanCommand(buildScanCmd, ignoreFile).
+		SetBuildScan(true).
+		SetOutputFormat(format).
+		SetIncludeVulnerabilities(includeVulnerabilities).
+		SetPrintExtendedTable(c.Bool(cliutils.ExtendedTable)).
+		SetFail(c.BoolT("fail")))
 }
 
 func DockerScan(c *cli.Context, image string) error {
@@ -371,7 +409,20 @@ func DockerScan(c *cli.Context, image string) error {
 	if c.String("watches") != "" {
 		containerScanCommand.SetWatches(splitAndTrim(c.String("watches"), ","))
 	}
-	return progressbar.ExecWithProgress(containerScanCommand)
+	ignoreFile, err := xrayignore.FindIgnoreFile()
+	if err != nil {
+		return err
+	}
+	if ignoreFile == nil {
+		return progressbar.ExecWithProgress(containerScanCommand)
+	}
+	containerScanCommand.SetOutputFormat(xrutils.Json).SetFail(false)
+	return progressbar.ExecWithProgress(newIgnoringScanCommand(containerScanCommand, ignoreFile).
+		SetOutputFormat(format).
+		SetIncludeVulnerabilities(shouldIncludeVulnerabilities(c)).
+		SetIncludeLicenses(c.Bool("licenses")).
+		SetPrintExtendedTable(c.Bool(cliutils.ExtendedTable)).
+		SetFail(c.BoolT("fail")))
 }
 
 func addTrailingSlashToRepoPathIfNeeded(c *cli.Context) string {
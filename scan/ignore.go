@@ -0,0 +1,171 @@
+package scan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-cli/utils/xrayignore"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// Runs a scan command of jfrog-cli-core, which is set to print its results in the JSON format, and collects the results
+// instead of printing them, so that the Xray ignore file is applied to the results before they are printed.
+type ignoringScanCommand struct {
+	scanCmd                commands.Command
+	ignoreFile             *xrayignore.IgnoreFile
+	outputFormat           xrutils.OutputFormat
+	includeVulnerabilities bool
+	includeLicenses        bool
+	printExtendedTable     bool
+	fail                   bool
+	// The results of a build scan are printed differently, and whether they fail the build is determined by Xray.
+	buildScan bool
+}
+
+func newIgnoringScanCommand(scanCmd commands.Command, ignoreFile *xrayignore.IgnoreFile) *ignoringScanCommand {
+	return &ignoringScanCommand{scanCmd: scanCmd, ignoreFile: ignoreFile}
+}
+
+func (isc *ignoringScanCommand) SetOutputFormat(format xrutils.OutputFormat) *ignoringScanCommand {
+	isc.outputFormat = format
+	return isc
+}
+
+func (isc *ignoringScanCommand) SetIncludeVulnerabilities(include bool) *ignoringScanCommand {
+	isc.includeVulnerabilities = include
+	return isc
+}
+
+func (isc *ignoringScanCommand) SetIncludeLicenses(include bool) *ignoringScanCommand {
+	isc.includeLicenses = include
+	return isc
+}
+
+func (isc *ignoringScanCommand) SetPrintExtendedTable(printExtendedTable bool) *ignoringScanCommand {
+	isc.printExtendedTable = printExtendedTable
+	return isc
+}
+
+func (isc *ignoringScanCommand) SetFail(fail bool) *ignoringScanCommand {
+	isc.fail = fail
+	return isc
+}
+
+func (isc *ignoringScanCommand) SetBuildScan(buildScan bool) *ignoringScanCommand {
+	isc.buildScan = buildScan
+	return isc
+}
+
+func (isc *ignoringScanCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	if scanCmd, ok := isc.scanCmd.(interface{ SetProgress(ioUtils.ProgressMgr) }); ok {
+		scanCmd.SetProgress(progress)
+	}
+}
+
+func (isc *ignoringScanCommand) ServerDetails() (*config.ServerDetails, error) {
+	return isc.scanCmd.ServerDetails()
+}
+
+func (isc *ignoringScanCommand) CommandName() string {
+	return isc.scanCmd.CommandName()
+}
+
+func (isc *ignoringScanCommand) Run() error {
+	results, scanErr := collectScanResults(isc.scanCmd)
+	// The fail build error is returned by the build scan command when Xray determines that the build should fail.
+	xrayFailBuild := isFailBuildError(scanErr)
+	if xrayFailBuild {
+		scanErr = nil
+	}
+	if scanErr != nil && len(results) == 0 {
+		return scanErr
+	}
+	now := time.Now()
+	filtered, suppressed := isc.ignoreFile.Filter(results, now)
+	if err := isc.printResults(results, filtered); err != nil {
+		return err
+	}
+	if err := xrayignore.PrintSuppressedFindings(suppressed, isc.outputFormat); err != nil {
+		return err
+	}
+	if isc.shouldFailBuild(filtered, xrayFailBuild) {
+		scanErr = xrutils.NewFailBuildError()
+	}
+	return isc.ignoreFile.ReportExpiredRules(scanErr, now)
+}
+
+func (isc *ignoringScanCommand) printResults(results, filtered []services.ScanResponse) error {
+	if !isc.buildScan {
+		return xrutils.PrintScanResults(filtered, nil, isc.outputFormat, isc.includeVulnerabilities, isc.includeLicenses, true, isc.printExtendedTable, true)
+	}
+	if isc.outputFormat != xrutils.Table {
+		// Print the violations and/or vulnerabilities as part of one JSON.
+		return xrutils.PrintScanResults(filtered, nil, isc.outputFormat, false, false, false, isc.printExtendedTable, true)
+	}
+	// Print two different tables for violations and vulnerabilities (if needed).
+	// If no violations were returned while including vulnerabilities, there's no Xray fail build policy for the build, so no need to print violations.
+	violations, _, _ := xrutils.SplitScanResults(results)
+	if !isc.includeVulnerabilities || len(violations) > 0 {
+		if err := xrutils.PrintScanResults(filtered, nil, isc.outputFormat, false, false, false, isc.printExtendedTable, true); err != nil {
+			return err
+		}
+	}
+	if isc.includeVulnerabilities {
+		return xrutils.PrintScanResults(filtered, nil, isc.outputFormat, true, false, false, isc.printExtendedTable, true)
+	}
+	return nil
+}
+
+func (isc *ignoringScanCommand) shouldFailBuild(filtered []services.ScanResponse, xrayFailBuild bool) bool {
+	if !isc.fail {
+		return false
+	}
+	if isc.buildScan {
+		// The build fails only if some of the violations which failed it weren't suppressed.
+		violations, _, _ := xrutils.SplitScanResults(filtered)
+		return xrayFailBuild && len(violations) > 0
+	}
+	// If includeVulnerabilities is false it means that context was provided, so we need to check for build violations.
+	return !isc.includeVulnerabilities && xrutils.CheckIfFailBuild(filtered)
+}
+
+func isFailBuildError(err error) bool {
+	var cliError coreutils.CliError
+	return errors.As(err, &cliError) && cliError.ExitCode == coreutils.ExitCodeVulnerableBuild
+}
+
+// Collects the output of the scan command, which is expected to be in the JSON format, instead of printing it.
+type outputCollector struct {
+	log.Log
+	output bytes.Buffer
+}
+
+func (oc *outputCollector) Output(a ...interface{}) {
+	fmt.Fprintln(&oc.output, a...)
+}
+
+func collectScanResults(scanCmd commands.Command) (results []services.ScanResponse, err error) {
+	// The logger is replaced after the progress bar was initialized, since the progress bar replaces the logger too.
+	logger := log.GetLogger()
+	collector := &outputCollector{Log: logger}
+	log.SetLogger(collector)
+	err = scanCmd.Run()
+	log.SetLogger(logger)
+	if collector.output.Len() == 0 {
+		return
+	}
+	if e := json.Unmarshal(collector.output.Bytes(), &results); e != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the scan results: %s", e.Error())
+	}
+	return
+}
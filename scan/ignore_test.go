@@ -0,0 +1,82 @@
+package scan
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-cli/utils/xrayignore"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Prints its results in the JSON format, like the scan commands of jfrog-cli-core.
+type fakeScanCommand struct {
+	results []services.ScanResponse
+	err     error
+}
+
+func (fsc *fakeScanCommand) Run() error {
+	content, err := json.Marshal(fsc.results)
+	if err != nil {
+		return err
+	}
+	log.Output(string(content))
+	return fsc.err
+}
+
+func (fsc *fakeScanCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (fsc *fakeScanCommand) CommandName() string {
+	return "fake_scan"
+}
+
+func createScanResults() []services.ScanResponse {
+	return []services.ScanResponse{{
+		Violations: []services.Violation{
+			{IssueId: "XRAY-1", Severity: "High", Components: map[string]services.Component{"npm://lodash:4.17.20": {}}, FailBuild: true},
+		},
+	}}
+}
+
+func TestCollectScanResults(t *testing.T) {
+	logger := log.GetLogger()
+	results, err := collectScanResults(&fakeScanCommand{results: createScanResults()})
+	assert.NoError(t, err)
+	assert.Equal(t, createScanResults(), results)
+	assert.Equal(t, logger, log.GetLogger())
+}
+
+func TestIgnoringScanCommand(t *testing.T) {
+	suppressing := &xrayignore.IgnoreFile{Rules: []xrayignore.Rule{{Component: "npm://lodash", Justification: "Accepted"}}}
+	tests := []struct {
+		name       string
+		ignoreFile *xrayignore.IgnoreFile
+		buildScan  bool
+		scanErr    error
+		failBuild  bool
+	}{
+		{name: "scan", ignoreFile: &xrayignore.IgnoreFile{}, failBuild: true},
+		{name: "scan with suppressed violation", ignoreFile: suppressing},
+		{name: "build scan failed by xray", ignoreFile: &xrayignore.IgnoreFile{}, buildScan: true, scanErr: xrutils.NewFailBuildError(), failBuild: true},
+		{name: "build scan with suppressed violation", ignoreFile: suppressing, buildScan: true, scanErr: xrutils.NewFailBuildError()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scanCmd := newIgnoringScanCommand(&fakeScanCommand{results: createScanResults(), err: test.scanErr}, test.ignoreFile).
+				SetOutputFormat(xrutils.SimpleJson).
+				SetBuildScan(test.buildScan).
+				SetFail(true)
+			err := scanCmd.Run()
+			if test.failBuild {
+				assert.True(t, isFailBuildError(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
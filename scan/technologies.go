@@ -0,0 +1,153 @@
+package scan
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrayaudit "github.com/jfrog/jfrog-cli-core/v2/xray/audit"
+	xraycommands "github.com/jfrog/jfrog-cli-core/v2/xray/commands"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/cargo"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/composer"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/helm"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// A technology which is audited by JFrog CLI, rather than by jfrog-cli-core.
+type cliTechnology struct {
+	technology coreutils.Technology
+	// The file which indicates that a directory includes a project of the technology.
+	descriptor string
+	// Builds the dependency trees of the project in the provided directory.
+	buildDependencyTree func(projectDir string) ([]*services.GraphNode, error)
+}
+
+var cliTechnologies = []cliTechnology{
+	{technology: cargo.Technology, descriptor: cargo.DescriptorFileName, buildDependencyTree: cargo.BuildDependencyTree},
+	{technology: composer.Technology, descriptor: composer.DescriptorFileName, buildDependencyTree: composer.BuildDependencyTree},
+	{technology: helm.Technology, descriptor: helm.DescriptorFileName, buildDependencyTree: helm.BuildDependencyTree},
+}
+
+// Returns the technology of JFrog CLI, or nil if the technology is audited by jfrog-cli-core.
+func getCliTechnology(technology coreutils.Technology) *cliTechnology {
+	for i := range cliTechnologies {
+		if cliTechnologies[i].technology == technology {
+			return &cliTechnologies[i]
+		}
+	}
+	return nil
+}
+
+// Returns the technologies of JFrog CLI to audit in each of the working directories, and whether jfrog-cli-core should
+// audit the working directories too. If no technologies were requested, the technologies are detected in the working directories,
+// and jfrog-cli-core audits them unless only technologies of JFrog CLI were detected.
+func (ac *auditCommand) technologiesToAudit(workingDirs []string) (dirsTechnologies map[string][]coreutils.Technology, auditCoreTechnologies bool, err error) {
+	dirsTechnologies = map[string][]coreutils.Technology{}
+	if len(ac.cliTechnologies) > 0 || len(ac.auditParams.Technologies()) > 0 {
+		if len(ac.cliTechnologies) > 0 {
+			for _, dir := range workingDirs {
+				dirsTechnologies[dir] = ac.cliTechnologies
+			}
+		}
+		return dirsTechnologies, len(ac.auditParams.Technologies()) > 0, nil
+	}
+	detectedCliTechnologies, detectedCoreTechnologies := false, false
+	for _, dir := range workingDirs {
+		for _, cliTech := range cliTechnologies {
+			var exists bool
+			if exists, err = fileutils.IsFileExists(filepath.Join(dir, cliTech.descriptor), false); err != nil {
+				return
+			}
+			if exists {
+				log.Info(fmt.Sprintf("Detected: %s in %s", cliTech.technology.ToFormal(), dir))
+				dirsTechnologies[dir] = append(dirsTechnologies[dir], cliTech.technology)
+				detectedCliTechnologies = true
+			}
+		}
+		var coreTechnologies map[coreutils.Technology]bool
+		if coreTechnologies, err = coreutils.DetectTechnologies(dir, false, false); err != nil {
+			return
+		}
+		detectedCoreTechnologies = detectedCoreTechnologies || len(coreTechnologies) > 0
+	}
+	return dirsTechnologies, detectedCoreTechnologies || !detectedCliTechnologies, nil
+}
+
+// Returns the absolute paths of the working directories to audit.
+func (ac *auditCommand) absWorkingDirs() ([]string, error) {
+	workingDirs := ac.auditParams.WorkingDirs()
+	if len(workingDirs) == 0 {
+		workingDirs = []string{"."}
+	}
+	absWorkingDirs := make([]string, len(workingDirs))
+	for i, dir := range workingDirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		absWorkingDirs[i] = absDir
+	}
+	return absWorkingDirs, nil
+}
+
+// Audits the technologies of JFrog CLI in the working directories.
+func (ac *auditCommand) auditCliTechnologies(dirsTechnologies map[string][]coreutils.Technology, workingDirs []string) (results []services.ScanResponse, isMultipleRoot bool, err error) {
+	if len(dirsTechnologies) == 0 {
+		return
+	}
+	xrayVersion := ac.auditParams.XrayVersion()
+	if xrayVersion == "" {
+		if _, xrayVersion, err = xraycommands.CreateXrayServiceManagerAndGetVersion(ac.auditParams.ServerDetails()); err != nil {
+			return
+		}
+		if err = coreutils.ValidateMinimumVersion(coreutils.Xray, xrayVersion, xraycommands.GraphScanMinXrayVersion); err != nil {
+			return
+		}
+	}
+	var errorList []string
+	for _, dir := range workingDirs {
+		for _, technology := range dirsTechnologies[dir] {
+			techResults, multipleRoot, e := ac.auditCliTechnology(technology, dir, xrayVersion)
+			if e != nil {
+				errorList = append(errorList, fmt.Sprintf("'%s' audit in %s failed:\n%s", technology, dir, e.Error()))
+				continue
+			}
+			results = append(results, techResults...)
+			isMultipleRoot = isMultipleRoot || multipleRoot
+		}
+	}
+	if len(errorList) > 0 {
+		err = errorutils.CheckErrorf(strings.Join(errorList, "\n"))
+	}
+	return
+}
+
+func (ac *auditCommand) auditCliTechnology(technology coreutils.Technology, dir, xrayVersion string) (results []services.ScanResponse, isMultipleRoot bool, err error) {
+	if ac.progress != nil {
+		ac.progress.SetHeadlineMsg(fmt.Sprintf("Calculating %v dependencies", technology.ToFormal()))
+	}
+	dependencyTrees, err := getCliTechnology(technology).buildDependencyTree(dir)
+	if err != nil {
+		return
+	}
+	// Flatten the graph to speed up the ScanGraph request.
+	flatTree, err := services.FlattenGraph(dependencyTrees)
+	if err != nil {
+		return
+	}
+	scanGraphParams := xraycommands.NewScanGraphParams().
+		SetServerDetails(ac.auditParams.ServerDetails()).
+		SetXrayGraphScanParams(ac.auditParams.XrayGraphScanParams()).
+		SetXrayVersion(xrayVersion).
+		SetFixableOnly(ac.auditParams.FixableOnly()).
+		SetSeverityLevel(ac.auditParams.MinSeverityFilter())
+	results, err = xrayaudit.Audit(flatTree, ac.progress, technology, scanGraphParams)
+	if err != nil {
+		return
+	}
+	return xrayaudit.BuildImpactPathsForScanResponse(results, dependencyTrees), len(dependencyTrees) > 1, nil
+}
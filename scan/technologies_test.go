@@ -0,0 +1,49 @@
+package scan
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/cargo"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/composer"
+	"github.com/jfrog/jfrog-cli/buildtools/commands/helm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTechnologiesToAuditRequested(t *testing.T) {
+	auditCmd := newAuditCommand().SetTechnologies([]string{"npm", "cargo"})
+	assert.Equal(t, []coreutils.Technology{cargo.Technology}, auditCmd.cliTechnologies)
+	assert.Equal(t, []string{"npm"}, auditCmd.auditParams.Technologies())
+
+	dirsTechnologies, auditCoreTechnologies, err := auditCmd.technologiesToAudit([]string{"a", "b"})
+	assert.NoError(t, err)
+	assert.True(t, auditCoreTechnologies)
+	assert.Equal(t, map[string][]coreutils.Technology{"a": {cargo.Technology}, "b": {cargo.Technology}}, dirsTechnologies)
+
+	auditCmd = newAuditCommand().SetTechnologies([]string{"npm"})
+	dirsTechnologies, auditCoreTechnologies, err = auditCmd.technologiesToAudit([]string{"a"})
+	assert.NoError(t, err)
+	assert.True(t, auditCoreTechnologies)
+	assert.Empty(t, dirsTechnologies)
+}
+
+func TestTechnologiesToAuditDetected(t *testing.T) {
+	cargoDir := filepath.Join("..", "testdata", "cargo", "cargoproject")
+	composerDir := filepath.Join("..", "testdata", "composer", "composerproject")
+	helmDir := filepath.Join("..", "testdata", "helm", "helmchart")
+	emptyDir := t.TempDir()
+	auditCmd := newAuditCommand()
+
+	// Only Cargo, Composer and Helm were detected, so jfrog-cli-core doesn't audit the projects.
+	dirsTechnologies, auditCoreTechnologies, err := auditCmd.technologiesToAudit([]string{cargoDir, composerDir, helmDir})
+	assert.NoError(t, err)
+	assert.False(t, auditCoreTechnologies)
+	assert.Equal(t, map[string][]coreutils.Technology{cargoDir: {cargo.Technology}, composerDir: {composer.Technology}, helmDir: {helm.Technology}}, dirsTechnologies)
+
+	// No technology was detected, so jfrog-cli-core reports it.
+	dirsTechnologies, auditCoreTechnologies, err = auditCmd.technologiesToAudit([]string{emptyDir})
+	assert.NoError(t, err)
+	assert.True(t, auditCoreTechnologies)
+	assert.Empty(t, dirsTechnologies)
+}
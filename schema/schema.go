@@ -0,0 +1,8 @@
+package schema
+
+import _ "embed"
+
+// The JSON schema of the File Specs, embedded so that specs can be validated offline.
+//
+//go:embed filespec-schema.json
+var FileSpecSchema []byte
@@ -0,0 +1,6 @@
+use regex::Regex;
+
+fn main() {
+    let re = Regex::new(r"^\d{4}-\d{2}-\d{2}$").unwrap();
+    println!("{}", re.is_match("2023-04-01"));
+}
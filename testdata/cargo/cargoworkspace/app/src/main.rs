@@ -0,0 +1,3 @@
+fn main() {
+    println!("{}", utils::roll(rand::random()));
+}
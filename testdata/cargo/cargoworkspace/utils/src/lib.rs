@@ -0,0 +1,3 @@
+pub fn roll(value: u8) -> u8 {
+    value % 6 + 1
+}
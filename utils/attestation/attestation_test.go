@@ -0,0 +1,139 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Writes the private key and its public key to PEM files, and returns their paths.
+func writeKeys(t *testing.T, key crypto.Signer, privateKeyType string) (privateKeyPath, publicKeyPath string) {
+	var privateDer []byte
+	var err error
+	switch privateKeyType {
+	case "EC PRIVATE KEY":
+		privateDer, err = x509.MarshalECPrivateKey(key.(*ecdsa.PrivateKey))
+	case "RSA PRIVATE KEY":
+		privateDer = x509.MarshalPKCS1PrivateKey(key.(*rsa.PrivateKey))
+	default:
+		privateDer, err = x509.MarshalPKCS8PrivateKey(key)
+	}
+	assert.NoError(t, err)
+	publicDer, err := x509.MarshalPKIXPublicKey(key.Public())
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	privateKeyPath, publicKeyPath = filepath.Join(dir, "key.pem"), filepath.Join(dir, "key.pub")
+	assert.NoError(t, os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: privateKeyType, Bytes: privateDer}), 0600))
+	assert.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer}), 0644))
+	return
+}
+
+func TestSignAndVerify(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	tests := []struct {
+		name           string
+		key            crypto.Signer
+		privateKeyType string
+	}{
+		{"ecdsa-pkcs8", ecdsaKey, "PRIVATE KEY"},
+		{"ecdsa-sec1", ecdsaKey, "EC PRIVATE KEY"},
+		{"ed25519", ed25519Key, "PRIVATE KEY"},
+		{"rsa-pkcs1", rsaKey, "RSA PRIVATE KEY"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			privateKeyPath, publicKeyPath := writeKeys(t, test.key, test.privateKeyType)
+			signer, err := NewSigner(privateKeyPath)
+			assert.NoError(t, err)
+			verifier, err := NewVerifier(publicKeyPath)
+			assert.NoError(t, err)
+			assert.Equal(t, verifier.KeyId(), signer.KeyId())
+
+			statement, err := NewStatement([]Subject{{Name: "app.jar", Digest: map[string]string{DigestSha256: "abc"}}}, "https://example.com/predicate", map[string]string{"key": "value"})
+			assert.NoError(t, err)
+			envelope, err := SignStatement(statement, signer)
+			assert.NoError(t, err)
+			assert.Equal(t, signer.KeyId(), envelope.Signatures[0].KeyId)
+			verified, err := VerifyStatement(envelope, verifier)
+			assert.NoError(t, err)
+			assert.Equal(t, statement, verified)
+
+			// A modified payload fails the verification.
+			envelope.Payload = base64.StdEncoding.EncodeToString([]byte(`{"_type":"https://in-toto.io/Statement/v1","subject":[]}`))
+			_, err = VerifyStatement(envelope, verifier)
+			assert.ErrorContains(t, err, "none of the 1 signatures")
+		})
+	}
+}
+
+func TestVerifyWithOtherKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	privateKeyPath, _ := writeKeys(t, key, "PRIVATE KEY")
+	_, otherPublicKeyPath := writeKeys(t, otherKey, "PRIVATE KEY")
+	signer, err := NewSigner(privateKeyPath)
+	assert.NoError(t, err)
+	verifier, err := NewVerifier(otherPublicKeyPath)
+	assert.NoError(t, err)
+	envelope, err := Sign(InTotoPayloadType, []byte("{}"), signer)
+	assert.NoError(t, err)
+	_, err = envelope.Verify(verifier)
+	assert.Error(t, err)
+}
+
+func TestExecSigner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test uses the base64 command.")
+	}
+	// The base64 command writes its input as the signature.
+	signer, err := NewSigner("exec:base64")
+	assert.NoError(t, err)
+	sig, err := signer.Sign([]byte("data to sign"))
+	assert.NoError(t, err)
+	assert.Equal(t, "data to sign", string(sig))
+
+	signer, err = NewSigner("exec:false")
+	assert.NoError(t, err)
+	_, err = signer.Sign([]byte("data to sign"))
+	assert.ErrorContains(t, err, "the signing command 'false' failed")
+
+	_, err = NewSigner("exec: ")
+	assert.ErrorContains(t, err, "the signing command is empty")
+}
+
+func TestNewSignerErrors(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	assert.NoError(t, os.WriteFile(keyPath, []byte("not a key"), 0600))
+	_, err := NewSigner(keyPath)
+	assert.ErrorContains(t, err, "isn't a PEM file")
+	assert.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("key")}), 0600))
+	_, err = NewSigner(keyPath)
+	assert.ErrorContains(t, err, "is encrypted")
+	_, err = NewVerifier(keyPath)
+	assert.ErrorContains(t, err, "isn't a PEM public key file")
+}
+
+func TestMatchDigests(t *testing.T) {
+	assert.True(t, MatchDigests(map[string]string{DigestSha1: "a", DigestSha256: "b"}, map[string]string{DigestSha1: "a"}))
+	assert.False(t, MatchDigests(map[string]string{DigestSha1: "a", DigestSha256: "b"}, map[string]string{DigestSha1: "a", DigestSha256: "c"}))
+	assert.False(t, MatchDigests(map[string]string{DigestSha256: "b"}, map[string]string{DigestSha1: "a"}))
+}
@@ -0,0 +1,69 @@
+package attestation
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// A DSSE envelope, as defined by https://github.com/secure-systems-lab/dsse/blob/master/envelope.md.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyId string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Returns the DSSE pre-authentication encoding of the payload, which is the data actually signed.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Signs the payload, and returns the DSSE envelope which includes the payload and its signature.
+func Sign(payloadType string, payload []byte, signer Signer) (*Envelope, error) {
+	sig, err := signer.Sign(pae(payloadType, payload))
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{KeyId: signer.KeyId(), Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}, nil
+}
+
+// Verifies that the envelope is signed by the verifier's key, and returns its payload.
+func (e *Envelope) Verify(verifier Verifier) ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("the payload of the signed envelope isn't valid base64: %s", err.Error())
+	}
+	data := pae(e.PayloadType, payload)
+	for _, signature := range e.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+		if verifier.Verify(data, sig) == nil {
+			return payload, nil
+		}
+	}
+	return nil, errorutils.CheckErrorf("none of the %d signatures of the envelope was created by the key %s", len(e.Signatures), verifier.KeyId())
+}
+
+func ParseEnvelope(content []byte) (*Envelope, error) {
+	envelope := &Envelope{}
+	if err := json.Unmarshal(content, envelope); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the signed envelope: %s", err.Error())
+	}
+	if len(envelope.Signatures) == 0 {
+		return nil, errorutils.CheckErrorf("the envelope isn't signed")
+	}
+	return envelope, nil
+}
@@ -0,0 +1,203 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Signing keys which start with this prefix are commands, which sign the data using an external signer, such as a KMS.
+const ExecSignerPrefix = "exec:"
+
+type Signer interface {
+	Sign(data []byte) ([]byte, error)
+	// Returns the ID of the key, or an empty string if it is unknown.
+	KeyId() string
+}
+
+type Verifier interface {
+	Verify(data, sig []byte) error
+	KeyId() string
+}
+
+// Creates a signer from a path of a PEM private key file, or from a signing command prefixed by 'exec:'.
+// ECDSA, Ed25519 and RSA keys are supported.
+func NewSigner(signKey string) (Signer, error) {
+	if command, isExec := strings.CutPrefix(signKey, ExecSignerPrefix); isExec {
+		if len(strings.Fields(command)) == 0 {
+			return nil, errorutils.CheckErrorf("the signing command is empty")
+		}
+		return &execSigner{command: command}, nil
+	}
+	content, err := os.ReadFile(signKey)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckErrorf("the signing key %s isn't a PEM file", signKey)
+	}
+	var key crypto.PrivateKey
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		return nil, errorutils.CheckErrorf("the signing key %s is encrypted. Decrypt it, or sign using an external command with the '%s' prefix", signKey, ExecSignerPrefix)
+	default:
+		return nil, errorutils.CheckErrorf("the signing key %s includes an unsupported PEM block: %s", signKey, block.Type)
+	}
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the signing key %s: %s", signKey, err.Error())
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errorutils.CheckErrorf("the type of the signing key %s isn't supported", signKey)
+	}
+	switch signer.(type) {
+	case *ecdsa.PrivateKey, ed25519.PrivateKey, *rsa.PrivateKey:
+	default:
+		return nil, errorutils.CheckErrorf("the type of the signing key %s isn't supported", signKey)
+	}
+	keyId, err := getKeyId(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &keySigner{key: signer, keyId: keyId}, nil
+}
+
+// Loads a verifier from a path of a PEM public key file.
+func NewVerifier(publicKeyPath string) (Verifier, error) {
+	content, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errorutils.CheckErrorf("the public key %s isn't a PEM public key file", publicKeyPath)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the public key %s: %s", publicKeyPath, err.Error())
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+	default:
+		return nil, errorutils.CheckErrorf("the type of the public key %s isn't supported", publicKeyPath)
+	}
+	keyId, err := getKeyId(key)
+	if err != nil {
+		return nil, err
+	}
+	return &keyVerifier{key: key, keyId: keyId}, nil
+}
+
+// The key ID is the SHA-256 of the DER encoded public key.
+func getKeyId(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+type keySigner struct {
+	key   crypto.Signer
+	keyId string
+}
+
+func (ks *keySigner) Sign(data []byte) ([]byte, error) {
+	var sig []byte
+	var err error
+	switch key := ks.key.(type) {
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(key, data)
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(data)
+		sig, err = ecdsa.SignASN1(rand.Reader, key, digest[:])
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256(data)
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	}
+	return sig, errorutils.CheckError(err)
+}
+
+func (ks *keySigner) KeyId() string {
+	return ks.keyId
+}
+
+type keyVerifier struct {
+	key   crypto.PublicKey
+	keyId string
+}
+
+// ECDSA and RSA signatures are expected to be created over the SHA-256 of the data. RSA signatures may use either PKCS #1 v1.5 or PSS.
+func (kv *keyVerifier) Verify(data, sig []byte) error {
+	digest := sha256.Sum256(data)
+	valid := false
+	switch key := kv.key.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, data, sig)
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(key, digest[:], sig)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil || rsa.VerifyPSS(key, crypto.SHA256, digest[:], sig, nil) == nil
+	}
+	if !valid {
+		return errorutils.CheckErrorf("invalid signature")
+	}
+	return nil
+}
+
+func (kv *keyVerifier) KeyId() string {
+	return kv.keyId
+}
+
+// Signs the data by running an external command, which receives the data in its standard input,
+// and writes the base64 encoded signature to its standard output.
+type execSigner struct {
+	command string
+}
+
+func (es *execSigner) Sign(data []byte) ([]byte, error) {
+	args := strings.Fields(es.command)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, errorutils.CheckErrorf("the signing command '%s' failed: %s", es.command, message)
+	}
+	// The base64 output may be wrapped into several lines.
+	sig, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(stdout.String()), ""))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("the output of the signing command '%s' isn't a base64 encoded signature: %s", es.command, err.Error())
+	}
+	return sig, nil
+}
+
+func (es *execSigner) KeyId() string {
+	return ""
+}
@@ -0,0 +1,95 @@
+package attestation
+
+import (
+	"encoding/json"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	StatementType     = "https://in-toto.io/Statement/v1"
+	InTotoPayloadType = "application/vnd.in-toto+json"
+	DigestSha1        = "sha1"
+	DigestSha256      = "sha256"
+	DigestMd5         = "md5"
+)
+
+// An in-toto statement, as defined by https://github.com/in-toto/attestation/blob/main/spec/v1/statement.md.
+type Statement struct {
+	Type          string          `json:"_type"`
+	Subject       []Subject       `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Creates the digest of a subject from the checksums of a build-info artifact or dependency.
+func NewDigest(checksum buildinfo.Checksum) map[string]string {
+	digest := map[string]string{}
+	for algorithm, value := range map[string]string{DigestSha1: checksum.Sha1, DigestSha256: checksum.Sha256, DigestMd5: checksum.Md5} {
+		if value != "" {
+			digest[algorithm] = value
+		}
+	}
+	return digest
+}
+
+// Returns true if the digests have at least one common algorithm, and the values of all their common algorithms are equal.
+func MatchDigests(digest, other map[string]string) bool {
+	common := 0
+	for algorithm, value := range digest {
+		if otherValue, exists := other[algorithm]; exists {
+			if value != otherValue {
+				return false
+			}
+			common++
+		}
+	}
+	return common > 0
+}
+
+// Creates a statement about the subjects, with the predicate marshaled to JSON.
+func NewStatement(subjects []Subject, predicateType string, predicate interface{}) (*Statement, error) {
+	content, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &Statement{Type: StatementType, Subject: subjects, PredicateType: predicateType, Predicate: content}, nil
+}
+
+func ParseStatement(content []byte) (*Statement, error) {
+	statement := &Statement{}
+	if err := json.Unmarshal(content, statement); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the in-toto statement: %s", err.Error())
+	}
+	if statement.Type != StatementType {
+		return nil, errorutils.CheckErrorf("unsupported in-toto statement type: '%s'", statement.Type)
+	}
+	return statement, nil
+}
+
+// Signs the statement, and returns the DSSE envelope.
+func SignStatement(statement *Statement, signer Signer) (*Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return Sign(InTotoPayloadType, payload, signer)
+}
+
+// Verifies the envelope, and returns the statement it includes.
+func VerifyStatement(envelope *Envelope, verifier Verifier) (*Statement, error) {
+	if envelope.PayloadType != InTotoPayloadType {
+		return nil, errorutils.CheckErrorf("the payload type of the envelope is '%s', while '%s' is expected", envelope.PayloadType, InTotoPayloadType)
+	}
+	payload, err := envelope.Verify(verifier)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStatement(payload)
+}
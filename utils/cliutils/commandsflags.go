@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/offlineupdate"
+	"github.com/jfrog/jfrog-cli/utils/throttling"
 	"sort"
 	"strconv"
 
@@ -33,6 +34,10 @@ const (
 	BuildAddDependencies   = "build-add-dependencies"
 	BuildAddGit            = "build-add-git"
 	BuildCollectEnv        = "build-collect-env"
+	BuildSbom              = "build-sbom"
+	BuildDiff              = "build-diff"
+	BuildShow              = "build-show"
+	BuildVerify            = "build-verify"
 	GitLfsClean            = "git-lfs-clean"
 	Mvn                    = "mvn"
 	MvnConfig              = "mvn-config"
@@ -69,21 +74,40 @@ const (
 	PipenvInstall          = "pipenv-install"
 	PoetryConfig           = "poetry-config"
 	Poetry                 = "poetry"
+	CargoConfig            = "cargo-config"
+	Cargo                  = "cargo"
+	ComposerConfig         = "composer-config"
+	Composer               = "composer"
+	ConanConfig            = "conan-config"
+	Conan                  = "conan"
+	HelmConfig             = "helm-config"
+	Helm                   = "helm"
 	Ping                   = "ping"
 	RtCurl                 = "rt-curl"
 	TemplateConsumer       = "template-consumer"
 	RepoDelete             = "repo-delete"
+	RepoApply              = "repo-apply"
+	TemplateExport         = "template-export"
 	ReplicationDelete      = "replication-delete"
 	PermissionTargetDelete = "permission-target-delete"
 	AccessTokenCreate      = "access-token-create"
+	AccessTokenList        = "access-token-list"
+	AccessTokenRevoke      = "access-token-revoke"
+	AccessTokenRefresh     = "access-token-refresh"
+	AccessTokenInspect     = "access-token-inspect"
 	UserCreate             = "user-create"
 	UsersCreate            = "users-create"
 	UsersDelete            = "users-delete"
+	UsersSync              = "users-sync"
 	GroupCreate            = "group-create"
 	GroupAddUsers          = "group-add-users"
 	GroupDelete            = "group-delete"
 	TransferConfig         = "transfer-config"
 	TransferConfigMerge    = "transfer-config-merge"
+	TransferJournal        = "transfer-journal"
+	RtSync                 = "rt-sync"
+	Diff                   = "diff"
+	SpecValidate           = "spec-validate"
 	passphrase             = "passphrase"
 
 	// Distribution's Command Keys
@@ -116,8 +140,12 @@ const (
 	OfflineUpdate = "offline-update"
 
 	// Config commands keys
-	AddConfig  = "config-add"
-	EditConfig = "config-edit"
+	AddConfig    = "config-add"
+	EditConfig   = "config-edit"
+	TestConfig   = "config-test"
+	ExportConfig = "config-export"
+	ImportConfig = "config-import"
+	WhichConfig  = "config-which"
 
 	// Project commands keys
 	InitProject = "project-init"
@@ -139,6 +167,14 @@ const (
 	passwordStdin    = "password-stdin"
 	accessTokenStdin = "access-token-stdin"
 
+	// Unique config export and import flags
+	bundleAll             = "all"
+	bundleOut             = "out"
+	bundlePassphraseStdin = "passphrase-stdin"
+	bundleProjects        = "bundle-include-projects"
+	bundleMerge           = "merge"
+	bundleReplace         = "bundle-replace"
+
 	// Ssh flags
 	sshKeyPath    = "ssh-key-path"
 	sshPassphrase = "ssh-passphrase"
@@ -188,6 +224,10 @@ const (
 	publicGpgKey            = "gpg-key"
 	archiveEntries          = "archive-entries"
 	detailedSummary         = "detailed-summary"
+	summaryFile             = "summary-file"
+	resume                  = "resume"
+	maxBandwidth            = "max-bandwidth"
+	maxRequestsPerSecond    = "max-requests-per-second"
 	archive                 = "archive"
 	syncDeletesQuiet        = syncDeletes + "-" + quiet
 	antFlag                 = "ant"
@@ -200,6 +240,8 @@ const (
 	EncPassword   = "enc-password"
 	BasicAuthOnly = "basic-auth-only"
 	Overwrite     = "overwrite"
+	SecretStore   = "secret-store"
+	SecretHelper  = "secret-helper"
 
 	// Unique upload flags
 	uploadPrefix      = "upload-"
@@ -215,6 +257,35 @@ const (
 	symlinks          = "symlinks"
 	uploadAnt         = uploadPrefix + antFlag
 
+	// Unique sync flags
+	syncDirection = "direction"
+	syncConflict  = "conflict"
+
+	// Unique diff flags
+	diffPrefix  = "diff-"
+	diffFormat  = diffPrefix + "format"
+	sourceBuild = "source-build"
+	targetBuild = "target-build"
+	ignoreProps = "ignore-props"
+	failOnDiff  = "fail-on-diff"
+
+	// Unique repo-apply flags
+	approve = "approve"
+	prune   = "prune"
+
+	// Unique template export flags
+	exportVars = "export-vars"
+
+	// Unique spec-validate flags
+	specValidateFor = "for"
+
+	// Unique users-sync flags
+	usersSyncFile     = "file"
+	deactivateMissing = "deactivate-missing"
+	reportFile        = "report-file"
+	usersSyncQuiet    = "users-sync-quiet"
+	usersSyncDryRun   = "users-sync-dry-run"
+
 	// Unique download flags
 	downloadPrefix       = "download-"
 	downloadRecursive    = downloadPrefix + recursive
@@ -256,6 +327,8 @@ const (
 	searchExcludeProps = searchPrefix + excludeProps
 	count              = "count"
 	searchTransitive   = searchPrefix + transitive
+	searchFormat       = searchPrefix + "format"
+	fields             = "fields"
 
 	// Unique properties flags
 	propertiesPrefix  = "props-"
@@ -267,6 +340,9 @@ const (
 	buildPublishPrefix = "bp-"
 	bpDryRun           = buildPublishPrefix + dryRun
 	bpDetailedSummary  = buildPublishPrefix + detailedSummary
+	signKey            = "sign-key"
+	provenance         = "provenance"
+	provenanceRepo     = "provenance-repo"
 	envInclude         = "env-include"
 	envExclude         = "env-exclude"
 	buildUrl           = "build-url"
@@ -279,6 +355,22 @@ const (
 	badRegexp    = badPrefix + regexpFlag
 	badFromRt    = badPrefix + fromRt
 
+	// Unique build-sbom flags
+	sbomPrefix = "sbom-"
+	sbomFormat = sbomPrefix + "format"
+	sbomLocal  = "local"
+	out        = "out"
+	sbomOut    = sbomPrefix + out
+
+	// Unique build-verify flags
+	pubKey = "pub-key"
+
+	// Unique build-show flags
+	showPrefix   = "show-"
+	showModule   = showPrefix + module
+	showFormat   = showPrefix + "format"
+	showValidate = showPrefix + "validate"
+
 	// Unique build-add-git flags
 	configFlag = "config"
 
@@ -295,6 +387,7 @@ const (
 	includeDependencies = "include-dependencies"
 	copyFlag            = "copy"
 	failFast            = "fail-fast"
+	requireSignature    = "require-signature"
 
 	async = "async"
 
@@ -389,6 +482,13 @@ const (
 	refreshable = "refreshable"
 	audience    = "audience"
 
+	// Unique access token output flags, used by the access-token-create and access-token-refresh commands
+	outputEnv      = "output-env"
+	outputServerId = "output-server-id"
+
+	// Unique access-token-revoke flags
+	revokeQuiet = "revoke-quiet"
+
 	// Unique Xray Flags for upload/publish commands
 	xrayScan = "scan"
 
@@ -456,6 +556,7 @@ const (
 	ExtendedTable    = "extended-table"
 	MinSeverity      = "min-severity"
 	FixableOnly      = "fixable-only"
+	Baseline         = "baseline"
 	// *** Mission Control Commands' flags ***
 	missionControlPrefix = "mc-"
 
@@ -501,6 +602,9 @@ const (
 	Stop                = "stop"
 	PreChecks           = "prechecks"
 
+	transferMaxBandwidth         = transferFilesPrefix + maxBandwidth
+	transferMaxRequestsPerSecond = transferFilesPrefix + maxRequestsPerSecond
+
 	// Transfer flags
 	IncludeRepos    = "include-repos"
 	ExcludeRepos    = "exclude-repos"
@@ -550,6 +654,30 @@ var flagsMap = map[string]cli.Flag{
 		Name:  passwordStdin,
 		Usage: "[Default: false] Set to true if you'd like to provide the password via stdin.` `",
 	},
+	bundleAll: cli.BoolFlag{
+		Name:  bundleAll,
+		Usage: "[Default: false] Set to true to export all the configured servers to an encrypted bundle file. Requires the --out option.` `",
+	},
+	bundleOut: cli.StringFlag{
+		Name:  bundleOut,
+		Usage: "[Optional] Path of the encrypted bundle file to create. If set, the configuration is exported to a bundle file, instead of a Config Token.` `",
+	},
+	bundlePassphraseStdin: cli.BoolFlag{
+		Name:  bundlePassphraseStdin,
+		Usage: "[Default: false] Set to true if you'd like to provide the passphrase of the bundle file via stdin. If not set, the passphrase is prompted.` `",
+	},
+	bundleProjects: cli.BoolFlag{
+		Name:  IncludeProjects,
+		Usage: "[Default: false] Set to true to include the global project configurations from the JFrog CLI home directory in the bundle file.` `",
+	},
+	bundleMerge: cli.BoolFlag{
+		Name:  bundleMerge,
+		Usage: "[Default: false] Set to true to add the bundle's configurations to the existing configuration. Existing configurations which differ from the bundle's are kept, and reported as conflicts. The configurations are merged in this way unless --replace is set.` `",
+	},
+	bundleReplace: cli.BoolFlag{
+		Name:  Replace,
+		Usage: "[Default: false] Set to true to replace the existing configuration with the bundle's configurations.` `",
+	},
 	accessTokenStdin: cli.BoolFlag{
 		Name:  accessTokenStdin,
 		Usage: "[Default: false] Set to true if you'd like to provide the access token via stdin.` `",
@@ -672,6 +800,86 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to include a list of the affected files in the command summary.` `",
 	},
+	summaryFile: cli.StringFlag{
+		Name:  summaryFile,
+		Usage: "[Optional] Path to a file to which a report of the command summary should be written. The report is written as JUnit XML if the file extension is .xml, and as JSON otherwise.` `",
+	},
+	maxBandwidth: cli.StringFlag{
+		Name:  maxBandwidth,
+		Usage: "[Optional] The maximum total bandwidth of the file transfers, shared by all the threads. For example, 20MB/s. Supported units are B, KB, MB and GB, where 1KB is 1024 bytes. Can also be set by the " + throttling.MaxBandwidthEnv + " environment variable.` `",
+	},
+	maxRequestsPerSecond: cli.StringFlag{
+		Name:  maxRequestsPerSecond,
+		Usage: "[Optional] The maximum number of file transfer requests per second, shared by all the threads. Every part of a split download and every checksum deploy is counted as a request. Can also be set by the " + throttling.MaxRequestsPerSecondEnv + " environment variable.` `",
+	},
+	syncDirection: cli.StringFlag{
+		Name:  syncDirection,
+		Usage: "[Default: push] The direction of the sync. 'push' mirrors the local directory to Artifactory, 'pull' mirrors the Artifactory path to the local directory, and 'both' copies the missing files to each side without deleting files.` `",
+	},
+	syncConflict: cli.StringFlag{
+		Name:  syncConflict,
+		Usage: "[Default: fail] Determines how files which were modified both locally and in Artifactory are handled, when the direction is 'both'. Can be one of: fail, local, remote, newer and skip.` `",
+	},
+	diffFormat: cli.StringFlag{
+		Name:  xrOutput,
+		Usage: "[Default: table] Defines the output format of the command. Acceptable values are: table and json.` `",
+	},
+	sourceBuild: cli.StringFlag{
+		Name:  sourceBuild,
+		Usage: "[Optional] If specified, only artifacts of the specified build are compared on the source side. The format is build-name/build-number. If the build number isn't provided, the latest build number is used.` `",
+	},
+	targetBuild: cli.StringFlag{
+		Name:  targetBuild,
+		Usage: "[Optional] If specified, only artifacts of the specified build are compared on the target side. The format is build-name/build-number. If the build number isn't provided, the latest build number is used.` `",
+	},
+	ignoreProps: cli.StringFlag{
+		Name:  ignoreProps,
+		Usage: "[Optional] A list of comma-separated property keys, which should be ignored when comparing the properties of the files. For example, build.number,build.timestamp.` `",
+	},
+	failOnDiff: cli.BoolFlag{
+		Name:  failOnDiff,
+		Usage: "[Default: false] Set to true if you'd like the command to fail when differences are found.` `",
+	},
+	approve: cli.BoolFlag{
+		Name:  approve,
+		Usage: "[Default: false] Set to true to apply the plan. If false, the plan is only displayed.` `",
+	},
+	prune: cli.StringFlag{
+		Name:  prune,
+		Usage: "[Optional] A repository key pattern, which may include wildcards. Existing repositories matching the pattern, which aren't defined by the templates, are deleted, including all of their content.` `",
+	},
+	exportVars: cli.StringFlag{
+		Name:  vars,
+		Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be extracted from the exported templates. Each occurrence of a variable's value is replaced by ${key1}, so that the templates can be reused with different values.` `",
+	},
+	usersSyncFile: cli.StringFlag{
+		Name:  usersSyncFile,
+		Usage: "[Mandatory] Path to a CSV or YAML file with the users' details. The format is determined by the file extension (.csv, .yaml or .yml).` `",
+	},
+	deactivateMissing: cli.BoolFlag{
+		Name:  deactivateMissing,
+		Usage: "[Default: false] Set to true to deactivate the internal users which are missing from the file. A deactivated user is removed from all of its groups, and its password and UI access are disabled.` `",
+	},
+	reportFile: cli.StringFlag{
+		Name:  reportFile,
+		Usage: "[Optional] Path to a file, to which a JSON report of the results is written.` `",
+	},
+	usersSyncDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to only print the plan, without applying it.` `",
+	},
+	usersSyncQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the confirmation message before applying the plan.` `",
+	},
+	specValidateFor: cli.StringFlag{
+		Name:  specValidateFor,
+		Usage: "[Optional] The command which the spec is intended for. If provided, the spec is also validated against the rules of the command. Acceptable values are: upload, download, copy, move, delete, search, set-props and release-bundle.` `",
+	},
+	resume: cli.BoolFlag{
+		Name:  resume,
+		Usage: "[Default: false] Set to true to keep a journal of the transferred files, and to skip the files which were verified by a previous run of the same command. The journal is removed once the command completes successfully.` `",
+	},
 	interactive: cli.BoolTFlag{
 		Name:  interactive,
 		Usage: "[Default: true, unless $CI is true] Set to false if you do not want the config command to be interactive. If true, the --url option becomes optional.` `",
@@ -684,6 +892,15 @@ var flagsMap = map[string]cli.Flag{
 		Name:  Overwrite,
 		Usage: "[Default: false] Overwrites the instance configuration if an instance with the same ID already exists.` `",
 	},
+	SecretStore: cli.StringFlag{
+		Name: SecretStore,
+		Usage: "[Default: file] The store in which the password and access token are kept. Acceptable values are: file (the JFrog CLI config file), keyring (the OS keychain) and exec (a credential helper, which provides the secrets at runtime). " +
+			"The keyring and exec stores can only be used along with --interactive=false and a server ID. The keyring store is supported on macOS and Linux only, and isn't supported on Windows.` `",
+	},
+	SecretHelper: cli.StringFlag{
+		Name:  SecretHelper,
+		Usage: "[Optional] The credential helper command, used by the exec secret store. The command is run with get, store or erase as its last argument.` `",
+	},
 	BasicAuthOnly: cli.BoolFlag{
 		Name: BasicAuthOnly,
 		Usage: "[Default: false] Set to true to disable replacing username and password/API key with automatically created access token that's refreshed hourly. " +
@@ -852,6 +1069,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  transitive,
 		Usage: "[Default: false] Set to true to look for artifacts also in remote repositories. The search will run on the first five remote repositories within the virtual repository. Available on Artifactory version 7.17.0 or higher.` `",
 	},
+	searchFormat: cli.StringFlag{
+		Name:  xrOutput,
+		Usage: "[Default: json] Defines the output format of the command. Acceptable values are: json, table and csv.` `",
+	},
+	fields: cli.StringFlag{
+		Name:  fields,
+		Usage: "[Optional] A list of comma-separated fields to include in the output. Supported fields are: path, type, size, created, modified, sha1, sha256, md5, props and props.<property key>.` `",
+	},
 	propsRecursive: cli.BoolTFlag{
 		Name:  recursive,
 		Usage: "[Default: true] When false, artifacts inside sub-folders in Artifactory will not be affected.` `",
@@ -880,6 +1105,46 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to get a command summary with details about the build info artifact.` `",
 	},
+	signKey: cli.StringFlag{
+		Name:  signKey,
+		Usage: "[Optional] Path to a PEM private key file, used to sign the build info. ECDSA, Ed25519 and RSA keys are supported. To sign using an external signer, such as a KMS, provide a command prefixed by 'exec:'. The command receives the data to sign in its standard input, and should write the base64 encoded signature to its standard output. The build info isn't signed when --dry-run is set.` `",
+	},
+	provenance: cli.BoolFlag{
+		Name:  provenance,
+		Usage: "[Default: false] Set to true to create a SLSA provenance attestation for the build artifacts, upload it to Artifactory and add it to the build info. If --sign-key is set, the provenance is signed as well.` `",
+	},
+	provenanceRepo: cli.StringFlag{
+		Name:  provenanceRepo,
+		Usage: "[Optional] The repository to upload the provenance to. If not set, the provenance is uploaded to the repository of the build artifacts.` `",
+	},
+	pubKey: cli.StringFlag{
+		Name:  pubKey,
+		Usage: "[Optional] Path to a PEM public key file, used to verify the signature of the build info. Required by the build-verify command, and by the build-promote command when --require-signature is set.` `",
+	},
+	sbomFormat: cli.StringFlag{
+		Name:  xrOutput,
+		Usage: "[Default: cyclonedx-json] Defines the format of the SBOM. Acceptable values are: cyclonedx-json and spdx-json.` `",
+	},
+	sbomLocal: cli.BoolFlag{
+		Name:  sbomLocal,
+		Usage: "[Default: false] Set to true to create the SBOM from the local build-info, collected by the build-collect-env, build-add-dependencies and build tools commands, instead of the build-info published to Artifactory.` `",
+	},
+	sbomOut: cli.StringFlag{
+		Name:  out,
+		Usage: "[Optional] Path of the SBOM file to create. If not set, the SBOM is written to the standard output.` `",
+	},
+	showModule: cli.StringFlag{
+		Name:  module,
+		Usage: "[Optional] If specified, only the module with the provided ID is shown.` `",
+	},
+	showFormat: cli.StringFlag{
+		Name:  xrOutput,
+		Usage: "[Default: tree] Defines the output format of the command. Acceptable values are: tree, table and json.` `",
+	},
+	showValidate: cli.BoolFlag{
+		Name:  Validate,
+		Usage: "[Default: false] Set to true to validate the build-info instead of showing it. The validation detects empty modules, duplicate artifacts and dependencies, and missing checksums.` `",
+	},
 	envInclude: cli.StringFlag{
 		Name:  envInclude,
 		Usage: "[Default: *] List of patterns in the form of \"value1;value2;...\" Only environment variables match those patterns will be included.` `",
@@ -944,6 +1209,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  props,
 		Usage: "[Optional] List of properties in the form of \"key1=value1;key2=value2,...\". A list of properties to attach to the build artifacts.` `",
 	},
+	requireSignature: cli.BoolFlag{
+		Name:  requireSignature,
+		Usage: "[Default: false] Set to true to refuse promoting builds whose build info isn't signed by the key provided by --pub-key, or was modified after it was signed.` `",
+	},
 	targetDockerImage: cli.StringFlag{
 		Name:  "target-docker-image",
 		Usage: "[Optional] Docker target image name.` `",
@@ -1119,6 +1388,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  audience,
 		Usage: "[Optional] A space-separate list of the other Artifactory instances or services that should accept this token identified by their Artifactory Service IDs, as obtained by the 'jfrog rt curl api/system/service_id' command.` `",
 	},
+	outputEnv: cli.StringFlag{
+		Name:  outputEnv,
+		Usage: "[Optional] Path to a dotenv file, to which the token is written as JF_ACCESS_TOKEN, instead of being printed. The file is created if it doesn't exist. JF_URL and JF_REFRESH_TOKEN are also written, if available.` `",
+	},
+	outputServerId: cli.StringFlag{
+		Name:  outputServerId,
+		Usage: "[Optional] A server ID, for which a server configuration is created with the URLs of the current server and the token, instead of printing the token. If the server ID is already configured, its configuration is replaced.` `",
+	},
+	revokeQuiet: cli.BoolFlag{
+		Name:  quiet,
+		Usage: "[Default: $CI] Set to true to skip the revoke confirmation message.` `",
+	},
 	usersCreateCsv: cli.StringFlag{
 		Name:  csv,
 		Usage: "[Mandatory] Path to a csv file with the users' details. The first row of the file is reserved for the cells' headers. It must include \"username\",\"password\",\"email\"` `",
@@ -1292,6 +1573,10 @@ var flagsMap = map[string]cli.Flag{
 		Name:  MinSeverity,
 		Usage: "[Optional] Set the minimum severity of issues to display. The following values are accepted: Low, Medium, High or Critical. ` `",
 	},
+	Baseline: cli.StringFlag{
+		Name:  Baseline,
+		Usage: "[Optional] Path to a file, to save the current findings to as a baseline. When the baseline is referenced by the .jfrog/xray-ignore.yaml file, only findings which aren't included in it are reported.` `",
+	},
 	watches: cli.StringFlag{
 		Name:  watches,
 		Usage: "[Optional] A comma separated list of Xray watches, to determine Xray's violations creation. ` `",
@@ -1372,6 +1657,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  Poetry,
 		Usage: "[Default: false] Set to true to request audit for a Poetry project.` `",
 	},
+	Cargo: cli.BoolFlag{
+		Name:  Cargo,
+		Usage: "[Default: false] Set to true to request audit for a Cargo project.` `",
+	},
+	Composer: cli.BoolFlag{
+		Name:  Composer,
+		Usage: "[Default: false] Set to true to request audit for a Composer project.` `",
+	},
+	Helm: cli.BoolFlag{
+		Name:  Helm,
+		Usage: "[Default: false] Set to true to request audit for a Helm chart.` `",
+	},
 	Go: cli.BoolFlag{
 		Name:  Go,
 		Usage: "[Default: false] Set to true to request audit for a Go project.` `",
@@ -1533,26 +1830,46 @@ var flagsMap = map[string]cli.Flag{
 		Name:  PreChecks,
 		Usage: "[Default: false] Set to true to run pre transfer checks.` `",
 	},
+	transferMaxBandwidth: cli.StringFlag{
+		Name:  maxBandwidth,
+		Usage: "[Optional] The maximum total bandwidth of the files the source Artifactory is requested to upload, for example 20MB/s. Only the submission of the upload chunks to the data transfer plugin is delayed, so the actual bandwidth of the plugin isn't capped and may exceed the limit in bursts. Can also be set by the " + throttling.MaxBandwidthEnv + " environment variable.` `",
+	},
+	transferMaxRequestsPerSecond: cli.StringFlag{
+		Name:  maxRequestsPerSecond,
+		Usage: "[Optional] The maximum number of files per second the source Artifactory is requested to upload. Only the submission of the upload chunks to the data transfer plugin is delayed, so the plugin may transfer more files per second in bursts. Can also be set by the " + throttling.MaxRequestsPerSecondEnv + " environment variable.` `",
+	},
 }
 
 var commandFlags = map[string][]string{
 	AddConfig: {
 		interactive, EncPassword, configPlatformUrl, configRtUrl, configDistUrl, configXrUrl, configMcUrl, configPlUrl, configUser, configPassword, configAccessToken, sshKeyPath, sshPassphrase, ClientCertPath,
-		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, Overwrite, passwordStdin, accessTokenStdin,
+		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, Overwrite, passwordStdin, accessTokenStdin, SecretStore, SecretHelper,
 	},
 	EditConfig: {
 		interactive, EncPassword, configPlatformUrl, configRtUrl, configDistUrl, configXrUrl, configMcUrl, configPlUrl, configUser, configPassword, configAccessToken, sshKeyPath, sshPassphrase, ClientCertPath,
-		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, passwordStdin, accessTokenStdin,
+		ClientCertKeyPath, BasicAuthOnly, configInsecureTls, passwordStdin, accessTokenStdin, SecretStore, SecretHelper,
 	},
 	DeleteConfig: {
 		deleteQuiet,
 	},
+	TestConfig: {
+		diffFormat,
+	},
+	ExportConfig: {
+		bundleAll, bundleOut, bundlePassphraseStdin, bundleProjects,
+	},
+	ImportConfig: {
+		bundleMerge, bundleReplace, bundlePassphraseStdin,
+	},
+	WhichConfig: {
+		serverId,
+	},
 	Upload: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath, uploadTargetProps,
 		ClientCertKeyPath, specFlag, specVars, buildName, buildNumber, module, uploadExclusions, deb,
 		uploadRecursive, uploadFlat, uploadRegexp, retries, retryWaitTime, dryRun, uploadExplode, symlinks, includeDirs,
 		failNoOp, threads, uploadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, project,
-		uploadAnt, uploadArchive,
+		uploadAnt, uploadArchive, summaryFile, resume, maxBandwidth, maxRequestsPerSecond,
 	},
 	Download: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
@@ -1560,31 +1877,31 @@ var commandFlags = map[string][]string{
 		sortOrder, limit, offset, downloadRecursive, downloadFlat, build, includeDeps, excludeArtifacts, minSplit, splitCount,
 		retries, retryWaitTime, dryRun, downloadExplode, bypassArchiveInspection, validateSymlinks, bundle, publicGpgKey, includeDirs,
 		downloadProps, downloadExcludeProps, failNoOp, threads, archiveEntries, downloadSyncDeletes, syncDeletesQuiet, InsecureTls, detailedSummary, project,
-		skipChecksum,
+		skipChecksum, summaryFile, resume, maxBandwidth, maxRequestsPerSecond,
 	},
 	Move: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset, moveRecursive,
 		moveFlat, dryRun, build, includeDeps, excludeArtifacts, moveProps, moveExcludeProps, failNoOp, threads, archiveEntries,
-		InsecureTls, retries, retryWaitTime, project,
+		InsecureTls, retries, retryWaitTime, project, summaryFile,
 	},
 	Copy: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset, copyRecursive,
 		copyFlat, dryRun, build, includeDeps, excludeArtifacts, bundle, copyProps, copyExcludeProps, failNoOp, threads,
-		archiveEntries, InsecureTls, retries, retryWaitTime, project,
+		archiveEntries, InsecureTls, retries, retryWaitTime, project, summaryFile,
 	},
 	Delete: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		deleteRecursive, dryRun, build, includeDeps, excludeArtifacts, deleteQuiet, deleteProps, deleteExcludeProps, failNoOp, threads, archiveEntries,
-		InsecureTls, retries, retryWaitTime, project,
+		InsecureTls, retries, retryWaitTime, project, summaryFile,
 	},
 	Search: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, specFlag, specVars, exclusions, sortBy, sortOrder, limit, offset,
 		searchRecursive, build, includeDeps, excludeArtifacts, count, bundle, includeDirs, searchProps, searchExcludeProps, failNoOp, archiveEntries,
-		InsecureTls, searchTransitive, retries, retryWaitTime, project,
+		InsecureTls, searchTransitive, retries, retryWaitTime, project, searchFormat, fields,
 	},
 	Properties: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
@@ -1594,7 +1911,19 @@ var commandFlags = map[string][]string{
 	},
 	BuildPublish: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
-		envInclude, envExclude, InsecureTls, project, bpDetailedSummary,
+		envInclude, envExclude, InsecureTls, project, bpDetailedSummary, signKey, provenance, provenanceRepo,
+	},
+	BuildSbom: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls, project, sbomFormat, sbomLocal, sbomOut,
+	},
+	BuildDiff: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls, project, diffFormat,
+	},
+	BuildShow: {
+		project, showModule, showFormat, showValidate,
+	},
+	BuildVerify: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, InsecureTls, project, pubKey,
 	},
 	BuildAppend: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, buildUrl, bpDryRun,
@@ -1622,7 +1951,7 @@ var commandFlags = map[string][]string{
 	},
 	BuildPromote: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, Status, comment,
-		sourceRepo, includeDependencies, copyFlag, failFast, bprDryRun, bprProps, InsecureTls, project,
+		sourceRepo, includeDependencies, copyFlag, failFast, bprDryRun, bprProps, InsecureTls, project, requireSignature, pubKey,
 	},
 	BuildDiscard: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, maxDays, maxBuilds,
@@ -1651,7 +1980,7 @@ var commandFlags = map[string][]string{
 	},
 	DockerPush: {
 		buildName, buildNumber, module, project,
-		serverId, skipLogin, threads, detailedSummary,
+		serverId, skipLogin, threads, detailedSummary, maxBandwidth, maxRequestsPerSecond,
 	},
 	DockerPull: {
 		buildName, buildNumber, module, project,
@@ -1663,7 +1992,7 @@ var commandFlags = map[string][]string{
 	},
 	ContainerPush: {
 		buildName, buildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
-		serverId, skipLogin, threads, project, detailedSummary,
+		serverId, skipLogin, threads, project, detailedSummary, maxBandwidth, maxRequestsPerSecond,
 	},
 	ContainerPull: {
 		buildName, buildNumber, module, url, user, password, accessToken, sshPassphrase, sshKeyPath,
@@ -1718,6 +2047,20 @@ var commandFlags = map[string][]string{
 	TransferConfigMerge: {
 		IncludeRepos, ExcludeRepos, IncludeProjects, ExcludeProjects,
 	},
+	TransferJournal: {
+		deleteQuiet,
+	},
+	Diff: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath, ClientCertKeyPath,
+		sourceBuild, targetBuild, project, diffFormat, ignoreProps, failOnDiff, InsecureTls, retries, retryWaitTime,
+	},
+	SpecValidate: {
+		specValidateFor, specVars,
+	},
+	RtSync: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath, ClientCertKeyPath,
+		syncDirection, syncConflict, dryRun, deleteQuiet, threads, InsecureTls, retries, retryWaitTime,
+	},
 	Ping: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, InsecureTls,
@@ -1743,6 +2086,30 @@ var commandFlags = map[string][]string{
 	Poetry: {
 		buildName, buildNumber, module, project,
 	},
+	CargoConfig: {
+		global, serverIdResolve, repoResolve,
+	},
+	Cargo: {
+		buildName, buildNumber, module, project,
+	},
+	ComposerConfig: {
+		global, serverIdResolve, repoResolve,
+	},
+	Composer: {
+		buildName, buildNumber, module, project,
+	},
+	ConanConfig: {
+		global, serverIdResolve, repoResolve,
+	},
+	Conan: {
+		buildName, buildNumber, module, project,
+	},
+	HelmConfig: {
+		global, serverIdResolve, repoResolve, serverIdDeploy, repoDeploy,
+	},
+	Helm: {
+		buildName, buildNumber, module, project,
+	},
 	ReleaseBundleCreate: {
 		distUrl, user, password, accessToken, serverId, specFlag, specVars, targetProps,
 		rbDryRun, sign, desc, exclusions, releaseNotesPath, releaseNotesSyntax, rbPassphrase, rbRepo, InsecureTls, distTarget, rbDetailedSummary,
@@ -1767,6 +2134,14 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, vars,
 	},
+	RepoApply: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, vars, approve, prune,
+	},
+	TemplateExport: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, exportVars,
+	},
 	RepoDelete: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, deleteQuiet,
@@ -1781,7 +2156,22 @@ var commandFlags = map[string][]string{
 	},
 	AccessTokenCreate: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
-		ClientCertKeyPath, groups, grantAdmin, expiry, refreshable, audience,
+		ClientCertKeyPath, groups, grantAdmin, expiry, refreshable, audience, outputEnv, outputServerId,
+	},
+	AccessTokenList: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath,
+	},
+	AccessTokenRevoke: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, revokeQuiet,
+	},
+	AccessTokenRefresh: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, outputEnv, outputServerId,
+	},
+	AccessTokenInspect: {
+		serverId, accessToken,
 	},
 	UserCreate: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
@@ -1795,6 +2185,10 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
 		usersDeleteCsv, deleteQuiet,
 	},
+	UsersSync: {
+		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
+		usersSyncFile, deactivateMissing, usersSyncDryRun, reportFile, usersSyncQuiet,
+	},
 	GroupCreate: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId,
 		Replace,
@@ -1806,7 +2200,7 @@ var commandFlags = map[string][]string{
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, deleteQuiet,
 	},
 	TransferFiles: {
-		Filestore, IncludeRepos, ExcludeRepos, IgnoreState, ProxyKey, transferFilesStatus, Stop, PreChecks,
+		Filestore, IncludeRepos, ExcludeRepos, IgnoreState, ProxyKey, transferFilesStatus, Stop, PreChecks, transferMaxBandwidth, transferMaxRequestsPerSecond,
 	},
 	TransferInstall: {
 		installPluginVersion, InstallPluginSrcDir, InstallPluginHomeDir,
@@ -1820,7 +2214,7 @@ var commandFlags = map[string][]string{
 	},
 	Audit: {
 		xrUrl, user, password, accessToken, serverId, InsecureTls, project, watches, repoPath, licenses, xrOutput, ExcludeTestDeps,
-		useWrapperAudit, DepType, RequirementsFile, fail, ExtendedTable, workingDirs, Mvn, Gradle, Npm, Yarn, Go, Nuget, Pip, Pipenv, Poetry, MinSeverity, FixableOnly,
+		useWrapperAudit, DepType, RequirementsFile, fail, ExtendedTable, workingDirs, Mvn, Gradle, Npm, Yarn, Go, Nuget, Pip, Pipenv, Poetry, Cargo, Composer, Helm, MinSeverity, FixableOnly, Baseline,
 	},
 	AuditMvn: {
 		xrUrl, user, password, accessToken, serverId, InsecureTls, project, watches, repoPath, licenses, xrOutput, fail, ExtendedTable, useWrapperAudit,
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	corecontainercmds "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/container"
 	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
@@ -16,7 +17,11 @@ import (
 	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-cli/utils/profile"
+	"github.com/jfrog/jfrog-cli/utils/projectconfig"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
 	"github.com/jfrog/jfrog-cli/utils/summary"
+	"github.com/jfrog/jfrog-cli/utils/throttling"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
@@ -229,6 +234,52 @@ func PrintCommandSummary(result *commandUtils.Result, detailedSummary, printDepl
 	return
 }
 
+// Starts recording the file transfers of the command for the report of the --summary-file option.
+// Returns nil if the option wasn't provided. Otherwise, the recorder should be stopped once the command is done.
+func StartTransferRecorderIfNeeded(c *cli.Context, command summary.TransferCommand, serverDetails *coreConfig.ServerDetails) (*summary.TransferRecorder, error) {
+	if c.String(summaryFile) == "" {
+		return nil, nil
+	}
+	recorder := summary.NewTransferRecorder(command, serverDetails.ArtifactoryUrl)
+	if err := recorder.Start(serverDetails); err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// Stops the recorder, if it was started. The original error is returned as is. If it's nil, an error from stopping the recorder is returned.
+func StopTransferRecorder(recorder *summary.TransferRecorder, result *commandUtils.Result, originalErr error) error {
+	err := recorder.Stop(result)
+	if originalErr != nil {
+		if err != nil {
+			log.Error(err)
+		}
+		return originalErr
+	}
+	return err
+}
+
+// Writes a transfer report of the command's result to the file provided by the --summary-file option, if it was provided.
+// The original error is returned as is. If it's nil, an error from writing the report is returned.
+// The recorder holds the attempts and errors of the transferred files.
+func WriteSummaryFile(c *cli.Context, commandName string, startTime time.Time, retries int, result *commandUtils.Result, recorder *summary.TransferRecorder, uploaded bool, originalErr error) error {
+	summaryFilePath := c.String(summaryFile)
+	if summaryFilePath == "" {
+		return originalErr
+	}
+	success, failed := 0, 0
+	var reader *content.ContentReader
+	if result != nil {
+		success, failed, reader = result.SuccessCount(), result.FailCount(), result.Reader()
+	}
+	report := summary.NewTransferReport(commandName, startTime, retries, success, failed, IsFailNoOp(c), originalErr)
+	err := report.WriteToFile(summaryFilePath, reader, recorder, uploaded)
+	if err == nil {
+		log.Info("The command summary was written to", summaryFilePath)
+	}
+	return summaryPrintError(err, originalErr)
+}
+
 func CreateSummaryReportString(success, failed int, failNoOp bool, err error) (string, error) {
 	summaryReport := summary.GetSummaryReport(success, failed, failNoOp, err)
 	summaryContent, mErr := summaryReport.Marshal()
@@ -261,6 +312,12 @@ func WrongNumberOfArgumentsHandler(context *cli.Context) error {
 	return PrintHelpAndReturnError(fmt.Sprintf("Wrong number of arguments (%d).", context.NArg()), context)
 }
 
+// Returns the limits of the file transfers, by the --max-bandwidth and --max-requests-per-second options.
+// If an option wasn't sent, its environment variable is used.
+func GetThrottlingLimits(c *cli.Context) (*throttling.Limits, error) {
+	return throttling.GetLimits(c.String("max-bandwidth"), c.String("max-requests-per-second"))
+}
+
 // This function indicates whether the command should be executed without
 // confirmation warning or not.
 // If the --quiet option was sent, it is used to determine whether to prompt the confirmation or not.
@@ -389,25 +446,31 @@ func handleSecretInput(c *cli.Context, stringFlag, stdinFlag string) (secret str
 	}
 
 	if isStdinSecret {
-		var stat os.FileInfo
-		stat, err = os.Stdin.Stat()
+		secret, err = ReadSecretFromStdin(stringFlag)
+	}
+	return
+}
+
+// Reads a secret piped to the standard input. Returns an error if no secret was piped.
+func ReadSecretFromStdin(secretName string) (secret string, err error) {
+	var stat os.FileInfo
+	stat, err = os.Stdin.Stat()
+	if err != nil {
+		return
+	}
+	if (stat.Mode() & os.ModeCharDevice) == 0 {
+		var rawSecret []byte
+		rawSecret, err = io.ReadAll(os.Stdin)
 		if err != nil {
 			return
 		}
-		if (stat.Mode() & os.ModeCharDevice) == 0 {
-			var rawSecret []byte
-			rawSecret, err = io.ReadAll(os.Stdin)
-			if err != nil {
-				return
-			}
-			secret = strings.TrimSpace(string(rawSecret))
-			if secret != "" {
-				log.Debug("Using", stringFlag, "provided via Stdin")
-				return
-			}
+		secret = strings.TrimSpace(string(rawSecret))
+		if secret != "" {
+			log.Debug("Using", secretName, "provided via Stdin")
+			return
 		}
-		err = errorutils.CheckErrorf("no %s provided via Stdin", stringFlag)
 	}
+	err = errorutils.CheckErrorf("no %s provided via Stdin", secretName)
 	return
 }
 
@@ -487,8 +550,13 @@ func CreateServerDetailsWithConfigOffer(c *cli.Context, excludeRefreshableTokens
 		return details, nil
 	}
 
-	// Else, use details from config for requested serverId, or for default server if empty.
-	confDetails, err := coreCommonCommands.GetConfig(details.ServerId, excludeRefreshableTokens)
+	// Else, use details from config for requested serverId, for the server pinned by the directory's profile, or for default server if empty.
+	if details.ServerId == "" {
+		if details.ServerId, err = profile.GetProfileServerId(); err != nil {
+			return nil, err
+		}
+	}
+	confDetails, err := secretstore.GetSpecificConfig(details.ServerId, true, excludeRefreshableTokens)
 	if err != nil {
 		return nil, err
 	}
@@ -630,9 +698,61 @@ func CreateConfigCmd(c *cli.Context, confType artifactoryUtils.ProjectType) erro
 	if c.NArg() != 0 {
 		return WrongNumberOfArgumentsHandler(c)
 	}
+	if err := applyProfileRepositories(c); err != nil {
+		return err
+	}
 	return commandUtils.CreateBuildConfig(c, confType)
 }
 
+// Creates the configuration of a project type which is configured by JFrog CLI rather than by jfrog-cli-core.
+// Unlike the configuration of the other project types, the configuration is created from the command options only.
+func CreateProjectConfigCmd(c *cli.Context, projectType projectconfig.ProjectType) error {
+	if c.NArg() != 0 {
+		return WrongNumberOfArgumentsHandler(c)
+	}
+	if err := applyProfileRepositories(c); err != nil {
+		return err
+	}
+	resolver := artifactoryUtils.Repository{ServerId: c.String(serverIdResolve), Repo: c.String(repoResolve)}
+	deployer := artifactoryUtils.Repository{ServerId: c.String(serverIdDeploy), Repo: c.String(repoDeploy)}
+	return projectconfig.CreateConfig(projectType, c.Bool(global), resolver, deployer)
+}
+
+// Uses the default repositories of the directory's profile for the resolution and deployment options which weren't set.
+func applyProfileRepositories(c *cli.Context) error {
+	dirProfile, err := profile.FindProfile()
+	if err != nil || dirProfile == nil {
+		return err
+	}
+	defaults := []struct{ serverIdFlag, repoFlag, repo string }{
+		{serverIdResolve, repoResolve, dirProfile.Repositories.Resolve},
+		{serverIdDeploy, repoDeploy, dirProfile.Repositories.Deploy},
+	}
+	for _, d := range defaults {
+		if d.repo == "" || c.IsSet(d.repoFlag) || !isFlagDefined(c, d.repoFlag) {
+			continue
+		}
+		if err = c.Set(d.repoFlag, d.repo); err != nil {
+			return errorutils.CheckError(err)
+		}
+		if dirProfile.ServerId != "" && !c.IsSet(d.serverIdFlag) && isFlagDefined(c, d.serverIdFlag) {
+			if err = c.Set(d.serverIdFlag, dirProfile.ServerId); err != nil {
+				return errorutils.CheckError(err)
+			}
+		}
+	}
+	return nil
+}
+
+func isFlagDefined(c *cli.Context, flagName string) bool {
+	for _, flag := range c.Command.Flags {
+		if flag.GetName() == flagName {
+			return true
+		}
+	}
+	return false
+}
+
 func RunNativeCmdWithDeprecationWarning(cmdName string, projectType artifactoryUtils.ProjectType, c *cli.Context, cmd func(c *cli.Context) error) error {
 	if shouldLogWarning() {
 		LogNativeCommandDeprecation(cmdName, projectType.String())
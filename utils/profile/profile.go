@@ -0,0 +1,181 @@
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	profileDirName  = ".jfrog"
+	profileFileName = "profile"
+)
+
+// The source from which the server ID was resolved.
+type Source string
+
+const (
+	OptionSource  Source = "--server-id option"
+	EnvSource     Source = coreutils.ServerID + " environment variable"
+	ProfileSource Source = "profile"
+	DefaultSource Source = "default server"
+)
+
+type Repositories struct {
+	// The repository from which dependencies are resolved.
+	Resolve string `yaml:"resolve,omitempty" json:"resolve,omitempty"`
+	// The repository to which artifacts are deployed.
+	Deploy string `yaml:"deploy,omitempty" json:"deploy,omitempty"`
+}
+
+// A profile pins the server and the default repositories used by the commands run inside a directory tree.
+// The profile is read from the .jfrog/profile YAML file, found in the working directory or in one of its parents.
+type Profile struct {
+	Name         string       `yaml:"name,omitempty" json:"name,omitempty"`
+	ServerId     string       `yaml:"serverId,omitempty" json:"serverId,omitempty"`
+	Repositories Repositories `yaml:"repositories,omitempty" json:"repositories,omitempty"`
+	// The path of the profile file.
+	Path string `yaml:"-" json:"path"`
+}
+
+// Searches for the profile file in the working directory and in its parents. Returns nil if no profile was found.
+func FindProfile() (*Profile, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return FindProfileFrom(wd)
+}
+
+// Searches for the profile file in the provided directory and in its parents. Returns nil if no profile was found.
+// The JFrog CLI home directory is skipped, since it isn't a project directory.
+func FindProfileFrom(dir string) (*Profile, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for {
+		profileDir := filepath.Join(dir, profileDirName)
+		if profileDir != filepath.Clean(homeDir) {
+			profilePath := filepath.Join(profileDir, profileFileName)
+			exists, err := fileutils.IsFileExists(profilePath, false)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return ReadProfile(profilePath)
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+func ReadProfile(path string) (*Profile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	profile := new(Profile)
+	if err = yaml.UnmarshalStrict(content, profile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the profile %s: %s", path, err.Error())
+	}
+	profile.Path = path
+	return profile, nil
+}
+
+// The server resolved for the working directory.
+type Resolution struct {
+	ServerId string   `json:"serverId"`
+	Source   Source   `json:"source"`
+	Profile  *Profile `json:"profile,omitempty"`
+}
+
+// Resolves the server ID to use, by the following order:
+// 1. The --server-id option, if provided.
+// 2. The JFROG_CLI_SERVER_ID environment variable.
+// 3. The profile of the working directory.
+// 4. The default server.
+// The profile is returned whenever it exists, since it also provides the default repositories.
+// The server ID is empty if there's no default server.
+func Resolve(serverIdOption string) (*Resolution, error) {
+	profile, err := FindProfile()
+	if err != nil {
+		return nil, err
+	}
+	resolution := &Resolution{Profile: profile}
+	switch {
+	case serverIdOption != "":
+		resolution.ServerId, resolution.Source = serverIdOption, OptionSource
+	case os.Getenv(coreutils.ServerID) != "":
+		resolution.ServerId, resolution.Source = os.Getenv(coreutils.ServerID), EnvSource
+	case profile != nil && profile.ServerId != "":
+		resolution.ServerId, resolution.Source = profile.ServerId, ProfileSource
+	default:
+		resolution.Source = DefaultSource
+		defaultServer, err := config.GetDefaultServerConf()
+		if err != nil {
+			return nil, err
+		}
+		if defaultServer != nil {
+			resolution.ServerId = defaultServer.ServerId
+		}
+	}
+	return resolution, nil
+}
+
+// Returns the server ID pinned by the profile of the working directory, or an empty string if there's no such profile.
+// Should be used only if the server ID wasn't provided explicitly.
+func GetProfileServerId() (string, error) {
+	profile, err := FindProfile()
+	if err != nil || profile == nil {
+		return "", err
+	}
+	if profile.ServerId != "" {
+		log.Debug("Using the server ID '" + profile.ServerId + "' from the profile " + profile.Path)
+	}
+	return profile.ServerId, nil
+}
+
+// Formats the resolution as aligned lines, for display.
+func FormatResolution(resolution *Resolution) string {
+	serverId := resolution.ServerId
+	if serverId == "" {
+		serverId = "None"
+	}
+	lines := []string{
+		fmt.Sprintf("%-20s%s", "Server ID:", serverId),
+		fmt.Sprintf("%-20s%s", "Source:", resolution.Source),
+	}
+	if resolution.Profile == nil {
+		lines = append(lines, fmt.Sprintf("%-20s%s", "Profile:", "None"))
+		return strings.Join(lines, "\n")
+	}
+	profilePath := resolution.Profile.Path
+	if resolution.Profile.Name != "" {
+		profilePath = resolution.Profile.Name + " (" + profilePath + ")"
+	}
+	lines = append(lines, fmt.Sprintf("%-20s%s", "Profile:", profilePath))
+	if resolution.Profile.Repositories.Resolve != "" {
+		lines = append(lines, fmt.Sprintf("%-20s%s", "Resolve repository:", resolution.Profile.Repositories.Resolve))
+	}
+	if resolution.Profile.Repositories.Deploy != "" {
+		lines = append(lines, fmt.Sprintf("%-20s%s", "Deploy repository:", resolution.Profile.Repositories.Deploy))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,93 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeProfile(t *testing.T, dir, content string) string {
+	profileDir := filepath.Join(dir, profileDirName)
+	assert.NoError(t, os.MkdirAll(profileDir, 0755))
+	profilePath := filepath.Join(profileDir, profileFileName)
+	assert.NoError(t, os.WriteFile(profilePath, []byte(content), 0644))
+	return profilePath
+}
+
+func TestFindProfileFrom(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv(coreutils.HomeDir, filepath.Join(homeDir, profileDirName))
+	// A profile in the JFrog CLI home directory is ignored.
+	writeProfile(t, homeDir, "serverId: home")
+	projectDir := filepath.Join(homeDir, "project")
+	nestedDir := filepath.Join(projectDir, "module", "src")
+	assert.NoError(t, os.MkdirAll(nestedDir, 0755))
+
+	profile, err := FindProfileFrom(nestedDir)
+	assert.NoError(t, err)
+	assert.Nil(t, profile)
+
+	profilePath := writeProfile(t, projectDir, "name: team-a\nserverId: team-a-server\nrepositories:\n  resolve: libs-remote\n  deploy: libs-local\n")
+	profile, err = FindProfileFrom(nestedDir)
+	assert.NoError(t, err)
+	assert.Equal(t, &Profile{Name: "team-a", ServerId: "team-a-server", Repositories: Repositories{Resolve: "libs-remote", Deploy: "libs-local"}, Path: profilePath}, profile)
+
+	// A profile in a nested directory takes precedence.
+	nestedProfilePath := writeProfile(t, filepath.Join(projectDir, "module"), "serverId: module-server\n")
+	profile, err = FindProfileFrom(nestedDir)
+	assert.NoError(t, err)
+	assert.Equal(t, &Profile{ServerId: "module-server", Path: nestedProfilePath}, profile)
+}
+
+func TestReadProfileUnknownField(t *testing.T) {
+	profilePath := writeProfile(t, t.TempDir(), "server: typo\n")
+	_, err := ReadProfile(profilePath)
+	assert.ErrorContains(t, err, "failed to parse the profile")
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	t.Setenv(coreutils.ServerID, "")
+	projectDir := t.TempDir()
+	profilePath := writeProfile(t, projectDir, "serverId: profile-server\n")
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(projectDir))
+	defer func() {
+		assert.NoError(t, os.Chdir(wd))
+	}()
+
+	resolution, err := Resolve("")
+	assert.NoError(t, err)
+	assert.Equal(t, "profile-server", resolution.ServerId)
+	assert.Equal(t, ProfileSource, resolution.Source)
+	// EvalSymlinks is used, since the temp directory may be a symlink.
+	resolvedPath, err := filepath.EvalSymlinks(resolution.Profile.Path)
+	assert.NoError(t, err)
+	expectedPath, err := filepath.EvalSymlinks(profilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPath, resolvedPath)
+
+	t.Setenv(coreutils.ServerID, "env-server")
+	resolution, err = Resolve("")
+	assert.NoError(t, err)
+	assert.Equal(t, "env-server", resolution.ServerId)
+	assert.Equal(t, EnvSource, resolution.Source)
+
+	resolution, err = Resolve("option-server")
+	assert.NoError(t, err)
+	assert.Equal(t, "option-server", resolution.ServerId)
+	assert.Equal(t, OptionSource, resolution.Source)
+}
+
+func TestFormatResolution(t *testing.T) {
+	assert.Equal(t, "Server ID:          None\nSource:             default server\nProfile:            None",
+		FormatResolution(&Resolution{Source: DefaultSource}))
+	resolution := &Resolution{ServerId: "team-a-server", Source: ProfileSource,
+		Profile: &Profile{Name: "team-a", Path: "/work/.jfrog/profile", Repositories: Repositories{Deploy: "libs-local"}}}
+	assert.Equal(t, "Server ID:          team-a-server\nSource:             profile\nProfile:            team-a (/work/.jfrog/profile)\nDeploy repository:  libs-local",
+		FormatResolution(resolution))
+}
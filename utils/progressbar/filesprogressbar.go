@@ -9,9 +9,12 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/progressbar"
+	"github.com/jfrog/jfrog-cli/utils/throttling"
 	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 
@@ -315,6 +318,12 @@ type CommandWithProgress interface {
 }
 
 func ExecWithProgress(cmd CommandWithProgress) (err error) {
+	return ExecWithThrottledProgress(cmd, nil)
+}
+
+// Executes the command with a progress bar, while throttling its file transfers by the provided limits.
+// The transfers are throttled also when a progress bar can't be displayed.
+func ExecWithThrottledProgress(cmd CommandWithProgress, limits *throttling.Limits) (err error) {
 	// Show log file path on all progress bars except 'setup' command
 	showLogFilePath := cmd.CommandName() != "setup"
 	// Init progress bar.
@@ -322,6 +331,13 @@ func ExecWithProgress(cmd CommandWithProgress) (err error) {
 	if err != nil {
 		return err
 	}
+	if limits.IsSet() {
+		throttled := throttling.NewProgressMgr(progressBar, limits)
+		if uploadCmd, ok := cmd.(*generic.UploadCommand); ok && isChecksumDeployPossible(uploadCmd.Spec()) {
+			throttled.CountUploadRequests(func() int64 { return uploadCmd.UploadConfiguration().MinChecksumDeploySize })
+		}
+		progressBar = throttled
+	}
 	if progressBar != nil {
 		cmd.SetProgress(progressBar)
 		defer func() {
@@ -334,3 +350,16 @@ func ExecWithProgress(cmd CommandWithProgress) (err error) {
 	err = commands.Exec(cmd)
 	return
 }
+
+// Files which are uploaded into an archive or exploded aren't deployed by their checksums.
+func isChecksumDeployPossible(uploadSpec *spec.SpecFiles) bool {
+	if uploadSpec == nil {
+		return false
+	}
+	for _, file := range uploadSpec.Files {
+		if explode, _ := file.IsExplode(false); explode || file.Archive != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+// The project types which are configured by JFrog CLI, in addition to the project types of jfrog-cli-core.
+// Their configuration files have the same format as the configuration files of jfrog-cli-core, and are saved in the
+// .jfrog/projects directory of the project, or in the JFrog CLI home directory if the configuration is global.
+type ProjectType string
+
+const (
+	Cargo    ProjectType = "cargo"
+	Composer ProjectType = "composer"
+	Conan    ProjectType = "conan"
+	Helm     ProjectType = "helm"
+)
+
+func (projectType ProjectType) String() string {
+	return string(projectType)
+}
+
+// Creates the configuration file of the project type, with the provided resolution and deployment repositories.
+// If the server ID of a repository isn't set, the default server is used.
+func CreateConfig(projectType ProjectType, global bool, resolver, deployer utils.Repository) error {
+	if resolver.Repo == "" && deployer.Repo == "" {
+		return errorutils.CheckErrorf("a resolution or a deployment repository must be set. Use the --repo-resolve or --repo-deploy option")
+	}
+	for _, repository := range []*utils.Repository{&resolver, &deployer} {
+		if err := setDefaultServerId(repository); err != nil {
+			return err
+		}
+	}
+	projectDir, err := utils.GetProjectDir(global)
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(projectDir); err != nil {
+		return err
+	}
+	configFile := commandsutils.ConfigFile{
+		Version:    commandsutils.BuildConfVersion,
+		ConfigType: projectType.String(),
+		Resolver:   resolver,
+		Deployer:   deployer,
+	}
+	content, err := yaml.Marshal(&configFile)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(filepath.Join(projectDir, projectType.String()+".yaml"), content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info(projectType.String() + " build config successfully created.")
+	return nil
+}
+
+func setDefaultServerId(repository *utils.Repository) error {
+	if repository.Repo == "" || repository.ServerId != "" {
+		return nil
+	}
+	if repository.ServerId = os.Getenv(coreutils.ServerID); repository.ServerId != "" {
+		return nil
+	}
+	serverDetails, err := config.GetDefaultServerConf()
+	if err != nil {
+		return err
+	}
+	if serverDetails == nil {
+		return errorutils.CheckErrorf("server ID must be set. Use the --server-id-resolve/deploy option or configure a default server using 'jf c add' and 'jf c use' commands")
+	}
+	repository.ServerId = serverDetails.ServerId
+	return nil
+}
+
+// Returns the path of the configuration file of the project type. The file is searched for in the .jfrog directory
+// of the working directory or of one of its parents, and then in the JFrog CLI home directory.
+func GetConfigFilePath(projectType ProjectType) (configFilePath string, exists bool, err error) {
+	configFileName := filepath.Join("projects", projectType.String()+".yaml")
+	projectDir, exists, err := fileutils.FindUpstream(".jfrog", fileutils.Dir)
+	if err != nil {
+		return
+	}
+	if exists {
+		configFilePath = filepath.Join(projectDir, ".jfrog", configFileName)
+		if exists, err = fileutils.IsFileExists(configFilePath, false); exists || err != nil {
+			return
+		}
+	}
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return
+	}
+	configFilePath = filepath.Join(jfrogHomeDir, configFileName)
+	exists, err = fileutils.IsFileExists(configFilePath, false)
+	return
+}
+
+// Returns the resolution repository and server of the project type.
+func GetResolverConfig(projectType ProjectType) (*utils.RepositoryConfig, error) {
+	return getRepositoryConfig(projectType, utils.ProjectConfigResolverPrefix)
+}
+
+// Returns the deployment repository and server of the project type.
+func GetDeployerConfig(projectType ProjectType) (*utils.RepositoryConfig, error) {
+	return getRepositoryConfig(projectType, utils.ProjectConfigDeployerPrefix)
+}
+
+func getRepositoryConfig(projectType ProjectType, prefix string) (*utils.RepositoryConfig, error) {
+	configFilePath, vConfig, err := readConfigFile(projectType)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GetRepoConfigByPrefix(configFilePath, prefix, vConfig)
+}
+
+// Returns the resolution and deployment repositories and servers of the project type, for project types which may
+// be configured with only one of them. A repository which isn't configured is returned as nil.
+func GetRepositoriesConfig(projectType ProjectType) (resolver, deployer *utils.RepositoryConfig, err error) {
+	configFilePath, vConfig, err := readConfigFile(projectType)
+	if err != nil {
+		return
+	}
+	if vConfig.IsSet(utils.ProjectConfigResolverPrefix) {
+		if resolver, err = utils.GetRepoConfigByPrefix(configFilePath, utils.ProjectConfigResolverPrefix, vConfig); err != nil {
+			return
+		}
+	}
+	if vConfig.IsSet(utils.ProjectConfigDeployerPrefix) {
+		deployer, err = utils.GetRepoConfigByPrefix(configFilePath, utils.ProjectConfigDeployerPrefix, vConfig)
+	}
+	return
+}
+
+func readConfigFile(projectType ProjectType) (configFilePath string, vConfig *viper.Viper, err error) {
+	configFilePath, exists, err := GetConfigFilePath(projectType)
+	if err != nil {
+		return
+	}
+	if !exists {
+		err = errorutils.CheckErrorf("no config file was found! Before running the %[1]s command on a project for the first time, the project should be configured using the %[1]s-config command", projectType)
+		return
+	}
+	vConfig, err = utils.ReadConfigFile(configFilePath, utils.YAML)
+	return
+}
@@ -0,0 +1,77 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateConfig(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	t.Setenv(coreutils.ServerID, "env-server")
+	projectDir := t.TempDir()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(projectDir))
+	defer func() {
+		assert.NoError(t, os.Chdir(wd))
+	}()
+
+	_, exists, err := GetConfigFilePath(Cargo)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.ErrorContains(t, CreateConfig(Cargo, false, utils.Repository{}, utils.Repository{}), "--repo-resolve")
+	require.NoError(t, CreateConfig(Cargo, false, utils.Repository{Repo: "cargo-remote"}, utils.Repository{Repo: "cargo-local", ServerId: "deploy-server"}))
+
+	configFilePath, exists, err := GetConfigFilePath(Cargo)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	// EvalSymlinks is used, since the temp directory may be a symlink.
+	resolvedPath, err := filepath.EvalSymlinks(configFilePath)
+	assert.NoError(t, err)
+	expectedPath, err := filepath.EvalSymlinks(filepath.Join(projectDir, ".jfrog", "projects", "cargo.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPath, resolvedPath)
+
+	vConfig, err := utils.ReadConfigFile(configFilePath, utils.YAML)
+	require.NoError(t, err)
+	assert.Equal(t, 1, vConfig.GetInt("version"))
+	assert.Equal(t, "cargo", vConfig.GetString("type"))
+	assert.Equal(t, "cargo-remote", vConfig.GetString("resolver.repo"))
+	// The server ID of the environment is used if the server ID wasn't set.
+	assert.Equal(t, "env-server", vConfig.GetString("resolver.serverId"))
+	assert.Equal(t, "cargo-local", vConfig.GetString("deployer.repo"))
+	assert.Equal(t, "deploy-server", vConfig.GetString("deployer.serverId"))
+}
+
+func TestGetRepositoriesConfig(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{{ServerId: "server", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", IsDefault: true}}))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() {
+		assert.NoError(t, os.Chdir(wd))
+	}()
+
+	_, _, err = GetRepositoriesConfig(Helm)
+	assert.ErrorContains(t, err, "helm-config")
+
+	// Only the deployment repository is configured.
+	require.NoError(t, CreateConfig(Helm, false, utils.Repository{}, utils.Repository{Repo: "helm-local"}))
+	resolver, deployer, err := GetRepositoriesConfig(Helm)
+	require.NoError(t, err)
+	assert.Nil(t, resolver)
+	require.NotNil(t, deployer)
+	assert.Equal(t, "helm-local", deployer.TargetRepo())
+	serverDetails, err := deployer.ServerDetails()
+	require.NoError(t, err)
+	assert.Equal(t, "server", serverDetails.ServerId)
+}
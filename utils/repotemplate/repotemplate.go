@@ -0,0 +1,96 @@
+package repotemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/repository"
+)
+
+// Configuration keys holding secrets: the credentials of remote repositories, and the names of the key pairs and
+// client certificates used by repositories, which differ between Artifactory instances.
+var secretKeys = map[string]bool{
+	repository.Username:             true,
+	repository.Password:             true,
+	repository.KeyPair:              true,
+	repository.ClientTlsCertificate: true,
+}
+
+// The suffixes of configuration keys holding secrets, such as the tokens of remote registries.
+var secretKeySuffixes = []string{"password", "token", "secret", "apikey", "privatekey"}
+
+// Returns true if the configuration key holds a secret, which mustn't be exported or printed.
+func IsSecretKey(key string) bool {
+	if secretKeys[key] {
+		return true
+	}
+	lowerKey := strings.ToLower(key)
+	for _, suffix := range secretKeySuffixes {
+		if strings.HasSuffix(lowerKey, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the template variable which replaces a secret of the named template.
+func SecretVar(name, secretKey string) string {
+	return "${" + name + "-" + secretKey + "}"
+}
+
+// Replaces the secrets of the named template by template variables. Returns the keys of the replaced secrets, sorted.
+func RedactSecrets(name string, template map[string]interface{}) (redacted []string) {
+	for key, value := range template {
+		if IsSecretKey(key) && FormatValue(key, value) != "" {
+			template[key] = SecretVar(name, key)
+			redacted = append(redacted, key)
+		}
+	}
+	sort.Strings(redacted)
+	return
+}
+
+// Formats a configuration value the way it is written in the templates, where all the values are strings, and arrays are comma-separated.
+func FormatValue(key string, value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedValue
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	case []interface{}:
+		values := make([]string, 0, len(typedValue))
+		for _, item := range typedValue {
+			values = append(values, FormatValue("", item))
+		}
+		return strings.Join(values, ",")
+	case map[string]interface{}:
+		if key == repository.ContentSynchronisation {
+			return formatContentSynchronisation(typedValue)
+		}
+		content, err := json.Marshal(typedValue)
+		if err != nil {
+			return fmt.Sprint(typedValue)
+		}
+		return string(content)
+	default:
+		return fmt.Sprint(typedValue)
+	}
+}
+
+// Formats the content synchronisation configuration of a remote repository in the template format,
+// which is "<enabled>,<statistics enabled>,<properties enabled>,<source origin absence detection>".
+func formatContentSynchronisation(value map[string]interface{}) string {
+	getBool := func(section, key string) string {
+		if section == "" {
+			return strconv.FormatBool(value[key] == true)
+		}
+		sectionMap, _ := value[section].(map[string]interface{})
+		return strconv.FormatBool(sectionMap[key] == true)
+	}
+	return strings.Join([]string{getBool("", "enabled"), getBool("statistics", "enabled"), getBool("properties", "enabled"), getBool("source", "originAbsenceDetection")}, ",")
+}
@@ -0,0 +1,45 @@
+package repotemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSecretKey(t *testing.T) {
+	for _, key := range []string{"username", "password", "keyPair", "clientTlsCertificate", "gitHubToken", "bearerToken", "apiKey"} {
+		assert.True(t, IsSecretKey(key), key)
+	}
+	for _, key := range []string{"key", "url", "proxy", "enableTokenAuthentication", "keyPairRef"} {
+		assert.False(t, IsSecretKey(key), key)
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	template := map[string]interface{}{"key": "npm-remote", "username": "admin", "password": "AES128:encrypted", "bearerToken": "token", "keyPair": ""}
+	assert.Equal(t, []string{"bearerToken", "password", "username"}, RedactSecrets("npm-remote", template))
+	assert.Equal(t, map[string]interface{}{
+		"key":         "npm-remote",
+		"username":    "${npm-remote-username}",
+		"password":    "${npm-remote-password}",
+		"bearerToken": "${npm-remote-bearerToken}",
+		"keyPair":     "",
+	}, template)
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		key      string
+		value    interface{}
+		expected string
+	}{
+		{"description", nil, ""},
+		{"xrayIndex", true, "true"},
+		{"socketTimeoutMillis", float64(15000), "15000"},
+		{"propertySets", []interface{}{"artifactory", "custom"}, "artifactory,custom"},
+		{"contentSynchronisation", map[string]interface{}{"enabled": true, "source": map[string]interface{}{"originAbsenceDetection": true}}, "true,false,false,true"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, FormatValue(test.key, test.value), test.key)
+	}
+}
@@ -0,0 +1,271 @@
+package searchoutput
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type Format string
+
+const (
+	Json  Format = "json"
+	Table Format = "table"
+	Csv   Format = "csv"
+
+	propsField       = "props"
+	propsFieldPrefix = propsField + "."
+	columnsSeparator = "  "
+)
+
+// The fields which can be projected, in addition to 'props.<key>'.
+var supportedFields = []string{"path", "type", "size", "created", "modified", "sha1", "sha256", "md5", propsField}
+
+// The fields printed by the table and csv formats, when the --fields option isn't provided.
+var defaultFields = []string{"path", "type", "size", "modified", "sha256"}
+
+func GetFormat(format string) (Format, error) {
+	switch strings.ToLower(format) {
+	case "", string(Json):
+		return Json, nil
+	case string(Table):
+		return Table, nil
+	case string(Csv):
+		return Csv, nil
+	default:
+		return "", errorutils.CheckErrorf("the --format option accepts the following values: json, table and csv, but received '%s'", format)
+	}
+}
+
+// Parses a comma-separated list of fields and validates each of them.
+// Returns an empty slice if no fields were provided.
+func ParseFields(fields string) ([]string, error) {
+	var parsedFields []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !isSupportedField(field) {
+			return nil, errorutils.CheckErrorf("the field '%s' is not supported by the --fields option. Supported fields are: %s and props.<property key>", field, strings.Join(supportedFields, ", "))
+		}
+		parsedFields = append(parsedFields, field)
+	}
+	return parsedFields, nil
+}
+
+func isSupportedField(field string) bool {
+	if strings.HasPrefix(field, propsFieldPrefix) {
+		return len(field) > len(propsFieldPrefix)
+	}
+	for _, supportedField := range supportedFields {
+		if field == supportedField {
+			return true
+		}
+	}
+	return false
+}
+
+// Prints the search results in the requested format, while streaming the records from the reader.
+// If no fields are provided, json results are printed in full and table/csv results include the default fields.
+func PrintSearchResults(reader *content.ContentReader, format Format, fields []string) error {
+	switch format {
+	case Table:
+		return printTable(reader, getFieldsOrDefault(fields))
+	case Csv:
+		return printCsv(reader, getFieldsOrDefault(fields))
+	default:
+		if len(fields) == 0 {
+			return utils.PrintSearchResults(reader)
+		}
+		return printJson(reader, fields)
+	}
+}
+
+func getFieldsOrDefault(fields []string) []string {
+	if len(fields) == 0 {
+		return defaultFields
+	}
+	return fields
+}
+
+func printJson(reader *content.ContentReader, fields []string) error {
+	length, err := reader.Length()
+	if err != nil || length == 0 {
+		log.Output("[]")
+		return err
+	}
+	log.Output("[")
+	suffix := ","
+	for searchResult := new(utils.SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(utils.SearchResult) {
+		length--
+		if length == 0 {
+			suffix = ""
+		}
+		data, err := json.Marshal(project(searchResult, fields))
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output("  " + clientutils.IndentJsonArray(data) + suffix)
+	}
+	log.Output("]")
+	reader.Reset()
+	return reader.GetError()
+}
+
+// Returns a copy of the search result, which includes the requested fields only.
+func project(searchResult *utils.SearchResult, fields []string) *utils.SearchResult {
+	projected := new(utils.SearchResult)
+	for _, field := range fields {
+		switch field {
+		case "path":
+			projected.Path = searchResult.Path
+		case "type":
+			projected.Type = searchResult.Type
+		case "size":
+			projected.Size = searchResult.Size
+		case "created":
+			projected.Created = searchResult.Created
+		case "modified":
+			projected.Modified = searchResult.Modified
+		case "sha1":
+			projected.Sha1 = searchResult.Sha1
+		case "sha256":
+			projected.Sha256 = searchResult.Sha256
+		case "md5":
+			projected.Md5 = searchResult.Md5
+		case propsField:
+			projected.Props = searchResult.Props
+		default:
+			key := strings.TrimPrefix(field, propsFieldPrefix)
+			if values, ok := searchResult.Props[key]; ok {
+				if projected.Props == nil {
+					projected.Props = make(map[string][]string)
+				}
+				projected.Props[key] = values
+			}
+		}
+	}
+	return projected
+}
+
+// Returns the string representation of a single field of the search result, as printed in the table and csv formats.
+func getFieldValue(searchResult *utils.SearchResult, field string) string {
+	switch field {
+	case "path":
+		return searchResult.Path
+	case "type":
+		return searchResult.Type
+	case "size":
+		return strconv.FormatInt(searchResult.Size, 10)
+	case "created":
+		return searchResult.Created
+	case "modified":
+		return searchResult.Modified
+	case "sha1":
+		return searchResult.Sha1
+	case "sha256":
+		return searchResult.Sha256
+	case "md5":
+		return searchResult.Md5
+	case propsField:
+		return propsToString(searchResult.Props)
+	default:
+		return strings.Join(searchResult.Props[strings.TrimPrefix(field, propsFieldPrefix)], ",")
+	}
+}
+
+// Converts the properties map to the "key1=value1,value2;key2=value3" format, sorted by keys.
+func propsToString(props map[string][]string) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+strings.Join(props[key], ","))
+	}
+	return strings.Join(pairs, ";")
+}
+
+// The table is printed in two passes over the reader, to avoid holding the results in memory.
+// The first pass calculates the columns widths, and the second prints the rows.
+func printTable(reader *content.ContentReader, fields []string) error {
+	widths := make([]int, len(fields))
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = strings.ToUpper(field)
+		widths[i] = len(header[i])
+	}
+	for searchResult := new(utils.SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(utils.SearchResult) {
+		for i, field := range fields {
+			if valueLen := len(getFieldValue(searchResult, field)); valueLen > widths[i] {
+				widths[i] = valueLen
+			}
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+
+	log.Output(formatTableRow(header, widths))
+	for searchResult := new(utils.SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(utils.SearchResult) {
+		row := make([]string, len(fields))
+		for i, field := range fields {
+			row[i] = getFieldValue(searchResult, field)
+		}
+		log.Output(formatTableRow(row, widths))
+	}
+	reader.Reset()
+	return reader.GetError()
+}
+
+func formatTableRow(values []string, widths []int) string {
+	cells := make([]string, len(values))
+	for i, value := range values {
+		cells[i] = fmt.Sprintf("%-*s", widths[i], value)
+	}
+	return strings.TrimRight(strings.Join(cells, columnsSeparator), " ")
+}
+
+func printCsv(reader *content.ContentReader, fields []string) error {
+	if err := outputCsvRecord(fields); err != nil {
+		return err
+	}
+	for searchResult := new(utils.SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(utils.SearchResult) {
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			record[i] = getFieldValue(searchResult, field)
+		}
+		if err := outputCsvRecord(record); err != nil {
+			return err
+		}
+	}
+	reader.Reset()
+	return reader.GetError()
+}
+
+func outputCsvRecord(record []string) error {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	if err := writer.Write(record); err != nil {
+		return errorutils.CheckError(err)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(strings.TrimSuffix(builder.String(), "\n"))
+	return nil
+}
@@ -0,0 +1,106 @@
+package searchoutput
+
+import (
+	"path/filepath"
+	"testing"
+
+	coretests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFormat(t *testing.T) {
+	tests := []struct {
+		format         string
+		expectedFormat Format
+		expectError    bool
+	}{
+		{"", Json, false},
+		{"json", Json, false},
+		{"TABLE", Table, false},
+		{"csv", Csv, false},
+		{"sarif", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			format, err := GetFormat(test.format)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedFormat, format)
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		fields         string
+		expectedFields []string
+		expectError    bool
+	}{
+		{"", nil, false},
+		{"path, size ,sha256", []string{"path", "size", "sha256"}, false},
+		{"path,props.build.name", []string{"path", "props.build.name"}, false},
+		{"path,props.", nil, true},
+		{"path,name", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.fields, func(t *testing.T) {
+			fields, err := ParseFields(test.fields)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedFields, fields)
+		})
+	}
+}
+
+func TestPrintSearchResults(t *testing.T) {
+	tests := []struct {
+		name           string
+		format         Format
+		fields         []string
+		expectedOutput string
+	}{
+		{"jsonProjection", Json, []string{"path", "props.build.name"}, `[
+  {
+    "path": "repo/a/a1.zip",
+    "props": {
+      "build.name": [
+        "build-a"
+      ]
+    }
+  },
+  {
+    "path": "repo/b/b1,1.zip"
+  }
+]
+`},
+		{"table", Table, []string{"path", "size", "props"}, `PATH             SIZE  PROPS
+repo/a/a1.zip    10    build.name=build-a;build.number=1
+repo/b/b1,1.zip  2048
+`},
+		{"csv", Csv, []string{"path", "sha1", "props.build.number"}, `path,sha1,props.build.number
+repo/a/a1.zip,sha1-a1,1
+"repo/b/b1,1.zip",sha1-b1,
+`},
+		{"csvDefaultFields", Csv, nil, `path,type,size,modified,sha256
+repo/a/a1.zip,file,10,2023-01-01T10:00:00.000Z,sha256-a1
+"repo/b/b1,1.zip",file,2048,2023-01-02T10:00:00.000Z,sha256-b1
+`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputBuffer, _, previousLog := coretests.RedirectLogOutputToBuffer()
+			defer log.SetLogger(previousLog)
+			reader := content.NewContentReader(filepath.Join("testdata", "searchresults.json"), content.DefaultKey)
+			assert.NoError(t, PrintSearchResults(reader, test.format, test.fields))
+			assert.Equal(t, test.expectedOutput, outputBuffer.String())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package secretstore
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The configurations of the servers, which are used for connecting to them, should be read by the functions below,
+// which fetch the secrets of the servers from their secret stores, in addition to reading the JFrog CLI config file.
+
+// Returns the configuration of the server like config.GetSpecificConfig, with the secrets from the server's secret store.
+func GetSpecificConfig(serverId string, defaultOrEmpty, excludeRefreshableTokens bool) (*config.ServerDetails, error) {
+	details, err := config.GetSpecificConfig(serverId, defaultOrEmpty, excludeRefreshableTokens)
+	if err != nil {
+		return nil, err
+	}
+	return details, ResolveSecrets(details)
+}
+
+// Returns the configuration of the server of a repository in a project configuration file, with the secrets from the
+// server's secret store.
+func GetRepositoryServerDetails(repositoryConfig *utils.RepositoryConfig) (*config.ServerDetails, error) {
+	details, err := repositoryConfig.ServerDetails()
+	if err != nil {
+		return nil, err
+	}
+	return details, ResolveSecrets(details)
+}
+
+// The commands of jfrog-cli-core which are configured by a project configuration file, such as mvn and npm, read the
+// configuration of their servers by themselves, so they can't use the secrets of servers which keep them in a secret store.
+// Returns an error if any of the servers of the project configuration file does, rather than running the command without its secrets.
+func CheckProjectConfigFile(configFilePath string) error {
+	vConfig, err := utils.ReadConfigFile(configFilePath, utils.YAML)
+	if err != nil {
+		return err
+	}
+	for _, prefix := range []string{utils.ProjectConfigResolverPrefix, utils.ProjectConfigDeployerPrefix} {
+		if err = CheckFileStore(vConfig.GetString(prefix + "." + utils.ProjectConfigServerId)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns an error if the server keeps its secrets in a secret store, for commands of jfrog-cli-core which read the
+// configuration of the server by themselves.
+func CheckFileStore(serverId string) error {
+	if serverId == "" {
+		return nil
+	}
+	store, err := GetServerStore(serverId)
+	if err != nil || store == nil {
+		return err
+	}
+	return errorutils.CheckErrorf("the '%s' server keeps its secrets in the %s secret store, which isn't supported by this command. "+
+		"Run '%s c edit %s --secret-store=%s' to keep its secrets in the config file instead", serverId, store.Type, coreutils.GetCliExecutableName(), serverId, File)
+}
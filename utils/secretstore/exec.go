@@ -0,0 +1,98 @@
+package secretstore
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The operations of the credential helper protocol.
+const (
+	helperGet   = "get"
+	helperStore = "store"
+	helperErase = "erase"
+)
+
+// Fetches the secrets from a user-provided credential helper, similarly to the git credential helpers.
+// The helper is run with the operation (get, store or erase) as its last argument,
+// and receives the server's details as key=value lines in its standard input: server-id, url and username.
+// For the store operation, the password and access-token keys are also provided.
+// For the get operation, the helper should write the password and/or access-token keys to its standard output, in the same format.
+type execBackend struct {
+	helper string
+	run    commandRunner
+}
+
+func (eb *execBackend) runHelper(operation string, input map[string]string) (string, error) {
+	args := strings.Fields(eb.helper)
+	if len(args) == 0 {
+		return "", errorutils.CheckErrorf("the credential helper command is empty")
+	}
+	run := eb.run
+	if run == nil {
+		run = runCommand
+	}
+	var stdin strings.Builder
+	for _, key := range []string{"server-id", "url", "username", "password", "access-token"} {
+		if value := input[key]; value != "" {
+			stdin.WriteString(key + "=" + value + "\n")
+		}
+	}
+	stdin.WriteString("\n")
+	output, err := run(stdin.String(), args[0], append(args[1:], operation)...)
+	if err != nil {
+		return "", errorutils.CheckErrorf("the credential helper '%s' failed to %s the secrets: %s", eb.helper, operation, err.Error())
+	}
+	return output, nil
+}
+
+func helperInput(details *config.ServerDetails) map[string]string {
+	url := details.Url
+	if url == "" {
+		url = details.ArtifactoryUrl
+	}
+	return map[string]string{"server-id": details.ServerId, "url": url, "username": details.User}
+}
+
+func (eb *execBackend) Get(details *config.ServerDetails) (*Secrets, error) {
+	output, err := eb.runHelper(helperGet, helperInput(details))
+	if err != nil {
+		return nil, err
+	}
+	return ParseHelperOutput(output), nil
+}
+
+func (eb *execBackend) Store(details *config.ServerDetails, secrets *Secrets) error {
+	input := helperInput(details)
+	input["password"] = secrets.Password
+	input["access-token"] = secrets.AccessToken
+	_, err := eb.runHelper(helperStore, input)
+	return err
+}
+
+func (eb *execBackend) Erase(details *config.ServerDetails) error {
+	_, err := eb.runHelper(helperErase, helperInput(details))
+	return err
+}
+
+// Parses the key=value lines written by a credential helper. Returns nil if the output includes no secrets.
+func ParseHelperOutput(output string) *Secrets {
+	secrets := new(Secrets)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimRight(line, "\r"), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "password":
+			secrets.Password = value
+		case "access-token":
+			secrets.AccessToken = value
+		}
+	}
+	if secrets.IsEmpty() {
+		return nil
+	}
+	return secrets
+}
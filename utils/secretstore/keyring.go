@@ -0,0 +1,133 @@
+package secretstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The service name, under which the secrets are saved in the OS keychain. The account is the server ID.
+const keyringService = "jfrog-cli"
+
+// Runs a command with the provided standard input, and returns its standard output.
+type commandRunner func(stdin string, name string, args ...string) (string, error)
+
+// A failure of a command. The error message is the command's standard error, if it wrote to it.
+type commandError struct {
+	stderr string
+	err    error
+}
+
+func (ce *commandError) Error() string {
+	if ce.stderr != "" {
+		return ce.stderr
+	}
+	return ce.err.Error()
+}
+
+func runCommand(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", &commandError{stderr: strings.TrimSpace(stderr.String()), err: err}
+	}
+	return stdout.String(), nil
+}
+
+// Returns true if the command ran and exited with a failure, without writing an error message.
+func isSilentFailure(err error) bool {
+	var cmdErr *commandError
+	if !errors.As(err, &cmdErr) || cmdErr.stderr != "" {
+		return false
+	}
+	var exitErr *exec.ExitError
+	return errors.As(cmdErr.err, &exitErr)
+}
+
+// Keeps the secrets in the OS keychain, using the macOS 'security' tool or the Linux 'secret-tool' tool (libsecret).
+type keyringBackend struct {
+	goos string
+	run  commandRunner
+}
+
+func newKeyringBackend() (*keyringBackend, error) {
+	backend := &keyringBackend{goos: runtime.GOOS, run: runCommand}
+	return backend, backend.validate()
+}
+
+func (kb *keyringBackend) validate() error {
+	switch kb.goos {
+	case "darwin", "linux":
+		return nil
+	default:
+		return errorutils.CheckErrorf("the '%s' secret store isn't supported on %s. Use the '%s' secret store with a credential helper instead", Keyring, kb.goos, Exec)
+	}
+}
+
+func (kb *keyringBackend) Get(details *config.ServerDetails) (*Secrets, error) {
+	var output string
+	var err error
+	if kb.goos == "darwin" {
+		output, err = kb.run("", "security", "find-generic-password", "-s", keyringService, "-a", details.ServerId, "-w")
+		if err != nil && strings.Contains(err.Error(), "could not be found") {
+			return nil, nil
+		}
+	} else {
+		output, err = kb.run("", "secret-tool", "lookup", "service", keyringService, "account", details.ServerId)
+		// secret-tool fails without an error message, if the secret doesn't exist.
+		if isSilentFailure(err) {
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return nil, nil
+	}
+	secrets := new(Secrets)
+	if err = json.Unmarshal([]byte(output), secrets); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the secrets saved in the keychain: %s", err.Error())
+	}
+	return secrets, nil
+}
+
+func (kb *keyringBackend) Store(details *config.ServerDetails, secrets *Secrets) error {
+	content, err := json.Marshal(secrets)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if kb.goos == "darwin" {
+		// The -U option updates the item if it already exists.
+		// The -w option is the last argument with no value, so that the secret is read from the standard input rather than passed on the command line,
+		// where other local users could read it. The security tool asks for the secret twice.
+		_, err = kb.run(string(content)+"\n"+string(content)+"\n", "security", "add-generic-password", "-U", "-s", keyringService, "-a", details.ServerId, "-l", "JFrog CLI "+details.ServerId, "-w")
+	} else {
+		// secret-tool reads the secret from the standard input.
+		_, err = kb.run(string(content), "secret-tool", "store", "--label", "JFrog CLI "+details.ServerId, "service", keyringService, "account", details.ServerId)
+	}
+	return errorutils.CheckError(err)
+}
+
+func (kb *keyringBackend) Erase(details *config.ServerDetails) error {
+	var err error
+	if kb.goos == "darwin" {
+		_, err = kb.run("", "security", "delete-generic-password", "-s", keyringService, "-a", details.ServerId)
+		if err != nil && strings.Contains(err.Error(), "could not be found") {
+			return nil
+		}
+	} else {
+		_, err = kb.run("", "secret-tool", "clear", "service", keyringService, "account", details.ServerId)
+	}
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,260 @@
+package secretstore
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type StoreType string
+
+const (
+	// The secrets are stored in the JFrog CLI config file. This is the default.
+	File StoreType = "file"
+	// The secrets are stored in the OS keychain.
+	Keyring StoreType = "keyring"
+	// The secrets are fetched at runtime from a user-provided credential helper.
+	Exec StoreType = "exec"
+)
+
+// The file in the JFrog home directory, which holds the secret store of each server, which doesn't use the default store.
+const storesFileName = "secret-stores.json"
+
+// The secret store of a server.
+type ServerStore struct {
+	Type StoreType `json:"type"`
+	// The credential helper command, used by the exec secret store.
+	Helper string `json:"helper,omitempty"`
+}
+
+type storesConfig struct {
+	Servers map[string]ServerStore `json:"servers"`
+}
+
+// The secrets of a server, which are kept out of the JFrog CLI config file.
+type Secrets struct {
+	Password    string `json:"password,omitempty"`
+	AccessToken string `json:"accessToken,omitempty"`
+}
+
+func (s *Secrets) IsEmpty() bool {
+	return s.Password == "" && s.AccessToken == ""
+}
+
+// A secret store, in which the secrets of servers are kept.
+type Backend interface {
+	// Returns the secrets of the server, or nil if the store holds no secrets for it.
+	Get(details *config.ServerDetails) (*Secrets, error)
+	Store(details *config.ServerDetails, secrets *Secrets) error
+	Erase(details *config.ServerDetails) error
+}
+
+// Creates a server store from the values of the --secret-store and --secret-helper options.
+// Returns nil for the default file store.
+func NewServerStore(storeType, helper string) (*ServerStore, error) {
+	switch StoreType(storeType) {
+	case "", File:
+		if helper != "" {
+			return nil, errorutils.CheckErrorf("a credential helper can only be used with the '%s' secret store", Exec)
+		}
+		return nil, nil
+	case Keyring:
+		if helper != "" {
+			return nil, errorutils.CheckErrorf("a credential helper can only be used with the '%s' secret store", Exec)
+		}
+		return &ServerStore{Type: Keyring}, nil
+	case Exec:
+		if strings.TrimSpace(helper) == "" {
+			return nil, errorutils.CheckErrorf("the '%s' secret store requires a credential helper command", Exec)
+		}
+		return &ServerStore{Type: Exec, Helper: helper}, nil
+	default:
+		return nil, errorutils.CheckErrorf("unsupported secret store '%s'. The supported stores are: %s, %s and %s", storeType, File, Keyring, Exec)
+	}
+}
+
+func (ss *ServerStore) Backend() (Backend, error) {
+	switch ss.Type {
+	case Keyring:
+		return newKeyringBackend()
+	case Exec:
+		return &execBackend{helper: ss.Helper}, nil
+	default:
+		return nil, errorutils.CheckErrorf("the '%s' secret store has no backend", ss.Type)
+	}
+}
+
+func getStoresFilePath() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, storesFileName), nil
+}
+
+func readStoresConfig() (*storesConfig, error) {
+	stores := &storesConfig{Servers: map[string]ServerStore{}}
+	path, err := getStoresFilePath()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsFileExists(path, false)
+	if err != nil || !exists {
+		return stores, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, stores); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", path, err.Error())
+	}
+	if stores.Servers == nil {
+		stores.Servers = map[string]ServerStore{}
+	}
+	return stores, nil
+}
+
+func writeStoresConfig(stores *storesConfig) error {
+	path, err := getStoresFilePath()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	content, err := json.MarshalIndent(stores, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(path, content, 0600))
+}
+
+// Returns the secret store of the server, or nil if the server uses the default file store.
+func GetServerStore(serverId string) (*ServerStore, error) {
+	stores, err := readStoresConfig()
+	if err != nil {
+		return nil, err
+	}
+	if store, exists := stores.Servers[serverId]; exists {
+		return &store, nil
+	}
+	return nil, nil
+}
+
+// Sets the secret store of the server. A nil store sets the default file store.
+func SetServerStore(serverId string, store *ServerStore) error {
+	stores, err := readStoresConfig()
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		if _, exists := stores.Servers[serverId]; !exists {
+			return nil
+		}
+		delete(stores.Servers, serverId)
+	} else {
+		stores.Servers[serverId] = *store
+	}
+	return writeStoresConfig(stores)
+}
+
+// Saves the secrets of the server in its secret store, and removes them from the server details, so that they aren't saved in the config file.
+// Any secrets kept by the server's previous secret store are erased.
+func MoveSecretsToStore(details *config.ServerDetails, store *ServerStore) error {
+	previous, err := GetServerStore(details.ServerId)
+	if err != nil {
+		return err
+	}
+	if previous != nil && (store == nil || *previous != *store) {
+		eraseSecrets(details, previous)
+	}
+	if store != nil {
+		secrets := &Secrets{Password: details.Password, AccessToken: details.AccessToken}
+		if !secrets.IsEmpty() {
+			backend, err := store.Backend()
+			if err != nil {
+				return err
+			}
+			if err = backend.Store(details, secrets); err != nil {
+				return err
+			}
+		}
+		details.Password = ""
+		details.AccessToken = ""
+	}
+	return SetServerStore(details.ServerId, store)
+}
+
+// Erases the secrets of the server from its secret store, and removes the server from the secret stores file.
+func RemoveServer(details *config.ServerDetails) error {
+	store, err := GetServerStore(details.ServerId)
+	if err != nil || store == nil {
+		return err
+	}
+	eraseSecrets(details, store)
+	return SetServerStore(details.ServerId, nil)
+}
+
+// Erases the secrets of all the servers, which aren't configured anymore.
+func RemoveUnconfiguredServers(configuredServerIds []string) error {
+	stores, err := readStoresConfig()
+	if err != nil {
+		return err
+	}
+	configured := make(map[string]bool, len(configuredServerIds))
+	for _, serverId := range configuredServerIds {
+		configured[serverId] = true
+	}
+	for serverId := range stores.Servers {
+		if !configured[serverId] {
+			if err = RemoveServer(&config.ServerDetails{ServerId: serverId}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func eraseSecrets(details *config.ServerDetails, store *ServerStore) {
+	backend, err := store.Backend()
+	if err == nil {
+		err = backend.Erase(details)
+	}
+	if err != nil {
+		log.Warn("Failed to erase the secrets of the '" + details.ServerId + "' server from the " + string(store.Type) + " secret store: " + err.Error())
+	}
+}
+
+// Fills the password and access token of the server details from the server's secret store, if the server doesn't use the default file store.
+func ResolveSecrets(details *config.ServerDetails) error {
+	if details == nil || details.ServerId == "" {
+		return nil
+	}
+	store, err := GetServerStore(details.ServerId)
+	if err != nil || store == nil {
+		return err
+	}
+	backend, err := store.Backend()
+	if err != nil {
+		return err
+	}
+	secrets, err := backend.Get(details)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to get the secrets of the '%s' server from the %s secret store: %s", details.ServerId, store.Type, err.Error())
+	}
+	if secrets == nil {
+		log.Debug("The " + string(store.Type) + " secret store holds no secrets for the '" + details.ServerId + "' server.")
+		return nil
+	}
+	details.Password = secrets.Password
+	details.AccessToken = secrets.AccessToken
+	return nil
+}
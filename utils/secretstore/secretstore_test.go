@@ -0,0 +1,191 @@
+package secretstore
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewServerStore(t *testing.T) {
+	store, err := NewServerStore("", "")
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+	store, err = NewServerStore("file", "")
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+	store, err = NewServerStore("keyring", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &ServerStore{Type: Keyring}, store)
+	store, err = NewServerStore("exec", "my-helper --verbose")
+	assert.NoError(t, err)
+	assert.Equal(t, &ServerStore{Type: Exec, Helper: "my-helper --verbose"}, store)
+
+	_, err = NewServerStore("exec", "")
+	assert.Error(t, err)
+	_, err = NewServerStore("keyring", "my-helper")
+	assert.Error(t, err)
+	_, err = NewServerStore("vault", "")
+	assert.Error(t, err)
+}
+
+func TestServerStores(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	store, err := GetServerStore("server1")
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+
+	assert.NoError(t, SetServerStore("server1", &ServerStore{Type: Exec, Helper: "my-helper"}))
+	assert.NoError(t, SetServerStore("server2", &ServerStore{Type: Keyring}))
+	store, err = GetServerStore("server1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ServerStore{Type: Exec, Helper: "my-helper"}, store)
+
+	assert.NoError(t, SetServerStore("server1", nil))
+	store, err = GetServerStore("server1")
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+	store, err = GetServerStore("server2")
+	assert.NoError(t, err)
+	assert.Equal(t, &ServerStore{Type: Keyring}, store)
+}
+
+func TestParseHelperOutput(t *testing.T) {
+	assert.Equal(t, &Secrets{Password: "pass=word", AccessToken: "token"}, ParseHelperOutput("username=admin\r\npassword=pass=word\r\naccess-token=token\r\n"))
+	assert.Nil(t, ParseHelperOutput("username=admin\n"))
+	assert.Nil(t, ParseHelperOutput(""))
+}
+
+func TestExecBackend(t *testing.T) {
+	var calls []string
+	backend := &execBackend{helper: "my-helper --profile ci", run: func(stdin string, name string, args ...string) (string, error) {
+		calls = append(calls, name+" "+strings.Join(args, " ")+"\n"+stdin)
+		return "password=secret\n", nil
+	}}
+	details := &config.ServerDetails{ServerId: "server1", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin"}
+	secrets, err := backend.Get(details)
+	assert.NoError(t, err)
+	assert.Equal(t, &Secrets{Password: "secret"}, secrets)
+	assert.NoError(t, backend.Store(details, &Secrets{AccessToken: "token"}))
+	assert.NoError(t, backend.Erase(details))
+	assert.Equal(t, []string{
+		"my-helper --profile ci get\nserver-id=server1\nurl=https://acme.jfrog.io/artifactory/\nusername=admin\n\n",
+		"my-helper --profile ci store\nserver-id=server1\nurl=https://acme.jfrog.io/artifactory/\nusername=admin\naccess-token=token\n\n",
+		"my-helper --profile ci erase\nserver-id=server1\nurl=https://acme.jfrog.io/artifactory/\nusername=admin\n\n",
+	}, calls)
+}
+
+func TestKeyringBackend(t *testing.T) {
+	details := &config.ServerDetails{ServerId: "server1"}
+	var commands []string
+	stored := ""
+	run := func(stdin string, name string, args ...string) (string, error) {
+		commands = append(commands, name+" "+args[0])
+		switch args[0] {
+		case "store":
+			stored = stdin
+		case "add-generic-password":
+			// The secret is never passed on the command line.
+			assert.Equal(t, "-w", args[len(args)-1])
+			stored, _, _ = strings.Cut(stdin, "\n")
+		case "lookup", "find-generic-password":
+			return stored, nil
+		}
+		return "", nil
+	}
+	for _, goos := range []string{"linux", "darwin"} {
+		t.Run(goos, func(t *testing.T) {
+			commands, stored = nil, ""
+			backend := &keyringBackend{goos: goos, run: run}
+			assert.NoError(t, backend.validate())
+			secrets, err := backend.Get(details)
+			assert.NoError(t, err)
+			assert.Nil(t, secrets)
+			assert.NoError(t, backend.Store(details, &Secrets{Password: "secret"}))
+			secrets, err = backend.Get(details)
+			assert.NoError(t, err)
+			assert.Equal(t, &Secrets{Password: "secret"}, secrets)
+			assert.NoError(t, backend.Erase(details))
+			assert.Len(t, commands, 4)
+		})
+	}
+	assert.Error(t, (&keyringBackend{goos: "windows"}).validate())
+}
+
+// Creates a JFrog home directory with a credential helper, which keeps the secrets in a file next to the script.
+// Returns the home directory and the path of the credential helper.
+func createHelper(t *testing.T) (string, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test credential helper is a shell script.")
+	}
+	homeDir := t.TempDir()
+	t.Setenv(coreutils.HomeDir, homeDir)
+	helperPath := filepath.Join(homeDir, "helper.sh")
+	script := `#!/bin/sh
+dir=$(dirname "$0")
+case "$1" in
+  get) cat "$dir/secrets" 2>/dev/null ;;
+  store) grep -E '^(password|access-token)=' > "$dir/secrets" ;;
+  erase) rm -f "$dir/secrets" ;;
+esac
+`
+	assert.NoError(t, os.WriteFile(helperPath, []byte(script), 0700))
+	return homeDir, helperPath
+}
+
+func TestMoveSecretsToStore(t *testing.T) {
+	homeDir, helperPath := createHelper(t)
+	details := &config.ServerDetails{ServerId: "server1", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin", Password: "secret"}
+	assert.NoError(t, MoveSecretsToStore(details, &ServerStore{Type: Exec, Helper: helperPath}))
+	assert.Empty(t, details.Password)
+
+	resolved := &config.ServerDetails{ServerId: "server1", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin"}
+	assert.NoError(t, ResolveSecrets(resolved))
+	assert.Equal(t, "secret", resolved.Password)
+
+	// Servers which use the file store are left as is.
+	other := &config.ServerDetails{ServerId: "server2", Password: "other"}
+	assert.NoError(t, ResolveSecrets(other))
+	assert.Equal(t, "other", other.Password)
+
+	assert.NoError(t, RemoveUnconfiguredServers([]string{"server2"}))
+	store, err := GetServerStore("server1")
+	assert.NoError(t, err)
+	assert.Nil(t, store)
+	assert.NoFileExists(t, filepath.Join(homeDir, "secrets"))
+}
+
+func TestGetSpecificConfig(t *testing.T) {
+	_, helperPath := createHelper(t)
+	details := &config.ServerDetails{ServerId: "server1", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin", Password: "secret"}
+	assert.NoError(t, MoveSecretsToStore(details, &ServerStore{Type: Exec, Helper: helperPath}))
+	assert.NoError(t, config.SaveServersConf([]*config.ServerDetails{details, {ServerId: "server2", User: "admin", Password: "other"}}))
+
+	resolved, err := GetSpecificConfig("server1", false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", resolved.Password)
+	resolved, err = GetSpecificConfig("server2", false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "other", resolved.Password)
+	_, err = GetSpecificConfig("server3", false, true)
+	assert.Error(t, err)
+}
+
+func TestCheckProjectConfigFile(t *testing.T) {
+	homeDir, helperPath := createHelper(t)
+	assert.NoError(t, SetServerStore("server1", &ServerStore{Type: Exec, Helper: helperPath}))
+	configFilePath := filepath.Join(homeDir, "npm.yaml")
+	writeConfigFile := func(deployerServerId string) {
+		content := "version: 1\ntype: npm\nresolver:\n  repo: npm-virtual\n  serverId: server2\ndeployer:\n  repo: npm-local\n  serverId: " + deployerServerId + "\n"
+		assert.NoError(t, os.WriteFile(configFilePath, []byte(content), 0644))
+	}
+	writeConfigFile("server2")
+	assert.NoError(t, CheckProjectConfigFile(configFilePath))
+	writeConfigFile("server1")
+	assert.ErrorContains(t, CheckProjectConfigFile(configFilePath), "'server1' server keeps its secrets in the exec secret store")
+}
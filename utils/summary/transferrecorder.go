@@ -0,0 +1,360 @@
+package summary
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/throttling"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+)
+
+// The commands whose file transfers are recorded.
+type TransferCommand int
+
+const (
+	UploadTransfer TransferCommand = iota
+	DownloadTransfer
+	CopyTransfer
+	MoveTransfer
+	DeleteTransfer
+)
+
+const (
+	// The maximal size of a response body which is read for the error of a failed transfer.
+	maxErrorBodySize = 4096
+	// Returned if the server accepted the last request of a file, but the command didn't complete its transfer,
+	// for example when the checksum of a downloaded file doesn't match.
+	incompleteTransferError = "The server accepted the request, but the command failed to complete the transfer. The error is written to the log of the command."
+)
+
+// TransferRecorder records the file transfers of a command, for its transfer report.
+// While recording, the requests of the command are sent through a local proxy, so that every request of a file is recorded,
+// with its timing and the error returned by the server. Requests which don't transfer files, such as searches, aren't recorded.
+type TransferRecorder struct {
+	command TransferCommand
+	// The Artifactory URL of the recorded server, with a trailing slash.
+	artifactoryUrl string
+	basePath       string
+	transfers      map[string]*recordedTransfer
+	mutex          sync.Mutex
+	// Set while recording.
+	proxy                *throttling.Proxy
+	serverDetails        *config.ServerDetails
+	originArtifactoryUrl string
+}
+
+type recordedTransfer struct {
+	// The target of a copied or moved file.
+	target   string
+	start    time.Time
+	end      time.Time
+	attempts int
+	// Whether the server accepted the last request of the file.
+	accepted bool
+	// The last error of the file.
+	err string
+}
+
+func NewTransferRecorder(command TransferCommand, artifactoryUrl string) *TransferRecorder {
+	tr := &TransferRecorder{
+		command:        command,
+		artifactoryUrl: clientutils.AddTrailingSlashIfNeeded(artifactoryUrl),
+		basePath:       "/",
+		transfers:      make(map[string]*recordedTransfer),
+	}
+	if parsedUrl, err := url.Parse(tr.artifactoryUrl); err == nil {
+		tr.basePath = parsedUrl.Path
+	}
+	return tr
+}
+
+// Starts recording the requests of the server details.
+// The Artifactory URL of the server details is replaced by the URL of the recording proxy until Stop is called,
+// so the command should be created with the same server details.
+func (tr *TransferRecorder) Start(serverDetails *config.ServerDetails) error {
+	if tr == nil {
+		return nil
+	}
+	proxy, err := throttling.NewProxy(tr.artifactoryUrl, &throttling.Limits{})
+	if err != nil {
+		return err
+	}
+	if err = proxy.SetServerDetails(serverDetails); err != nil {
+		return err
+	}
+	proxy.WrapTransport(func(transport http.RoundTripper) http.RoundTripper {
+		return &recordingTransport{recorder: tr, transport: transport}
+	})
+	if err = proxy.Start(); err != nil {
+		return err
+	}
+	tr.proxy, tr.serverDetails, tr.originArtifactoryUrl = proxy, serverDetails, serverDetails.ArtifactoryUrl
+	serverDetails.ArtifactoryUrl = clientutils.AddTrailingSlashIfNeeded(proxy.Url())
+	return nil
+}
+
+// Stops recording, and restores the Artifactory URL of the server details and of the transferred files listed by the result.
+func (tr *TransferRecorder) Stop(result *utils.Result) error {
+	if tr == nil || tr.proxy == nil {
+		return nil
+	}
+	proxyUrl := tr.serverDetails.ArtifactoryUrl
+	tr.serverDetails.ArtifactoryUrl = tr.originArtifactoryUrl
+	err := tr.proxy.Close()
+	tr.proxy = nil
+	if result == nil || result.Reader() == nil {
+		return err
+	}
+	reader, e := replaceArtifactoryUrl(result.Reader(), proxyUrl, tr.artifactoryUrl)
+	if e != nil {
+		return e
+	}
+	result.SetReader(reader)
+	return err
+}
+
+// Returns a reader of the transfer details, in which the provided Artifactory URL is replaced. The provided reader is closed.
+func replaceArtifactoryUrl(reader *content.ContentReader, oldUrl, newUrl string) (*content.ContentReader, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		if transferDetails.RtUrl == oldUrl {
+			transferDetails.RtUrl = newUrl
+		}
+		writer.Write(*transferDetails)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	if err = reader.GetError(); err != nil {
+		return nil, err
+	}
+	if err = reader.Close(); err != nil {
+		return nil, err
+	}
+	if writer.IsEmpty() {
+		return content.NewEmptyContentReader(content.DefaultKey), nil
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
+
+// Returns the key of the file which the request transfers, and the target of a copied or moved file.
+// Uploads are recorded by their target paths, without their properties, and the other commands by their source paths.
+// An empty key is returned for requests which don't transfer files.
+func (tr *TransferRecorder) getTransferKey(req *http.Request) (key, target string) {
+	relativePath := strings.TrimPrefix(req.URL.Path, tr.basePath)
+	isApi := strings.HasPrefix(relativePath, "api/")
+	switch tr.command {
+	case UploadTransfer:
+		if req.Method == http.MethodPut && !isApi {
+			key, _, _ = strings.Cut(relativePath, ";")
+			return strings.TrimSuffix(key, "/"), ""
+		}
+	case DownloadTransfer:
+		if req.Method == http.MethodGet && !isApi {
+			return relativePath, ""
+		}
+	case DeleteTransfer:
+		if req.Method == http.MethodDelete && !isApi {
+			return relativePath, ""
+		}
+	case CopyTransfer, MoveTransfer:
+		api := "api/copy/"
+		if tr.command == MoveTransfer {
+			api = "api/move/"
+		}
+		if req.Method == http.MethodPost && strings.HasPrefix(relativePath, api) {
+			return strings.TrimPrefix(relativePath, api), req.URL.Query().Get("to")
+		}
+	}
+	return "", ""
+}
+
+// Records a request of a file. The parts of a split download are counted as a single attempt.
+func (tr *TransferRecorder) record(key, target string, req *http.Request, start time.Time, accepted bool, err string) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	transfer, exists := tr.transfers[key]
+	if !exists {
+		transfer = &recordedTransfer{target: target, start: start}
+		tr.transfers[key] = transfer
+	}
+	if req.Header.Get("Range") == "" || transfer.attempts == 0 {
+		transfer.attempts++
+	}
+	transfer.end = time.Now()
+	transfer.accepted = accepted
+	if err != "" {
+		transfer.err = err
+	}
+}
+
+func (tr *TransferRecorder) recordEnd(key string) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	if transfer, exists := tr.transfers[key]; exists {
+		transfer.end = time.Now()
+	}
+}
+
+// Adds the attempts and duration of the file's transfer, if it was recorded.
+func (tr *TransferRecorder) addTransferDetails(file *TransferReportFile, key string) {
+	if tr == nil {
+		return
+	}
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	if transfer, exists := tr.transfers[key]; exists {
+		file.Attempts = transfer.attempts
+		file.DurationMillis = transfer.duration().Milliseconds()
+	}
+}
+
+// Returns the recorded files whose keys aren't in the provided set, sorted by their keys, so that the report is stable.
+// If the command lists the files which succeeded, the files which aren't listed failed.
+// Otherwise, the status of each file is determined by the response to its last request.
+func (tr *TransferRecorder) getUnlistedFiles(listed map[string]bool, listsSucceeded bool) []TransferReportFile {
+	if tr == nil {
+		return nil
+	}
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	var keys []string
+	for key := range tr.transfers {
+		if !listed[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	files := make([]TransferReportFile, 0, len(keys))
+	for _, key := range keys {
+		transfer := tr.transfers[key]
+		file := TransferReportFile{Status: Success, Attempts: transfer.attempts, DurationMillis: transfer.duration().Milliseconds()}
+		switch tr.command {
+		case UploadTransfer:
+			file.Target = tr.artifactoryUrl + key
+		case CopyTransfer, MoveTransfer:
+			file.Source = tr.artifactoryUrl + key
+			file.Target = tr.artifactoryUrl + strings.TrimPrefix(transfer.target, "/")
+		default:
+			file.Source = tr.artifactoryUrl + key
+		}
+		if listsSucceeded || !transfer.accepted {
+			file.Status = Failure
+			file.Error = transfer.err
+			if transfer.accepted || file.Error == "" {
+				file.Error = incompleteTransferError
+			}
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+func (rt *recordedTransfer) duration() time.Duration {
+	if rt.end.Before(rt.start) {
+		return 0
+	}
+	return rt.end.Sub(rt.start)
+}
+
+// Records the requests which are sent through the recording proxy.
+type recordingTransport struct {
+	recorder  *TransferRecorder
+	transport http.RoundTripper
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, target := rt.recorder.getTransferKey(req)
+	if key == "" {
+		return rt.transport.RoundTrip(req)
+	}
+	start := time.Now()
+	resp, err := rt.transport.RoundTrip(req)
+	if err != nil {
+		rt.recorder.record(key, target, req, start, false, err.Error())
+		return resp, err
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		rt.recorder.record(key, target, req, start, true, "")
+		resp.Body = &recordedBody{ReadCloser: resp.Body, onClose: func() { rt.recorder.recordEnd(key) }}
+		return resp, nil
+	}
+	// A file whose checksum isn't found by the server is uploaded with its content after its checksum deploy.
+	if req.Header.Get("X-Checksum-Deploy") == "true" {
+		return resp, nil
+	}
+	var errorBody []byte
+	errorBody, resp.Body, err = readErrorBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	rt.recorder.record(key, target, req, start, false, getResponseError(resp.Status, errorBody))
+	return resp, nil
+}
+
+// Reads the beginning of a response body, and returns a body which is read from its start.
+func readErrorBody(body io.ReadCloser) ([]byte, io.ReadCloser, error) {
+	start, err := io.ReadAll(io.LimitReader(body, maxErrorBodySize))
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	return start, &recordedBody{ReadCloser: body, reader: io.MultiReader(bytes.NewReader(start), body)}, nil
+}
+
+// Returns the error of a failed response, with the error messages returned by Artifactory, or the body of the response otherwise.
+func getResponseError(status string, body []byte) string {
+	var artifactoryErrors struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	var messages []string
+	if json.Unmarshal(body, &artifactoryErrors) == nil {
+		for _, artifactoryError := range artifactoryErrors.Errors {
+			messages = append(messages, artifactoryError.Message)
+		}
+	}
+	message := strings.Join(messages, " ")
+	if message == "" {
+		message = strings.TrimSpace(string(body))
+	}
+	if message == "" {
+		return status
+	}
+	return status + ": " + message
+}
+
+// A response body which is optionally read from another reader, and notifies when it's closed.
+type recordedBody struct {
+	io.ReadCloser
+	reader  io.Reader
+	onClose func()
+}
+
+func (rb *recordedBody) Read(p []byte) (int, error) {
+	if rb.reader != nil {
+		return rb.reader.Read(p)
+	}
+	return rb.ReadCloser.Read(p)
+}
+
+func (rb *recordedBody) Close() error {
+	if rb.onClose != nil {
+		rb.onClose()
+	}
+	return rb.ReadCloser.Close()
+}
@@ -0,0 +1,292 @@
+package summary
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+)
+
+// The version of the transfer report structure.
+// Increase it on any change which isn't backward compatible, so that consumers can rely on the report structure.
+const TransferReportVersion = 1
+
+const junitExtension = ".xml"
+
+// A transfer report summarizes a single run of a file transfer command (upload, download, copy, move or delete).
+// It's written to the file provided by the --summary-file option, either as JSON or as a JUnit XML.
+type TransferReport struct {
+	Version        int        `json:"version"`
+	Command        string     `json:"command"`
+	Status         StatusType `json:"status"`
+	Totals         *Totals    `json:"totals"`
+	StartTime      string     `json:"startTime"`
+	EndTime        string     `json:"endTime"`
+	DurationMillis int64      `json:"durationMillis"`
+	Retries        int        `json:"retries"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// The details of a file in the transfer report.
+// The attempts and duration are omitted for files which no request was sent for, such as in a dry run.
+// The error of a file which failed is the error returned by the server for its last failed request.
+type TransferReportFile struct {
+	Status         StatusType `json:"status"`
+	Source         string     `json:"source,omitempty"`
+	Target         string     `json:"target,omitempty"`
+	Sha256         string     `json:"sha256,omitempty"`
+	Attempts       int        `json:"attempts,omitempty"`
+	DurationMillis int64      `json:"durationMillis,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+func NewTransferReport(command string, startTime time.Time, retries, success, failed int, failNoOp bool, err error) *TransferReport {
+	endTime := time.Now()
+	summaryReport := GetSummaryReport(success, failed, failNoOp, err)
+	report := &TransferReport{
+		Version:        TransferReportVersion,
+		Command:        command,
+		Status:         summaryReport.Status,
+		Totals:         summaryReport.Totals,
+		StartTime:      startTime.UTC().Format(time.RFC3339),
+		EndTime:        endTime.UTC().Format(time.RFC3339),
+		DurationMillis: endTime.Sub(startTime).Milliseconds(),
+		Retries:        retries,
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	return report
+}
+
+// Creates a report record from the details of a transferred file.
+// For uploads, the target is prefixed with the Artifactory URL. For other commands, the source is prefixed.
+func NewTransferReportFile(transferDetails *clientutils.FileTransferDetails, uploaded bool) TransferReportFile {
+	file := TransferReportFile{Status: Success, Source: transferDetails.SourcePath, Target: transferDetails.TargetPath, Sha256: transferDetails.Sha256}
+	if uploaded {
+		file.Target = transferDetails.RtUrl + file.Target
+	} else if file.Source != "" {
+		file.Source = transferDetails.RtUrl + file.Source
+	}
+	return file
+}
+
+// The files of the report. The upload and download commands list the files which succeeded,
+// and the files which failed are the transfers which were recorded by the recorder, but aren't listed by the commands.
+// The copy, move and delete commands only count their files, so all of their files are taken from the recorder.
+type transferReportFiles struct {
+	// Streamed into the report rather than being loaded into memory.
+	reader   *content.ContentReader
+	recorder *TransferRecorder
+	uploaded bool
+}
+
+func (trf *transferReportFiles) isListed() bool {
+	return trf.reader != nil || trf.recorder != nil
+}
+
+func (trf *transferReportFiles) forEach(handler func(file TransferReportFile) error) error {
+	listed := make(map[string]bool)
+	if trf.reader != nil {
+		for transferDetails := new(clientutils.FileTransferDetails); trf.reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+			file := NewTransferReportFile(transferDetails, trf.uploaded)
+			if trf.recorder != nil {
+				key := getListedTransferKey(transferDetails, trf.uploaded)
+				listed[key] = true
+				trf.recorder.addTransferDetails(&file, key)
+			}
+			if err := handler(file); err != nil {
+				return err
+			}
+		}
+		if err := trf.reader.GetError(); err != nil {
+			return err
+		}
+		trf.reader.Reset()
+	}
+	for _, file := range trf.recorder.getUnlistedFiles(listed, trf.reader != nil) {
+		if err := handler(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the key the transfer of a listed file is recorded by. See TransferRecorder.getTransferKey.
+func getListedTransferKey(transferDetails *clientutils.FileTransferDetails, uploaded bool) string {
+	if uploaded {
+		return strings.TrimSuffix(transferDetails.TargetPath, "/")
+	}
+	return transferDetails.SourcePath
+}
+
+// Writes the report to the provided path. Paths with the '.xml' extension are written as JUnit XML, and all others as JSON.
+// The reader holds the details of the transferred files, and the recorder holds their attempts and errors. Both are optional.
+func (tr *TransferReport) WriteToFile(filePath string, reader *content.ContentReader, recorder *TransferRecorder, uploaded bool) (err error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		e := file.Close()
+		if err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	files := &transferReportFiles{reader: reader, recorder: recorder, uploaded: uploaded}
+	if strings.EqualFold(filepath.Ext(filePath), junitExtension) {
+		return tr.writeJunit(file, files)
+	}
+	return tr.writeJson(file, files)
+}
+
+func (tr *TransferReport) writeJson(writer io.Writer, files *transferReportFiles) error {
+	reportContent, err := json.MarshalIndent(tr, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if !files.isListed() {
+		_, err = fmt.Fprintln(writer, string(reportContent))
+		return errorutils.CheckError(err)
+	}
+	// We remove the closing curly bracket in order to append the files array while streaming the reader.
+	if _, err = fmt.Fprint(writer, strings.TrimSuffix(string(reportContent), "\n}")+",\n  \"files\": ["); err != nil {
+		return errorutils.CheckError(err)
+	}
+	separator := "\n    "
+	err = files.forEach(func(file TransferReportFile) error {
+		fileContent, err := json.Marshal(file)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		_, err = fmt.Fprint(writer, separator+string(fileContent))
+		separator = ",\n    "
+		return errorutils.CheckError(err)
+	})
+	if err != nil {
+		return err
+	}
+	if separator != "\n    " {
+		_, err = fmt.Fprint(writer, "\n  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	_, err = fmt.Fprintln(writer, "]\n}")
+	return errorutils.CheckError(err)
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitTestCase struct {
+	XMLName   xml.Name      `xml:"testcase"`
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// Each listed file is reported as a test case, and the error of a file which failed is reported as the message of its failure.
+// The command's error isn't attributed to the files, so it's reported by the system-err element of the test suite.
+func (tr *TransferReport) writeJunit(writer io.Writer, files *transferReportFiles) error {
+	tests, failures := 0, 0
+	err := files.forEach(func(file TransferReportFile) error {
+		tests++
+		if file.Status == Failure {
+			failures++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(writer, xml.Header); err != nil {
+		return errorutils.CheckError(err)
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	suites := xml.StartElement{Name: xml.Name{Local: "testsuites"}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: "name"}, Value: tr.Command},
+		{Name: xml.Name{Local: "tests"}, Value: fmt.Sprint(tests)},
+		{Name: xml.Name{Local: "failures"}, Value: fmt.Sprint(failures)},
+		{Name: xml.Name{Local: "time"}, Value: formatSeconds(tr.DurationMillis)},
+	}}
+	suite := xml.StartElement{Name: xml.Name{Local: "testsuite"}, Attr: append(suites.Attr, xml.Attr{Name: xml.Name{Local: "timestamp"}, Value: tr.StartTime})}
+	if err = encoder.EncodeToken(suites); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = encoder.EncodeToken(suite); err != nil {
+		return errorutils.CheckError(err)
+	}
+	properties := struct {
+		XMLName    xml.Name        `xml:"properties"`
+		Properties []junitProperty `xml:"property"`
+	}{Properties: []junitProperty{
+		{Name: "version", Value: fmt.Sprint(tr.Version)},
+		{Name: "status", Value: StatusTypes[tr.Status]},
+		{Name: "retries", Value: fmt.Sprint(tr.Retries)},
+		{Name: "success", Value: fmt.Sprint(tr.Totals.Success)},
+		{Name: "failure", Value: fmt.Sprint(tr.Totals.Failure)},
+	}}
+	if err = encoder.Encode(properties); err != nil {
+		return errorutils.CheckError(err)
+	}
+	err = files.forEach(func(file TransferReportFile) error {
+		testCase := junitTestCase{ClassName: tr.Command, Name: file.Target}
+		if file.Target == "" {
+			testCase.Name = file.Source
+		}
+		var systemOut []string
+		if file.Sha256 != "" {
+			systemOut = append(systemOut, "sha256: "+file.Sha256)
+		}
+		if file.Attempts > 0 {
+			testCase.Time = formatSeconds(file.DurationMillis)
+			systemOut = append(systemOut, fmt.Sprintf("attempts: %d", file.Attempts))
+		}
+		testCase.SystemOut = strings.Join(systemOut, "\n")
+		if file.Status == Failure {
+			testCase.Failure = &junitFailure{Message: file.Error}
+		}
+		return errorutils.CheckError(encoder.Encode(testCase))
+	})
+	if err != nil {
+		return err
+	}
+	if tr.Error != "" {
+		systemErr := struct {
+			XMLName xml.Name `xml:"system-err"`
+			Content string   `xml:",chardata"`
+		}{Content: tr.Error}
+		if err = encoder.Encode(systemErr); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	if err = encoder.EncodeToken(suite.End()); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = encoder.EncodeToken(suites.End()); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(encoder.Flush())
+}
+
+func formatSeconds(millis int64) string {
+	return fmt.Sprintf("%.3f", float64(millis)/1000)
+}
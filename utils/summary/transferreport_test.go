@@ -0,0 +1,221 @@
+package summary
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+const forbiddenBody = `{"errors":[{"status":403,"message":"Not enough permissions to deploy"}]}`
+
+// Starts recording the requests to a test server, which responds to each request by the status returned by the provided function.
+// Returns the server details, whose URL is the URL of the recording proxy.
+func startRecording(t *testing.T, command TransferCommand, getStatus func(r *http.Request, count int) int) (*TransferRecorder, *config.ServerDetails) {
+	counts := make(map[string]int)
+	var mutex sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		counts[r.Method+r.URL.Path]++
+		count := counts[r.Method+r.URL.Path]
+		mutex.Unlock()
+		status := getStatus(r, count)
+		w.WriteHeader(status)
+		if status == http.StatusForbidden {
+			_, err := io.WriteString(w, forbiddenBody)
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}
+	recorder := NewTransferRecorder(command, serverDetails.ArtifactoryUrl)
+	assert.NoError(t, recorder.Start(serverDetails))
+	return recorder, serverDetails
+}
+
+func sendRequest(t *testing.T, method, url string, headers map[string]string) {
+	req, err := http.NewRequest(method, url, strings.NewReader("content"))
+	assert.NoError(t, err)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if assert.NoError(t, err) {
+		assert.NoError(t, resp.Body.Close())
+	}
+}
+
+func TestTransferReportJson(t *testing.T) {
+	recorder, serverDetails := startRecording(t, UploadTransfer, func(r *http.Request, count int) int {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "a1.in") && count == 1:
+			return http.StatusInternalServerError
+		case r.Header.Get("X-Checksum-Deploy") == "true":
+			return http.StatusNotFound
+		case strings.HasSuffix(r.URL.Path, "a 3.in"):
+			return http.StatusForbidden
+		}
+		return http.StatusCreated
+	})
+	proxyUrl := serverDetails.ArtifactoryUrl
+	// The first file is uploaded on the second attempt, the second after its checksum deploy failed, and the third fails.
+	for i := 0; i < 2; i++ {
+		sendRequest(t, http.MethodPut, proxyUrl+"generic-local/testdata/a/a1.in;build.name=build", nil)
+	}
+	sendRequest(t, http.MethodPut, proxyUrl+"generic-local/testdata/a/a2.in", map[string]string{"X-Checksum-Deploy": "true"})
+	sendRequest(t, http.MethodPut, proxyUrl+"generic-local/testdata/a/a2.in", nil)
+	for i := 0; i < 3; i++ {
+		sendRequest(t, http.MethodPut, proxyUrl+"generic-local/testdata/a/a%203.in", nil)
+	}
+	// Requests which don't upload files aren't recorded.
+	sendRequest(t, http.MethodPost, proxyUrl+"api/search/aql", nil)
+
+	// The files which succeeded are listed by the command, with the URL of the proxy.
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	writer.Write(clientutils.FileTransferDetails{SourcePath: "testdata/a/a1.in", TargetPath: "generic-local/testdata/a/a1.in", RtUrl: proxyUrl, Sha256: "4eb341b5d2762a853d79cc25e622aa8b978eb6e12c3259e2d99dc9dc60d82c5d"})
+	writer.Write(clientutils.FileTransferDetails{SourcePath: "testdata/a/a2.in", TargetPath: "generic-local/testdata/a/a2.in", RtUrl: proxyUrl})
+	assert.NoError(t, writer.Close())
+	result := new(utils.Result)
+	result.SetReader(content.NewContentReader(writer.GetFilePath(), content.DefaultKey))
+	assert.NoError(t, recorder.Stop(result))
+	defer func() {
+		assert.NoError(t, result.Reader().Close())
+	}()
+	// The URL of the server is restored once the recording stops.
+	artifactoryUrl := serverDetails.ArtifactoryUrl
+	assert.NotEqual(t, proxyUrl, artifactoryUrl)
+
+	report := NewTransferReport("rt upload", time.Now(), 3, 2, 1, false, errors.New("upload failed"))
+	reportPath := filepath.Join(t.TempDir(), "summary.json")
+	assert.NoError(t, report.WriteToFile(reportPath, result.Reader(), recorder, true))
+
+	reportContent, err := os.ReadFile(reportPath)
+	assert.NoError(t, err)
+	var actual struct {
+		TransferReport
+		Files []TransferReportFile `json:"files"`
+	}
+	assert.NoError(t, json.Unmarshal(reportContent, &actual))
+	assert.Equal(t, TransferReportVersion, actual.Version)
+	assert.Equal(t, "rt upload", actual.Command)
+	assert.Equal(t, Failure, actual.Status)
+	assert.Equal(t, &Totals{Success: 2, Failure: 1}, actual.Totals)
+	assert.Equal(t, 3, actual.Retries)
+	assert.Equal(t, "upload failed", actual.Error)
+	if assert.Len(t, actual.Files, 3) {
+		assert.Equal(t, Success, actual.Files[0].Status)
+		assert.Equal(t, "testdata/a/a1.in", actual.Files[0].Source)
+		assert.Equal(t, artifactoryUrl+"generic-local/testdata/a/a1.in", actual.Files[0].Target)
+		assert.Equal(t, "4eb341b5d2762a853d79cc25e622aa8b978eb6e12c3259e2d99dc9dc60d82c5d", actual.Files[0].Sha256)
+		assert.Equal(t, 2, actual.Files[0].Attempts)
+		assert.Empty(t, actual.Files[0].Error)
+		assert.Equal(t, Success, actual.Files[1].Status)
+		assert.Equal(t, artifactoryUrl+"generic-local/testdata/a/a2.in", actual.Files[1].Target)
+		assert.Equal(t, 1, actual.Files[1].Attempts)
+		assert.Equal(t, Failure, actual.Files[2].Status)
+		assert.Equal(t, artifactoryUrl+"generic-local/testdata/a/a 3.in", actual.Files[2].Target)
+		assert.Equal(t, 3, actual.Files[2].Attempts)
+		assert.Equal(t, "403 Forbidden: Not enough permissions to deploy", actual.Files[2].Error)
+	}
+}
+
+func TestTransferReportJsonWithoutFiles(t *testing.T) {
+	report := NewTransferReport("rt copy", time.Now(), 3, 5, 0, false, nil)
+	reportPath := filepath.Join(t.TempDir(), "summary.json")
+	assert.NoError(t, report.WriteToFile(reportPath, nil, nil, false))
+
+	reportContent, err := os.ReadFile(reportPath)
+	assert.NoError(t, err)
+	var actual map[string]interface{}
+	assert.NoError(t, json.Unmarshal(reportContent, &actual))
+	assert.Equal(t, "success", actual["status"])
+	// Files aren't listed without the details of the command's files.
+	assert.NotContains(t, actual, "files")
+	assert.NotContains(t, actual, "error")
+}
+
+func TestTransferReportJunit(t *testing.T) {
+	recorder, serverDetails := startRecording(t, MoveTransfer, func(r *http.Request, _ int) int {
+		if strings.HasSuffix(r.URL.Path, "b.in") {
+			return http.StatusForbidden
+		}
+		return http.StatusOK
+	})
+	artifactoryUrl := recorder.artifactoryUrl
+	sendRequest(t, http.MethodPost, serverDetails.ArtifactoryUrl+"api/move/generic-local/a.in?to=/target-local/a.in", nil)
+	sendRequest(t, http.MethodPost, serverDetails.ArtifactoryUrl+"api/move/generic-local/b.in?to=/target-local/b.in", nil)
+	// The move command doesn't list its files, so their statuses are taken from the responses.
+	assert.NoError(t, recorder.Stop(&utils.Result{}))
+	report := NewTransferReport("rt move", time.Now(), 3, 1, 1, false, errors.New("move failed"))
+	reportPath := filepath.Join(t.TempDir(), "summary.xml")
+	assert.NoError(t, report.WriteToFile(reportPath, nil, recorder, false))
+
+	reportContent, err := os.ReadFile(reportPath)
+	assert.NoError(t, err)
+	var actual struct {
+		Tests     int `xml:"tests,attr"`
+		Failures  int `xml:"failures,attr"`
+		TestSuite struct {
+			TestCases []struct {
+				Name      string        `xml:"name,attr"`
+				Time      string        `xml:"time,attr"`
+				SystemOut string        `xml:"system-out"`
+				Failure   *junitFailure `xml:"failure"`
+			} `xml:"testcase"`
+			SystemErr string `xml:"system-err"`
+		} `xml:"testsuite"`
+	}
+	assert.NoError(t, xml.Unmarshal(reportContent, &actual))
+	assert.Equal(t, 2, actual.Tests)
+	assert.Equal(t, 1, actual.Failures)
+	assert.Equal(t, "move failed", actual.TestSuite.SystemErr)
+	testCases := actual.TestSuite.TestCases
+	if assert.Len(t, testCases, 2) {
+		assert.Equal(t, artifactoryUrl+"target-local/a.in", testCases[0].Name)
+		assert.Equal(t, "attempts: 1", testCases[0].SystemOut)
+		assert.NotEmpty(t, testCases[0].Time)
+		assert.Nil(t, testCases[0].Failure)
+		assert.Equal(t, artifactoryUrl+"target-local/b.in", testCases[1].Name)
+		if assert.NotNil(t, testCases[1].Failure) {
+			assert.Equal(t, "403 Forbidden: Not enough permissions to deploy", testCases[1].Failure.Message)
+		}
+	}
+}
+
+func TestTransferReportJunitWithoutFiles(t *testing.T) {
+	// No test case is made up for the failures of commands which don't list their files.
+	report := NewTransferReport("rt delete", time.Now(), 3, 1, 2, false, errors.New("delete failed"))
+	reportPath := filepath.Join(t.TempDir(), "summary.xml")
+	assert.NoError(t, report.WriteToFile(reportPath, nil, nil, false))
+
+	reportContent, err := os.ReadFile(reportPath)
+	assert.NoError(t, err)
+	var actual struct {
+		Tests     int `xml:"tests,attr"`
+		Failures  int `xml:"failures,attr"`
+		TestSuite struct {
+			TestCases []junitTestCase `xml:"testcase"`
+			SystemErr string          `xml:"system-err"`
+		} `xml:"testsuite"`
+	}
+	assert.NoError(t, xml.Unmarshal(reportContent, &actual))
+	assert.Zero(t, actual.Tests)
+	assert.Zero(t, actual.Failures)
+	assert.Empty(t, actual.TestSuite.TestCases)
+	assert.Equal(t, "delete failed", actual.TestSuite.SystemErr)
+}
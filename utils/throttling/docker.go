@@ -0,0 +1,119 @@
+package throttling
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	containerutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Pushes the layers of an image through a throttling proxy to its registry, before the image is pushed by the 'docker push' command.
+// The layers are uploaded by the Docker daemon, which is local to the proxy only if it runs on the same Linux machine.
+// The 'docker push' command then finds the layers in the registry, so that only the image manifest is pushed without throttling.
+func PushLayersThroughProxy(imageTag string, containerManagerType containerutils.ContainerManagerType, serverDetails *config.ServerDetails, limits *Limits) (err error) {
+	if !limits.IsSet() {
+		return nil
+	}
+	if containerManagerType != containerutils.DockerClient || !isLocalDockerDaemon() {
+		log.Warn(fmt.Sprintf("The image layers are uploaded by a %s daemon which isn't local to JFrog CLI, and therefore aren't throttled by the %s and %s environment variables.",
+			containerManagerType, MaxBandwidthEnv, MaxRequestsPerSecondEnv))
+		return nil
+	}
+	registry, err := containerutils.NewImage(imageTag).GetRegistry()
+	if err != nil {
+		return err
+	}
+	scheme := "https"
+	if artifactoryUrl, e := url.Parse(serverDetails.ArtifactoryUrl); e == nil && artifactoryUrl.Scheme != "" {
+		scheme = artifactoryUrl.Scheme
+	}
+	proxy, err := NewProxy(scheme+"://"+registry, limits)
+	if err != nil {
+		return err
+	}
+	if err = proxy.SetServerDetails(serverDetails); err != nil {
+		return err
+	}
+	if err = proxy.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		if e := proxy.Close(); err == nil {
+			err = e
+		}
+	}()
+
+	// The proxy doesn't authenticate the requests it forwards, since any local process can send requests to it.
+	// The Docker daemon authenticates by itself, with the credentials of a Docker config which is private to this push.
+	dockerConfigDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := fileutils.RemoveTempDir(dockerConfigDir); err == nil {
+			err = e
+		}
+	}()
+	if err = writeDockerConfig(dockerConfigDir, proxy.Host(), serverDetails); err != nil {
+		return err
+	}
+
+	// The Docker daemon pushes to the loopback interface over HTTP, since it trusts it as an insecure registry by default.
+	proxiedTag := proxy.Host() + strings.TrimPrefix(imageTag, registry)
+	if err = runDockerCmd(dockerConfigDir, "tag", imageTag, proxiedTag); err != nil {
+		return err
+	}
+	defer func() {
+		if e := runDockerCmd(dockerConfigDir, "rmi", proxiedTag); err == nil {
+			err = e
+		}
+	}()
+	log.Info("Pushing the layers of " + imageTag + " through the throttling proxy...")
+	return runDockerCmd(dockerConfigDir, "push", proxiedTag)
+}
+
+func isLocalDockerDaemon() bool {
+	dockerHost := os.Getenv("DOCKER_HOST")
+	return runtime.GOOS == "linux" && (dockerHost == "" || strings.HasPrefix(dockerHost, "unix://"))
+}
+
+// Writes a Docker config with the credentials of the server for the proxy's host.
+// The config directory is created with permissions which allow only the current user to read it.
+func writeDockerConfig(dockerConfigDir, proxyHost string, serverDetails *config.ServerDetails) error {
+	user, password := serverDetails.User, serverDetails.Password
+	if password == "" {
+		password = serverDetails.AccessToken
+		if user == "" {
+			user = auth.ExtractUsernameFromAccessToken(password)
+		}
+	}
+	dockerConfig := map[string]map[string]map[string]string{"auths": {}}
+	if user != "" && password != "" {
+		dockerConfig["auths"][proxyHost] = map[string]string{"auth": base64.StdEncoding.EncodeToString([]byte(user + ":" + password))}
+	}
+	content, err := json.Marshal(dockerConfig)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(dockerConfigDir, "config.json"), content, 0600))
+}
+
+func runDockerCmd(dockerConfigDir string, args ...string) error {
+	cmd := exec.Command("docker", args...)
+	cmd.Env = append(os.Environ(), "DOCKER_CONFIG="+dockerConfigDir)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
@@ -0,0 +1,156 @@
+package throttling
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/auth/cert"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Returns the number of requests and bytes a proxied request is counted for.
+type Weigher func(req *http.Request) (requests float64, bytes int64, err error)
+
+// Proxy is a local reverse proxy, which throttles the requests to a server.
+// It allows throttling the file transfers which aren't performed by JFrog CLI's own HTTP clients.
+// By default, every request is counted once, and the contents of the requests and responses are throttled while they are transferred.
+type Proxy struct {
+	target    *url.URL
+	weigher   Weigher
+	bandwidth *tokenBucket
+	requests  *tokenBucket
+	proxy     *httputil.ReverseProxy
+	listener  net.Listener
+	server    *http.Server
+}
+
+func NewProxy(targetUrl string, limits *Limits) (*Proxy, error) {
+	target, err := url.Parse(targetUrl)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	p := &Proxy{target: target}
+	p.bandwidth, p.requests = limits.newTokenBuckets()
+	p.proxy = &httputil.ReverseProxy{Director: p.direct, ModifyResponse: p.modifyResponse}
+	return p, nil
+}
+
+// Counts the requests by the provided weigher. The contents of the requests and responses are then no longer throttled.
+func (p *Proxy) SetWeigher(weigher Weigher) *Proxy {
+	p.weigher = weigher
+	return p
+}
+
+// Connects to the server with the trusted certificates, the client certificate and the TLS verification of the provided server details.
+func (p *Proxy) SetServerDetails(serverDetails *config.ServerDetails) error {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	certsDir, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return err
+	}
+	if _, err = cert.GetTransportWithLoadedCert(certsDir, serverDetails.InsecureTls, transport); err != nil {
+		return err
+	}
+	if transport.TLSClientConfig == nil {
+		//#nosec G402 -- Skipping insecure tls verification was requested by the user.
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: serverDetails.InsecureTls}
+	}
+	if serverDetails.ClientCertPath != "" {
+		certificate, err := tls.LoadX509KeyPair(serverDetails.ClientCertPath, serverDetails.ClientCertKeyPath)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+	p.proxy.Transport = transport
+	return nil
+}
+
+// Wraps the transport the requests are sent to the server by, such as to observe their responses.
+// Should be called after SetServerDetails, so that the transport of the server details is wrapped.
+func (p *Proxy) WrapTransport(wrap func(transport http.RoundTripper) http.RoundTripper) *Proxy {
+	transport := p.proxy.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	p.proxy.Transport = wrap(transport)
+	return p
+}
+
+// Starts listening on a random port of the loopback interface.
+func (p *Proxy) Start() (err error) {
+	p.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	p.server = &http.Server{Handler: p, ReadHeaderTimeout: time.Minute}
+	go func() {
+		if e := p.server.Serve(p.listener); e != nil && e != http.ErrServerClosed {
+			log.Error("The throttling proxy stopped unexpectedly: " + e.Error())
+		}
+	}()
+	return nil
+}
+
+// Returns the host and port the proxy listens on.
+func (p *Proxy) Host() string {
+	return p.listener.Addr().String()
+}
+
+// Returns the URL of the proxy, with the path of the target URL.
+func (p *Proxy) Url() string {
+	return (&url.URL{Scheme: "http", Host: p.Host(), Path: p.target.Path}).String()
+}
+
+func (p *Proxy) Close() error {
+	if p.server == nil {
+		return nil
+	}
+	return errorutils.CheckError(p.server.Close())
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if p.weigher == nil {
+		p.requests.wait(1)
+		p.proxy.ServeHTTP(w, req)
+		return
+	}
+	requests, bytes, err := p.weigher(req)
+	if err != nil {
+		log.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	p.requests.wait(requests)
+	p.bandwidth.wait(float64(bytes))
+	p.proxy.ServeHTTP(w, req)
+}
+
+func (p *Proxy) direct(req *http.Request) {
+	req.URL.Scheme = p.target.Scheme
+	req.URL.Host = p.target.Host
+	req.Host = p.target.Host
+	if p.weigher == nil && p.bandwidth != nil && req.Body != nil && req.Body != http.NoBody {
+		req.Body = &throttledReader{ReadCloser: req.Body, bandwidth: p.bandwidth}
+	}
+}
+
+// Redirects to the server, such as the locations of the Docker blob uploads, are sent back through the proxy.
+func (p *Proxy) modifyResponse(resp *http.Response) error {
+	if location, err := resp.Location(); err == nil && location.Host == p.target.Host {
+		location.Scheme = "http"
+		location.Host = p.Host()
+		resp.Header.Set("Location", location.String())
+	}
+	if p.weigher == nil && p.bandwidth != nil {
+		resp.Body = &throttledReader{ReadCloser: resp.Body, bandwidth: p.bandwidth}
+	}
+	return nil
+}
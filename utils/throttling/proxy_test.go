@@ -0,0 +1,104 @@
+package throttling
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferfiles/api"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func startProxy(t *testing.T, targetUrl string, limits *Limits) *Proxy {
+	proxy, err := NewProxy(targetUrl, limits)
+	assert.NoError(t, err)
+	assert.NoError(t, proxy.Start())
+	t.Cleanup(func() {
+		assert.NoError(t, proxy.Close())
+	})
+	return proxy
+}
+
+func TestProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.Header().Set("Location", "http://"+r.Host+"/v2/uploads/1")
+		w.WriteHeader(http.StatusAccepted)
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	proxy := startProxy(t, server.URL, &Limits{RequestsPerSecond: 10})
+
+	// Every request is counted.
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		// The client authenticates by itself, and its headers are forwarded as is.
+		req, err := http.NewRequest(http.MethodPost, proxy.Url()+"/v2/uploads", strings.NewReader("layer"))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer token")
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		content, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Equal(t, "layer", string(content))
+		// The redirects to the server are sent back through the proxy.
+		assert.Equal(t, "http://"+proxy.Host()+"/v2/uploads/1", resp.Header.Get("Location"))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 290*time.Millisecond)
+}
+
+func TestTransferFilesProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.URL.Path, "/artifactory/api/"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	proxy, serverDetails, err := StartTransferFilesProxy(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, &Limits{BytesPerSecond: 10 << 10, RequestsPerSecond: 100})
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, proxy.Close())
+	}()
+	assert.Equal(t, "http://"+proxy.Host()+"/artifactory/", serverDetails.ArtifactoryUrl)
+
+	// Requests which aren't upload chunks aren't counted.
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		resp, err := http.Get(serverDetails.ArtifactoryUrl + "api/system/version")
+		assert.NoError(t, err)
+		assert.NoError(t, resp.Body.Close())
+	}
+	assert.Less(t, time.Since(start), 90*time.Millisecond)
+
+	// The files of an upload chunk are counted by their sizes.
+	content, err := json.Marshal(api.UploadChunk{UploadCandidates: []api.FileRepresentation{{Repo: "repo", Name: "a", Size: 2 << 10}, {Repo: "repo", Name: "b", Size: 1 << 10}}})
+	assert.NoError(t, err)
+	start = time.Now()
+	resp, err := http.Post(serverDetails.ArtifactoryUrl+uploadChunkApi, "application/json", strings.NewReader(string(content)))
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestWriteDockerConfig(t *testing.T) {
+	dockerConfigDir := t.TempDir()
+	assert.NoError(t, writeDockerConfig(dockerConfigDir, "127.0.0.1:5000", &config.ServerDetails{User: "user", Password: "password"}))
+	content, err := os.ReadFile(filepath.Join(dockerConfigDir, "config.json"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"auths":{"127.0.0.1:5000":{"auth":"dXNlcjpwYXNzd29yZA=="}}}`, string(content))
+	info, err := os.Stat(filepath.Join(dockerConfigDir, "config.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
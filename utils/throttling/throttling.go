@@ -0,0 +1,312 @@
+package throttling
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+const (
+	// Default values for the --max-bandwidth and --max-requests-per-second options, allowing to cap the transfers globally.
+	MaxBandwidthEnv         = "JFROG_CLI_MAX_BANDWIDTH"
+	MaxRequestsPerSecondEnv = "JFROG_CLI_MAX_REQUESTS_PER_SECOND"
+)
+
+// The size units accepted by the --max-bandwidth option, ordered so that longer suffixes are matched first.
+var bandwidthUnits = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// Limits of the file transfers, shared by all the worker threads of a command. Zero means unlimited.
+type Limits struct {
+	BytesPerSecond    int64
+	RequestsPerSecond float64
+}
+
+func (l *Limits) IsSet() bool {
+	return l != nil && (l.BytesPerSecond > 0 || l.RequestsPerSecond > 0)
+}
+
+// Returns the limits from the provided option values. Empty values fall back to the matching environment variables.
+func GetLimits(maxBandwidth, maxRequestsPerSecond string) (*Limits, error) {
+	if maxBandwidth == "" {
+		maxBandwidth = os.Getenv(MaxBandwidthEnv)
+	}
+	if maxRequestsPerSecond == "" {
+		maxRequestsPerSecond = os.Getenv(MaxRequestsPerSecondEnv)
+	}
+	var err error
+	limits := new(Limits)
+	if limits.BytesPerSecond, err = ParseBandwidth(maxBandwidth); err != nil {
+		return nil, err
+	}
+	if limits.RequestsPerSecond, err = ParseRequestsPerSecond(maxRequestsPerSecond); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// Parses a bandwidth such as '20MB/s', '512KB' or '1048576' to bytes per second.
+// The units are binary, so that 1KB is 1024 bytes. An empty value means unlimited and is parsed to 0.
+func ParseBandwidth(bandwidth string) (int64, error) {
+	value := strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(bandwidth)), "/S")
+	if value == "" {
+		return 0, nil
+	}
+	multiplier := float64(1)
+	for _, unit := range bandwidthUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, multiplier = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), unit.multiplier
+			break
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		return 0, errorutils.CheckErrorf("invalid bandwidth '%s'. The bandwidth should be a positive number, optionally followed by one of the B, KB, MB or GB units, such as '20MB/s'", bandwidth)
+	}
+	bytesPerSecond := int64(number * multiplier)
+	if bytesPerSecond < 1 {
+		bytesPerSecond = 1
+	}
+	return bytesPerSecond, nil
+}
+
+// Parses the maximum number of requests per second. An empty value means unlimited and is parsed to 0.
+func ParseRequestsPerSecond(requestsPerSecond string) (float64, error) {
+	value := strings.TrimSpace(requestsPerSecond)
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		return 0, errorutils.CheckErrorf("invalid maximum requests per second '%s'. The value should be a positive number", requestsPerSecond)
+	}
+	return number, nil
+}
+
+// A token bucket, which allows consuming tokens at a constant rate.
+// Consumers may go into debt, and then wait until the debt is repaid, so that the rate is kept across all the consumers.
+type tokenBucket struct {
+	mutex    sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+// The bucket starts empty, so that the rate is kept from the very first transfer.
+func newTokenBucket(rate, capacity float64) *tokenBucket {
+	return &tokenBucket{rate: rate, capacity: capacity, last: time.Now()}
+}
+
+// Returns the buckets of the bandwidth and requests limits. A bucket is nil if its limit isn't set.
+func (l *Limits) newTokenBuckets() (bandwidth, requests *tokenBucket) {
+	if l.BytesPerSecond > 0 {
+		bandwidth = newTokenBucket(float64(l.BytesPerSecond), float64(l.BytesPerSecond))
+	}
+	if l.RequestsPerSecond > 0 {
+		// Allow a burst of a single request when the rate is lower than one request per second.
+		capacity := l.RequestsPerSecond
+		if capacity < 1 {
+			capacity = 1
+		}
+		requests = newTokenBucket(l.RequestsPerSecond, capacity)
+	}
+	return
+}
+
+// Consumes n tokens, while waiting until they are available. A nil bucket doesn't limit anything.
+func (tb *tokenBucket) wait(n float64) {
+	if tb == nil || n <= 0 {
+		return
+	}
+	tb.mutex.Lock()
+	now := time.Now()
+	tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+	if tb.tokens > tb.capacity {
+		tb.tokens = tb.capacity
+	}
+	tb.last = now
+	tb.tokens -= n
+	debt := -tb.tokens
+	tb.mutex.Unlock()
+	if debt > 0 {
+		time.Sleep(time.Duration(debt / tb.rate * float64(time.Second)))
+	}
+}
+
+// ProgressMgr throttles the file transfers of a command.
+// The file transfers of the upload and download services read their content through the progress indicators,
+// so wrapping them allows sharing the limits across all the worker threads.
+// The wrapped progress manager is optional, so that the transfers are throttled also when no progress bar is displayed.
+// Each read through a progress indicator is a request, so every part of a split download is counted.
+type ProgressMgr struct {
+	ioUtils.ProgressMgr
+	bandwidth  *tokenBucket
+	requests   *tokenBucket
+	progresses map[int]*progress
+	lastId     int
+	mutex      sync.Mutex
+	// Set when the requests of an upload command are counted by its collected files. See CountUploadRequests.
+	minChecksumDeploySize func() int64
+}
+
+func NewProgressMgr(progressMgr ioUtils.ProgressMgr, limits *Limits) *ProgressMgr {
+	throttled := &ProgressMgr{ProgressMgr: progressMgr, progresses: make(map[int]*progress)}
+	throttled.bandwidth, throttled.requests = limits.newTokenBuckets()
+	return throttled
+}
+
+// The files whose size is at least the minimum checksum deploy size are first deployed by their checksums, with requests
+// that don't go through the progress indicators. Therefore, the requests of an upload command are counted by its files instead:
+// Each file is counted once when it is collected, for its checksum deploy or its upload, and the upload of a file which
+// follows a checksum deploy is counted again.
+// The minimum checksum deploy size is read when the files are uploaded, since it is set by the command when it runs.
+func (p *ProgressMgr) CountUploadRequests(minChecksumDeploySize func() int64) *ProgressMgr {
+	p.minChecksumDeploySize = minChecksumDeploySize
+	return p
+}
+
+func (p *ProgressMgr) NewProgressReader(total int64, label, path string) ioUtils.Progress {
+	throttled := &progress{bandwidth: p.bandwidth}
+	if p.minChecksumDeploySize == nil {
+		throttled.requests = p.requests
+	} else if total >= p.minChecksumDeploySize() {
+		// The file wasn't deployed by its checksum, so it is uploaded by another request.
+		p.requests.wait(1)
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.ProgressMgr != nil {
+		throttled.Progress = p.ProgressMgr.NewProgressReader(total, label, path)
+		throttled.id = throttled.Progress.GetId()
+	} else {
+		p.lastId++
+		throttled.id = p.lastId
+	}
+	p.progresses[throttled.id] = throttled
+	return throttled
+}
+
+func (p *ProgressMgr) GetProgress(id int) ioUtils.Progress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if throttled, exists := p.progresses[id]; exists {
+		return throttled
+	}
+	return nil
+}
+
+func (p *ProgressMgr) RemoveProgress(id int) {
+	p.mutex.Lock()
+	delete(p.progresses, id)
+	p.mutex.Unlock()
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.RemoveProgress(id)
+	}
+}
+
+func (p *ProgressMgr) SetProgressState(id int, state string) {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.SetProgressState(id, state)
+	}
+}
+
+func (p *ProgressMgr) Quit() error {
+	if p.ProgressMgr != nil {
+		return p.ProgressMgr.Quit()
+	}
+	return nil
+}
+
+// Called by the upload command once for each file it collects, before the file is uploaded.
+func (p *ProgressMgr) IncGeneralProgressTotalBy(n int64) {
+	if p.minChecksumDeploySize != nil {
+		p.requests.wait(float64(n))
+	}
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.IncGeneralProgressTotalBy(n)
+	}
+}
+
+func (p *ProgressMgr) SetHeadlineMsg(msg string) {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.SetHeadlineMsg(msg)
+	}
+}
+
+func (p *ProgressMgr) ClearHeadlineMsg() {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.ClearHeadlineMsg()
+	}
+}
+
+func (p *ProgressMgr) InitProgressReaders() {
+	if p.ProgressMgr != nil {
+		p.ProgressMgr.InitProgressReaders()
+	}
+}
+
+type progress struct {
+	ioUtils.Progress
+	id        int
+	bandwidth *tokenBucket
+	// Nil if the requests are counted by the files of an upload command.
+	requests *tokenBucket
+}
+
+// Called once for each request which transfers content, such as each part of a split download.
+func (p *progress) ActionWithProgress(reader io.Reader) io.Reader {
+	p.requests.wait(1)
+	if p.Progress != nil {
+		reader = p.Progress.ActionWithProgress(reader)
+	}
+	if p.bandwidth == nil || reader == nil {
+		return reader
+	}
+	readCloser, ok := reader.(io.ReadCloser)
+	if !ok {
+		readCloser = io.NopCloser(reader)
+	}
+	return &throttledReader{ReadCloser: readCloser, bandwidth: p.bandwidth}
+}
+
+func (p *progress) Abort() {
+	if p.Progress != nil {
+		p.Progress.Abort()
+	}
+}
+
+func (p *progress) GetId() int {
+	return p.id
+}
+
+// Wraps an io.Reader and waits for the bandwidth limit after each read.
+type throttledReader struct {
+	io.ReadCloser
+	bandwidth *tokenBucket
+}
+
+func (tr *throttledReader) Read(p []byte) (n int, err error) {
+	// Avoid reading more than the bucket's capacity at once, so that the transfer stays smooth.
+	if maxRead := int(tr.bandwidth.capacity); len(p) > maxRead {
+		p = p[:maxRead]
+	}
+	n, err = tr.ReadCloser.Read(p)
+	if n > 0 {
+		tr.bandwidth.wait(float64(n))
+	}
+	return
+}
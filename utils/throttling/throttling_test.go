@@ -0,0 +1,104 @@
+package throttling
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	tests := []struct {
+		bandwidth     string
+		expectedBytes int64
+		expectError   bool
+	}{
+		{"", 0, false},
+		{"1024", 1024, false},
+		{"20MB/s", 20 << 20, false},
+		{"512 kb/s", 512 << 10, false},
+		{"1.5GB", 3 << 29, false},
+		{"100B/s", 100, false},
+		{"0", 0, true},
+		{"-1MB", 0, true},
+		{"fast", 0, true},
+		{"20Mbps", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.bandwidth, func(t *testing.T) {
+			bytesPerSecond, err := ParseBandwidth(test.bandwidth)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedBytes, bytesPerSecond)
+		})
+	}
+}
+
+func TestGetLimitsFromEnv(t *testing.T) {
+	t.Setenv(MaxBandwidthEnv, "1MB/s")
+	t.Setenv(MaxRequestsPerSecondEnv, "5")
+	limits, err := GetLimits("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Limits{BytesPerSecond: 1 << 20, RequestsPerSecond: 5}, limits)
+
+	// The options override the environment variables.
+	limits, err = GetLimits("2KB", "0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, &Limits{BytesPerSecond: 2 << 10, RequestsPerSecond: 0.5}, limits)
+
+	t.Setenv(MaxRequestsPerSecondEnv, "many")
+	_, err = GetLimits("", "")
+	assert.Error(t, err)
+}
+
+func TestThrottledTransfer(t *testing.T) {
+	progressMgr := NewProgressMgr(nil, &Limits{BytesPerSecond: 40 << 10, RequestsPerSecond: 10})
+	start := time.Now()
+	// Two concurrent transfers of 5KB each share the 40KB/s bandwidth, so they should take at least 0.25 seconds.
+	done := make(chan error)
+	for i := 0; i < 2; i++ {
+		go func() {
+			progress := progressMgr.NewProgressReader(5<<10, "Uploading", "path")
+			defer progressMgr.RemoveProgress(progress.GetId())
+			content, err := io.ReadAll(progress.ActionWithProgress(bytes.NewReader(make([]byte, 5<<10))))
+			if err == nil && len(content) != 5<<10 {
+				err = io.ErrUnexpectedEOF
+			}
+			done <- err
+		}()
+	}
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-done)
+	assert.GreaterOrEqual(t, time.Since(start), 240*time.Millisecond)
+	assert.Empty(t, progressMgr.progresses)
+}
+
+func TestRequestsCounting(t *testing.T) {
+	// Every part of a split download is a request.
+	progressMgr := NewProgressMgr(nil, &Limits{RequestsPerSecond: 10})
+	start := time.Now()
+	progress := progressMgr.NewProgressReader(4, "Downloading", "path")
+	for i := 0; i < 3; i++ {
+		_, err := io.ReadAll(progressMgr.GetProgress(progress.GetId()).ActionWithProgress(bytes.NewReader([]byte{0})))
+		assert.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 290*time.Millisecond)
+
+	// The requests of an upload command are counted by its files, and by the files which are larger than the minimum checksum deploy size.
+	progressMgr = NewProgressMgr(nil, &Limits{RequestsPerSecond: 10}).CountUploadRequests(func() int64 { return 10 })
+	start = time.Now()
+	progressMgr.IncGeneralProgressTotalBy(2)
+	for _, size := range []int64{5, 10} {
+		progress = progressMgr.NewProgressReader(size, "Uploading", "path")
+		_, err := io.ReadAll(progress.ActionWithProgress(bytes.NewReader(make([]byte, size))))
+		assert.NoError(t, err)
+	}
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 290*time.Millisecond)
+	assert.Less(t, elapsed, 390*time.Millisecond)
+}
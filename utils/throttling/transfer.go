@@ -0,0 +1,54 @@
+package throttling
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferfiles/api"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const uploadChunkApi = "api/plugins/execute/uploadChunk"
+
+// Starts a throttling proxy to the source Artifactory of the 'rt transfer-files' command, and returns the server details to connect through it.
+// The files are transferred by the data transfer plugin of the source Artifactory, so the plugin is throttled by the chunks of files it is requested to upload.
+func StartTransferFilesProxy(sourceServerDetails *config.ServerDetails, limits *Limits) (*Proxy, *config.ServerDetails, error) {
+	proxy, err := NewProxy(sourceServerDetails.ArtifactoryUrl, limits)
+	if err != nil {
+		return nil, nil, err
+	}
+	proxy.SetWeigher(weighUploadChunk)
+	if err = proxy.SetServerDetails(sourceServerDetails); err != nil {
+		return nil, nil, err
+	}
+	if err = proxy.Start(); err != nil {
+		return nil, nil, err
+	}
+	proxiedServerDetails := *sourceServerDetails
+	proxiedServerDetails.ArtifactoryUrl = proxy.Url()
+	return proxy, &proxiedServerDetails, nil
+}
+
+// Counts a request for each file of an upload chunk, and the sizes of its files. The other requests to the source Artifactory aren't counted.
+func weighUploadChunk(req *http.Request) (requests float64, size int64, err error) {
+	if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, uploadChunkApi) || req.Body == nil {
+		return
+	}
+	content, err := io.ReadAll(req.Body)
+	if err != nil {
+		return 0, 0, errorutils.CheckError(err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(content))
+	var chunk api.UploadChunk
+	if err = json.Unmarshal(content, &chunk); err != nil {
+		return 0, 0, errorutils.CheckError(err)
+	}
+	for _, file := range chunk.UploadCandidates {
+		size += file.Size
+	}
+	return float64(len(chunk.UploadCandidates)), size, nil
+}
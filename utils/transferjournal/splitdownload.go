@@ -0,0 +1,363 @@
+package transferjournal
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The maximal number of split downloads which wait for a thread.
+const splitDownloadsCapacity = 10000
+
+// A download command, which downloads the files that are large enough to be split by itself, before running the download.
+// The parts of these files are kept in the journal's directory until they are merged, so that a split download which was
+// interrupted continues from where it stopped when the command is rerun, rather than from the start.
+// The download then skips these files, since they already exist locally with the same checksum.
+type ResumableDownloadCommand struct {
+	*generic.DownloadCommand
+	journal            *Journal
+	retryWaitMilliSecs int
+	progress           ioUtils.ProgressMgr
+}
+
+func NewResumableDownloadCommand(downloadCommand *generic.DownloadCommand, journal *Journal) *ResumableDownloadCommand {
+	return &ResumableDownloadCommand{DownloadCommand: downloadCommand, journal: journal}
+}
+
+func (rdc *ResumableDownloadCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *ResumableDownloadCommand {
+	rdc.retryWaitMilliSecs = retryWaitMilliSecs
+	rdc.DownloadCommand.SetRetryWaitMilliSecs(retryWaitMilliSecs)
+	return rdc
+}
+
+func (rdc *ResumableDownloadCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	rdc.progress = progress
+	rdc.DownloadCommand.SetProgress(progress)
+}
+
+func (rdc *ResumableDownloadCommand) Run() error {
+	if err := rdc.downloadSplitFiles(); err != nil {
+		return err
+	}
+	return rdc.DownloadCommand.Run()
+}
+
+// Downloads the files which are large enough to be split in parallel, by the number of threads of the download command.
+func (rdc *ResumableDownloadCommand) downloadSplitFiles() error {
+	configuration := rdc.Configuration()
+	if rdc.DryRun() || configuration.SplitCount == 0 || configuration.MinSplitSize < 0 {
+		return nil
+	}
+	serverDetails, err := rdc.ServerDetails()
+	if err != nil {
+		return err
+	}
+	serviceManager, err := utils.CreateServiceManager(serverDetails, rdc.Retries(), rdc.retryWaitMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	threads := configuration.Threads
+	if threads < 1 {
+		threads = 1
+	}
+	producerConsumer := parallel.NewRunner(threads, splitDownloadsCapacity, false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	go func() {
+		defer producerConsumer.Done()
+		for i := range rdc.Spec().Files {
+			if e := rdc.addSplitFilesOfSpec(producerConsumer, errorsQueue, serviceManager, rdc.Spec().Get(i)); e != nil {
+				errorsQueue.AddError(e)
+				return
+			}
+		}
+	}()
+	producerConsumer.Run()
+	return errorsQueue.GetError()
+}
+
+// Adds the downloads of the files of the spec file which are large enough to be split, to the local paths the download command would download them to.
+func (rdc *ResumableDownloadCommand) addSplitFilesOfSpec(producerConsumer parallel.Runner, errorsQueue *clientutils.ErrorsQueue, serviceManager artifactory.ArtifactoryServicesManager, file *spec.File) (err error) {
+	searchParams := services.NewSearchParams()
+	if searchParams.CommonParams, err = file.ToCommonParams(); err != nil {
+		return
+	}
+	if searchParams.Recursive, err = file.IsRecursive(true); err != nil {
+		return
+	}
+	flat, err := file.IsFlat(false)
+	if err != nil {
+		return
+	}
+	reader, err := serviceManager.SearchFiles(searchParams)
+	if err != nil {
+		return
+	}
+	defer func() {
+		e := reader.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	minSplitSize := rdc.Configuration().MinSplitSize * 1000
+	for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+		if item.Type == "folder" || item.Size < minSplitSize || item.Actual_Sha1 == "" {
+			continue
+		}
+		target, placeholdersUsed, e := clientutils.BuildTargetPath(searchParams.GetPattern(), item.GetItemRelativePath(), searchParams.GetTarget(), true)
+		if e != nil {
+			return e
+		}
+		localPath, localFileName := fileutils.GetLocalPathAndFile(item.Name, item.Path, target, flat, placeholdersUsed)
+		splitItem, localFilePath := item, filepath.Join(localPath, localFileName)
+		_, err = producerConsumer.AddTaskWithError(func(int) error {
+			return rdc.downloadSplitFile(serviceManager, splitItem, localFilePath)
+		}, errorsQueue.AddError)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return reader.GetError()
+}
+
+func (rdc *ResumableDownloadCommand) downloadSplitFile(serviceManager artifactory.ArtifactoryServicesManager, item *serviceutils.ResultItem, localFilePath string) error {
+	isEqual, err := fileutils.IsEqualToLocalFile(localFilePath, item.Actual_Md5, item.Actual_Sha1)
+	if err != nil || isEqual {
+		return err
+	}
+	serviceDetails := serviceManager.GetConfig().GetServiceDetails()
+	downloadUrl, err := serviceutils.BuildArtifactoryUrl(serviceDetails.GetUrl(), item.GetItemRelativePath(), make(map[string]string))
+	if err != nil {
+		return err
+	}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	acceptRanges, resp, err := serviceManager.Client().IsAcceptRanges(downloadUrl, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return err
+	}
+	if !acceptRanges {
+		// The file is downloaded by the download command in a single request.
+		return nil
+	}
+	partsDir, err := rdc.journal.getPartsDir(item.Actual_Sha1)
+	if err != nil {
+		return err
+	}
+	file := splitFile{
+		DownloadUrl:  downloadUrl,
+		RelativePath: item.GetItemRelativePath(),
+		LocalPath:    localFilePath,
+		Size:         item.Size,
+		Sha1:         item.Actual_Sha1,
+		SplitCount:   rdc.Configuration().SplitCount,
+		PartsDir:     partsDir,
+	}
+	return file.download(serviceManager.Client(), httpClientDetails, rdc.progress)
+}
+
+// A file which is downloaded in parts, concurrently.
+type splitFile struct {
+	DownloadUrl  string
+	RelativePath string
+	LocalPath    string
+	Size         int64
+	Sha1         string
+	SplitCount   int
+	// The directory of the downloaded parts, which is kept if the download fails.
+	PartsDir string
+}
+
+// Downloads the parts of the file into the parts directory, and merges them into the local file.
+// Each part continues from the size of its file in the parts directory.
+func (sf *splitFile) download(client *jfroghttpclient.JfrogHttpClient, httpClientDetails httputils.HttpClientDetails, progress ioUtils.ProgressMgr) error {
+	if err := fileutils.CreateDirIfNotExist(sf.PartsDir); err != nil {
+		return err
+	}
+	log.Info("Downloading", sf.RelativePath, "in", sf.SplitCount, "parts")
+	progressId := 0
+	if progress != nil {
+		progressId = progress.NewProgressReader(sf.Size, "Downloading", sf.RelativePath).GetId()
+		defer progress.RemoveProgress(progressId)
+	}
+	var wg sync.WaitGroup
+	errorsList := make([]error, sf.SplitCount)
+	for i := 0; i < sf.SplitCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errorsList[i] = sf.downloadPart(i, client, httpClientDetails.Clone(), progress, progressId)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errorsList {
+		if err != nil {
+			log.Info("The downloaded parts of", sf.RelativePath, "are kept. Rerun the command with the --resume option to continue its download.")
+			return err
+		}
+	}
+	if progress != nil {
+		progress.SetProgressState(progressId, "Merging")
+	}
+	if err := sf.mergeParts(); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.RemoveAll(sf.PartsDir))
+}
+
+// Returns the range of the part, like the split download of the download command.
+func (sf *splitFile) getPartRange(part int) (start, end int64) {
+	partSize := sf.Size / int64(sf.SplitCount)
+	start = partSize * int64(part)
+	end = start + partSize
+	if part == sf.SplitCount-1 {
+		end = sf.Size
+	}
+	return
+}
+
+func (sf *splitFile) getPartPath(part int) string {
+	return filepath.Join(sf.PartsDir, strconv.Itoa(part))
+}
+
+func (sf *splitFile) downloadPart(part int, client *jfroghttpclient.JfrogHttpClient, httpClientDetails *httputils.HttpClientDetails, progress ioUtils.ProgressMgr, progressId int) error {
+	retryExecutor := clientutils.RetryExecutor{
+		MaxRetries:               client.GetHttpClient().GetRetries(),
+		RetriesIntervalMilliSecs: client.GetHttpClient().GetRetryWaitTime(),
+		ErrorMessage:             fmt.Sprintf("Failure occurred while downloading part %d of %s", part, sf.RelativePath),
+		LogMsgPrefix:             fmt.Sprintf("[%d]: ", part),
+		ExecutionHandler: func() (bool, error) {
+			return sf.doDownloadPart(part, client, httpClientDetails, progress, progressId)
+		},
+	}
+	return retryExecutor.Execute()
+}
+
+// Downloads the rest of the part, which wasn't downloaded yet, by appending it to the part's file.
+// Returns true if the download should be retried.
+func (sf *splitFile) doDownloadPart(part int, client *jfroghttpclient.JfrogHttpClient, httpClientDetails *httputils.HttpClientDetails, progress ioUtils.ProgressMgr, progressId int) (shouldRetry bool, err error) {
+	partFile, err := os.OpenFile(sf.getPartPath(part), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	defer func() {
+		e := partFile.Close()
+		if err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	info, err := partFile.Stat()
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	start, end := sf.getPartRange(part)
+	downloaded := info.Size()
+	if downloaded > end-start {
+		log.Debug(fmt.Sprintf("The downloaded part %d of %s is larger than expected. Downloading it again.", part, sf.RelativePath))
+		if err = partFile.Truncate(0); err != nil {
+			return false, errorutils.CheckError(err)
+		}
+		downloaded = 0
+	}
+	if downloaded == end-start {
+		return false, nil
+	}
+	if downloaded > 0 {
+		log.Debug(fmt.Sprintf("Continuing the download of part %d of %s from byte %d.", part, sf.RelativePath, downloaded))
+	}
+	if httpClientDetails.Headers == nil {
+		httpClientDetails.Headers = make(map[string]string)
+	}
+	httpClientDetails.Headers["Range"] = fmt.Sprintf("bytes=%d-%d", start+downloaded, end-1)
+	_, resp, err := client.ReadRemoteFile(sf.DownloadUrl, httpClientDetails)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		e := resp.Body.Close()
+		if err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	if resp.StatusCode != http.StatusPartialContent {
+		return resp.StatusCode >= http.StatusInternalServerError, errorutils.CheckErrorf("failed downloading part %d of %s. Artifactory response: %s", part, sf.RelativePath, resp.Status)
+	}
+	var reader io.Reader = resp.Body
+	if progress != nil {
+		reader = progress.GetProgress(progressId).ActionWithProgress(reader)
+	}
+	if _, err = io.Copy(partFile, reader); err != nil {
+		return true, errorutils.CheckError(err)
+	}
+	return false, nil
+}
+
+// Merges the parts into the local file, and verifies its checksum.
+// If the checksum doesn't match, the parts are removed, so that the file is downloaded again from the start.
+func (sf *splitFile) mergeParts() (err error) {
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(sf.LocalPath)); err != nil {
+		return
+	}
+	localFile, err := os.Create(sf.LocalPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		e := localFile.Close()
+		if err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	//#nosec G401 -- sha1 is supported by Artifactory.
+	actualSha1 := sha1.New()
+	writer := io.MultiWriter(localFile, actualSha1)
+	for i := 0; i < sf.SplitCount; i++ {
+		if err = appendFile(writer, sf.getPartPath(i)); err != nil {
+			return
+		}
+	}
+	if actual := hex.EncodeToString(actualSha1.Sum(nil)); actual != sf.Sha1 {
+		if e := os.RemoveAll(sf.PartsDir); e != nil {
+			log.Debug("Couldn't remove the downloaded parts of", sf.RelativePath+":", e.Error())
+		}
+		return errorutils.CheckErrorf("checksum mismatch for %s, expected: %s, actual: %s", sf.LocalPath, sf.Sha1, actual)
+	}
+	return
+}
+
+func appendFile(writer io.Writer, path string) (err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		e := file.Close()
+		if err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	_, err = io.Copy(writer, file)
+	return errorutils.CheckError(err)
+}
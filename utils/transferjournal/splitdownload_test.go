@@ -0,0 +1,68 @@
+package transferjournal
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFileDownloadResume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	var mutex sync.Mutex
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		mutex.Unlock()
+		http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+	serviceManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, 0, false)
+	assert.NoError(t, err)
+
+	tempDir := t.TempDir()
+	checksum := sha1.Sum(content)
+	file := splitFile{
+		DownloadUrl:  server.URL + "/repo/file.bin",
+		RelativePath: "repo/file.bin",
+		LocalPath:    filepath.Join(tempDir, "out", "file.bin"),
+		Size:         int64(len(content)),
+		Sha1:         hex.EncodeToString(checksum[:]),
+		SplitCount:   3,
+		PartsDir:     filepath.Join(tempDir, "parts"),
+	}
+	// The first part was partially downloaded, and the last part was fully downloaded, by a previous run.
+	assert.NoError(t, os.MkdirAll(file.PartsDir, 0700))
+	assert.NoError(t, os.WriteFile(file.getPartPath(0), content[:100], 0600))
+	start, _ := file.getPartRange(2)
+	assert.NoError(t, os.WriteFile(file.getPartPath(2), content[start:], 0600))
+
+	assert.NoError(t, file.download(serviceManager.Client(), serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails(), nil))
+	assert.ElementsMatch(t, []string{"bytes=100-332", "bytes=333-665"}, ranges)
+	downloaded, err := os.ReadFile(file.LocalPath)
+	assert.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+	assert.NoDirExists(t, file.PartsDir)
+}
+
+func TestSplitFileChecksumMismatch(t *testing.T) {
+	tempDir := t.TempDir()
+	file := splitFile{LocalPath: filepath.Join(tempDir, "file.bin"), Size: 4, Sha1: "abc", SplitCount: 2, PartsDir: filepath.Join(tempDir, "parts")}
+	assert.NoError(t, os.MkdirAll(file.PartsDir, 0700))
+	assert.NoError(t, os.WriteFile(file.getPartPath(0), []byte("ab"), 0600))
+	assert.NoError(t, os.WriteFile(file.getPartPath(1), []byte("cd"), 0600))
+	assert.ErrorContains(t, file.mergeParts(), "checksum mismatch")
+	// The corrupted parts are removed, so that the file is downloaded again from the start.
+	assert.NoDirExists(t, file.PartsDir)
+}
@@ -0,0 +1,340 @@
+package transferjournal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	journalsDirName = "transfer-journals"
+	journalFileExt  = ".json"
+	// The characters of a local path which a wildcard pattern doesn't match literally, such as wildcards, placeholder parentheses and regular expression quantifiers.
+	wildcardPatternChars = "*?(){}|"
+)
+
+// A transfer journal records the files which were successfully transferred by an upload or a download command.
+// It allows rerunning a command which failed midway with the --resume option, while skipping the verified files.
+// The journal is identified by a hash of the command, the Artifactory URL and the File Spec, and is removed once the command completes successfully.
+type Journal struct {
+	Id      string  `json:"id"`
+	Command string  `json:"command"`
+	Created string  `json:"created"`
+	Updated string  `json:"updated"`
+	Files   []Entry `json:"files"`
+}
+
+type Entry struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Sha256 string `json:"sha256,omitempty"`
+}
+
+func GetJournalsDir() (string, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, journalsDirName), nil
+}
+
+// Returns the journal ID of a command, which is the hash of the command name, Artifactory URL and File Spec.
+func CalcJournalId(command, artifactoryUrl string, specFiles *spec.SpecFiles) (string, error) {
+	content, err := json.Marshal(specFiles)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	hash := sha256.Sum256([]byte(command + "\n" + artifactoryUrl + "\n" + string(content)))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Loads the journal of the command. If no journal exists, a new empty journal is returned.
+// Should be called before the spec is modified, since the journal ID is calculated from it.
+func Load(command, artifactoryUrl string, specFiles *spec.SpecFiles) (*Journal, error) {
+	id, err := CalcJournalId(command, artifactoryUrl, specFiles)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := readJournal(id)
+	if err != nil || journal != nil {
+		return journal, err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	return &Journal{Id: id, Command: command, Created: now, Updated: now}, nil
+}
+
+func getJournalPath(id string) (string, error) {
+	journalsDir, err := GetJournalsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(journalsDir, id+journalFileExt), nil
+}
+
+// Reads the journal with the provided ID. Returns nil if it doesn't exist.
+func readJournal(id string) (*Journal, error) {
+	journalPath, err := getJournalPath(id)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsFileExists(journalPath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := os.ReadFile(journalPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	journal := new(Journal)
+	if err = json.Unmarshal(content, journal); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the transfer journal %s: %s", journalPath, err.Error())
+	}
+	return journal, nil
+}
+
+// Returns the directory of the downloaded parts of a split file, which is identified by its checksum.
+func (j *Journal) getPartsDir(sha1 string) (string, error) {
+	journalsDir, err := GetJournalsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(journalsDir, j.Id, sha1), nil
+}
+
+func (j *Journal) Save() error {
+	journalsDir, err := GetJournalsDir()
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(journalsDir); err != nil {
+		return err
+	}
+	j.Updated = time.Now().UTC().Format(time.RFC3339)
+	content, err := json.Marshal(j)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(journalsDir, j.Id+journalFileExt), content, 0600))
+}
+
+func (j *Journal) Remove() error {
+	return Clean(j.Id)
+}
+
+// Updates the journal with the result of the command.
+// If the command completed successfully, the journal is removed. Otherwise, the transferred files are added to the journal and it is saved.
+func (j *Journal) Update(result *commandUtils.Result, completed bool) error {
+	if completed {
+		log.Debug("The command completed successfully. Removing the transfer journal", j.Id)
+		return j.Remove()
+	}
+	if result != nil && result.Reader() != nil {
+		reader := result.Reader()
+		known := make(map[string]bool, len(j.Files))
+		for _, entry := range j.Files {
+			known[entry.Source] = true
+		}
+		for transferDetails := new(clientutils.FileTransferDetails); reader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+			if !known[transferDetails.SourcePath] {
+				j.Files = append(j.Files, Entry{Source: transferDetails.SourcePath, Target: transferDetails.TargetPath, Sha256: transferDetails.Sha256})
+				known[transferDetails.SourcePath] = true
+			}
+		}
+		if err := reader.GetError(); err != nil {
+			return err
+		}
+		reader.Reset()
+	}
+	if err := j.Save(); err != nil {
+		return err
+	}
+	log.Info("The transfer journal was updated with", len(j.Files), "transferred files. Rerun the command with the --resume option to continue from where it stopped.")
+	return nil
+}
+
+// Returns the local paths of the uploaded files, which weren't modified since they were uploaded.
+func (j *Journal) VerifiedUploads() (map[string]bool, error) {
+	verified := make(map[string]bool)
+	for _, entry := range j.Files {
+		if entry.Sha256 == "" {
+			continue
+		}
+		exists, err := fileutils.IsFileExists(entry.Source, false)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		details, err := fileutils.GetFileDetails(entry.Source, true)
+		if err != nil {
+			return nil, err
+		}
+		if details.Checksum.Sha256 == entry.Sha256 {
+			verified[entry.Source] = true
+		}
+	}
+	return verified, nil
+}
+
+// Removes the verified uploaded files from the upload spec, so that they are not uploaded again.
+// The files of each spec file are collected the way the upload command collects them, and looked up in the verified files.
+// A spec file which matched verified files is replaced by a spec file for each of its remaining files, with its resolved target.
+func (j *Journal) SkipVerifiedUploads(uploadSpec *spec.SpecFiles) error {
+	verified, err := j.VerifiedUploads()
+	if err != nil || len(verified) == 0 {
+		return err
+	}
+	var files []spec.File
+	skippedCount := 0
+	for i := range uploadSpec.Files {
+		remaining, skipped, err := skipVerifiedFiles(uploadSpec.Get(i), verified)
+		if err != nil {
+			return err
+		}
+		files = append(files, remaining...)
+		skippedCount += skipped
+	}
+	uploadSpec.Files = files
+	log.Info("Resuming the upload.", skippedCount, "files which were uploaded by previous runs are skipped.")
+	return nil
+}
+
+// Returns the spec files to upload instead of the provided spec file, and the number of its verified files which were skipped.
+func skipVerifiedFiles(file *spec.File, verified map[string]bool) (remaining []spec.File, skipped int, err error) {
+	uploadParams, err := getUploadParams(file)
+	if err != nil {
+		return
+	}
+	if uploadParams.IncludeDirs {
+		log.Debug("Skipping the verified uploads lookup for the pattern", file.Pattern, "since it includes directories")
+		return []spec.File{*file}, 0, nil
+	}
+	var artifacts []clientutils.Artifact
+	err = services.CollectFilesForUpload(uploadParams, nil, nil, func(data services.UploadData) {
+		if verified[data.Artifact.LocalPath] {
+			skipped++
+			return
+		}
+		artifacts = append(artifacts, data.Artifact)
+	})
+	if err != nil || skipped == 0 {
+		return []spec.File{*file}, 0, err
+	}
+	for _, artifact := range artifacts {
+		if strings.ContainsAny(artifact.LocalPath, wildcardPatternChars) {
+			// A path which isn't matched literally by a wildcard pattern can't be uploaded by its own spec file, so the whole pattern is uploaded again.
+			log.Debug("Skipping the verified uploads lookup for the pattern", file.Pattern, "since it matched the path", artifact.LocalPath, "which includes pattern characters")
+			return []spec.File{*file}, 0, nil
+		}
+	}
+	for _, artifact := range artifacts {
+		remainingFile := *file
+		remainingFile.Pattern = artifact.LocalPath
+		remainingFile.Target = artifact.TargetPath
+		remainingFile.Exclusions = nil
+		remainingFile.Recursive, remainingFile.Regexp, remainingFile.Ant, remainingFile.Flat = "false", "false", "false", "true"
+		remaining = append(remaining, remainingFile)
+	}
+	return
+}
+
+// Returns the parameters which the upload command uses to collect the files of the spec file.
+func getUploadParams(file *spec.File) (uploadParams services.UploadParams, err error) {
+	uploadParams = services.NewUploadParams()
+	if uploadParams.CommonParams, err = file.ToCommonParams(); err != nil {
+		return
+	}
+	if uploadParams.Recursive, err = file.IsRecursive(true); err != nil {
+		return
+	}
+	if uploadParams.Regexp, err = file.IsRegexp(false); err != nil {
+		return
+	}
+	if uploadParams.Ant, err = file.IsAnt(false); err != nil {
+		return
+	}
+	if uploadParams.IncludeDirs, err = file.IsIncludeDirs(false); err != nil {
+		return
+	}
+	if uploadParams.Flat, err = file.IsFlat(true); err != nil {
+		return
+	}
+	if uploadParams.ExplodeArchive, err = file.IsExplode(false); err != nil {
+		return
+	}
+	uploadParams.Symlink, err = file.IsSymlinks(false)
+	return
+}
+
+// Returns all the journals, sorted by their last update time.
+func List() ([]*Journal, error) {
+	journalsDir, err := GetJournalsDir()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsDirExists(journalsDir, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	paths, err := fileutils.ListFiles(journalsDir, false)
+	if err != nil {
+		return nil, err
+	}
+	var journals []*Journal
+	for _, path := range paths {
+		if filepath.Ext(path) != journalFileExt {
+			continue
+		}
+		journal, err := readJournal(strings.TrimSuffix(filepath.Base(path), journalFileExt))
+		if err != nil {
+			return nil, err
+		}
+		journals = append(journals, journal)
+	}
+	sort.Slice(journals, func(i, k int) bool { return journals[i].Updated < journals[k].Updated })
+	return journals, nil
+}
+
+// Removes the journal with the provided ID, and the downloaded parts of its split files.
+func Clean(id string) error {
+	journalPath, err := getJournalPath(id)
+	if err != nil {
+		return err
+	}
+	exists, err := fileutils.IsFileExists(journalPath, false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("the transfer journal '%s' does not exist", id)
+	}
+	if err = os.Remove(journalPath); err != nil {
+		return errorutils.CheckError(err)
+	}
+	// Remove the downloaded parts of the split files, which are kept next to the journal.
+	return errorutils.CheckError(os.RemoveAll(strings.TrimSuffix(journalPath, journalFileExt)))
+}
+
+// Removes all the journals, and the downloaded parts of their split files.
+func CleanAll() error {
+	journalsDir, err := GetJournalsDir()
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.RemoveAll(journalsDir))
+}
@@ -0,0 +1,94 @@
+package transferjournal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcJournalId(t *testing.T) {
+	uploadSpec := spec.NewBuilder().Pattern("a/*").Target("repo/").BuildSpec()
+	id, err := CalcJournalId("rt upload", "https://acme.jfrog.io/artifactory/", uploadSpec)
+	assert.NoError(t, err)
+	sameId, err := CalcJournalId("rt upload", "https://acme.jfrog.io/artifactory/", spec.NewBuilder().Pattern("a/*").Target("repo/").BuildSpec())
+	assert.NoError(t, err)
+	assert.Equal(t, id, sameId)
+
+	otherId, err := CalcJournalId("rt download", "https://acme.jfrog.io/artifactory/", uploadSpec)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, otherId)
+	otherId, err = CalcJournalId("rt upload", "https://acme.jfrog.io/artifactory/", spec.NewBuilder().Pattern("b/*").Target("repo/").BuildSpec())
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, otherId)
+}
+
+func TestSaveLoadAndClean(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	downloadSpec := spec.NewBuilder().Pattern("repo/*").BuildSpec()
+	journal, err := Load("rt download", "https://acme.jfrog.io/artifactory/", downloadSpec)
+	assert.NoError(t, err)
+	assert.Empty(t, journal.Files)
+
+	journal.Files = append(journal.Files, Entry{Source: "repo/a.zip", Target: "a.zip", Sha256: "abc"})
+	assert.NoError(t, journal.Save())
+	loaded, err := Load("rt download", "https://acme.jfrog.io/artifactory/", downloadSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, journal.Files, loaded.Files)
+
+	journals, err := List()
+	assert.NoError(t, err)
+	if assert.Len(t, journals, 1) {
+		assert.Equal(t, journal.Id, journals[0].Id)
+	}
+
+	assert.NoError(t, Clean(journal.Id))
+	assert.Error(t, Clean(journal.Id))
+	journals, err = List()
+	assert.NoError(t, err)
+	assert.Empty(t, journals)
+}
+
+func TestSkipVerifiedUploads(t *testing.T) {
+	tempDir := t.TempDir()
+	unmodified := filepath.Join(tempDir, "unmodified.txt")
+	modified := filepath.Join(tempDir, "modified.txt")
+	assert.NoError(t, os.WriteFile(unmodified, []byte("unmodified"), 0600))
+	assert.NoError(t, os.WriteFile(modified, []byte("modified"), 0600))
+	details, err := fileutils.GetFileDetails(unmodified, true)
+	assert.NoError(t, err)
+
+	journal := &Journal{Files: []Entry{
+		{Source: unmodified, Sha256: details.Checksum.Sha256},
+		{Source: modified, Sha256: details.Checksum.Sha256},
+		{Source: filepath.Join(tempDir, "deleted.txt"), Sha256: details.Checksum.Sha256},
+	}}
+	uploadSpec := spec.NewBuilder().Pattern(filepath.Join(tempDir, "*")).Target("repo/").Flat(true).Props("a=b").BuildSpec()
+	regexpSpec := spec.NewBuilder().Pattern(tempDir + "/(.*).txt").Target("repo/{1}.bin").Regexp(true).BuildSpec()
+	otherSpec := spec.NewBuilder().Pattern(filepath.Join(tempDir, "modified.*")).Target("repo/").BuildSpec()
+	uploadSpec.Files = append(uploadSpec.Files, regexpSpec.Files...)
+	uploadSpec.Files = append(uploadSpec.Files, otherSpec.Files...)
+	assert.NoError(t, journal.SkipVerifiedUploads(uploadSpec))
+	if assert.Len(t, uploadSpec.Files, 3) {
+		assert.Equal(t, modified, uploadSpec.Get(0).Pattern)
+		assert.Equal(t, "repo/modified.txt", uploadSpec.Get(0).Target)
+		assert.Equal(t, "a=b", uploadSpec.Get(0).Props)
+		assert.Equal(t, modified, uploadSpec.Get(1).Pattern)
+		assert.Equal(t, "repo/modified.bin", uploadSpec.Get(1).Target)
+		// A spec file which didn't match verified files is kept as is.
+		assert.Equal(t, otherSpec.Files[0], uploadSpec.Files[2])
+	}
+
+	// A spec file which matched a path with pattern characters is kept as is, since the path can't be used as a pattern.
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "modified(1).txt"), []byte("modified"), 0600))
+	uploadSpec = spec.NewBuilder().Pattern(filepath.Join(tempDir, "*")).Target("repo/").BuildSpec()
+	expected := uploadSpec.Files[0]
+	assert.NoError(t, journal.SkipVerifiedUploads(uploadSpec))
+	if assert.Len(t, uploadSpec.Files, 1) {
+		assert.Equal(t, expected, uploadSpec.Files[0])
+	}
+}
@@ -0,0 +1,99 @@
+package xrayignore
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// A finding is a security issue of a single component.
+type Finding struct {
+	IssueId   string   `json:"issueId,omitempty"`
+	Cves      []string `json:"cves,omitempty"`
+	Component string   `json:"component"`
+	Severity  string   `json:"severity,omitempty"`
+}
+
+// Identifies the finding by its Xray issue ID, or by its CVEs if the issue ID is missing.
+func (f Finding) key() string {
+	issue := f.IssueId
+	if issue == "" {
+		issue = strings.Join(f.Cves, ",")
+	}
+	return issue + "|" + f.Component
+}
+
+// A baseline is a snapshot of the findings of a project. Findings which are included in the baseline are suppressed,
+// so that only new findings fail the build.
+type Baseline struct {
+	Created  string    `json:"created"`
+	Findings []Finding `json:"findings"`
+	keys     map[string]bool
+}
+
+// Creates a baseline of the violations and vulnerabilities in the scan results.
+func NewBaseline(results []services.ScanResponse, now time.Time) *Baseline {
+	baseline := &Baseline{Created: now.UTC().Format(time.RFC3339), Findings: []Finding{}, keys: map[string]bool{}}
+	add := func(issueId string, cves []services.Cve, severity string, components map[string]services.Component) {
+		for componentId := range components {
+			finding := newFinding(issueId, cves, severity, componentId)
+			if !baseline.keys[finding.key()] {
+				baseline.keys[finding.key()] = true
+				baseline.Findings = append(baseline.Findings, finding)
+			}
+		}
+	}
+	for _, result := range results {
+		for _, violation := range result.Violations {
+			add(violation.IssueId, violation.Cves, violation.Severity, violation.Components)
+		}
+		for _, vulnerability := range result.Vulnerabilities {
+			add(vulnerability.IssueId, vulnerability.Cves, vulnerability.Severity, vulnerability.Components)
+		}
+	}
+	sort.Slice(baseline.Findings, func(i, j int) bool { return baseline.Findings[i].key() < baseline.Findings[j].key() })
+	return baseline
+}
+
+func newFinding(issueId string, cves []services.Cve, severity, componentId string) Finding {
+	finding := Finding{IssueId: issueId, Component: componentId, Severity: severity}
+	for _, cve := range cves {
+		if cve.Id != "" {
+			finding.Cves = append(finding.Cves, cve.Id)
+		}
+	}
+	return finding
+}
+
+func ReadBaseline(path string) (*Baseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the Xray baseline file: %s", err.Error())
+	}
+	baseline := new(Baseline)
+	if err = json.Unmarshal(content, baseline); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the Xray baseline file %s: %s", path, err.Error())
+	}
+	baseline.keys = map[string]bool{}
+	for _, finding := range baseline.Findings {
+		baseline.keys[finding.key()] = true
+	}
+	return baseline, nil
+}
+
+func (baseline *Baseline) Write(path string) error {
+	content, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(path, content, 0644))
+}
+
+func (baseline *Baseline) contains(finding Finding) bool {
+	return baseline != nil && baseline.keys[finding.key()]
+}
@@ -0,0 +1,160 @@
+package xrayignore
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const baselineJustification = "Included in the baseline"
+
+// A finding which was suppressed by a rule of the ignore file, or by its baseline.
+type SuppressedFinding struct {
+	Issue         string `col-name:"Issue"`
+	Severity      string `col-name:"Severity"`
+	Component     string `col-name:"Component"`
+	Justification string `col-name:"Justification"`
+	Expires       string `col-name:"Expires"`
+}
+
+func newSuppressedFinding(finding Finding, justification, expires string) SuppressedFinding {
+	issue := finding.IssueId
+	if cves := strings.Join(finding.Cves, ", "); cves != "" {
+		if issue == "" {
+			issue = cves
+		} else {
+			issue += " (" + cves + ")"
+		}
+	}
+	return SuppressedFinding{Issue: issue, Severity: finding.Severity, Component: finding.Component, Justification: justification, Expires: expires}
+}
+
+// Removes the findings which match the rules of the ignore file, or which are included in its baseline, from the violations
+// and vulnerabilities of the scan results. A violation or a vulnerability is removed only when all its components are suppressed.
+// Expired rules are ignored. The licenses are returned as is.
+func (ignoreFile *IgnoreFile) Filter(results []services.ScanResponse, now time.Time) (filtered []services.ScanResponse, suppressed []SuppressedFinding) {
+	if ignoreFile == nil {
+		return results, nil
+	}
+	for _, result := range results {
+		violations := []services.Violation{}
+		for _, violation := range result.Violations {
+			var suppressedComponents []SuppressedFinding
+			violation.Components, suppressedComponents = ignoreFile.filterComponents(violation.IssueId, violation.Cves, violation.Severity, violation.Components, now)
+			suppressed = append(suppressed, suppressedComponents...)
+			if len(violation.Components) > 0 || len(suppressedComponents) == 0 {
+				violations = append(violations, violation)
+			}
+		}
+		vulnerabilities := []services.Vulnerability{}
+		for _, vulnerability := range result.Vulnerabilities {
+			var suppressedComponents []SuppressedFinding
+			vulnerability.Components, suppressedComponents = ignoreFile.filterComponents(vulnerability.IssueId, vulnerability.Cves, vulnerability.Severity, vulnerability.Components, now)
+			suppressed = append(suppressed, suppressedComponents...)
+			if len(vulnerability.Components) > 0 || len(suppressedComponents) == 0 {
+				vulnerabilities = append(vulnerabilities, vulnerability)
+			}
+		}
+		result.Violations, result.Vulnerabilities = violations, vulnerabilities
+		filtered = append(filtered, result)
+	}
+	sort.SliceStable(suppressed, func(i, j int) bool {
+		if suppressed[i].Issue != suppressed[j].Issue {
+			return suppressed[i].Issue < suppressed[j].Issue
+		}
+		return suppressed[i].Component < suppressed[j].Component
+	})
+	return
+}
+
+func (ignoreFile *IgnoreFile) filterComponents(issueId string, cves []services.Cve, severity string, components map[string]services.Component, now time.Time) (map[string]services.Component, []SuppressedFinding) {
+	var suppressed []SuppressedFinding
+	remaining := map[string]services.Component{}
+	for componentId, component := range components {
+		finding := newFinding(issueId, cves, severity, componentId)
+		if rule := ignoreFile.findRule(finding, component, now); rule != nil {
+			suppressed = append(suppressed, newSuppressedFinding(finding, rule.Justification, rule.Expires))
+			continue
+		}
+		if ignoreFile.baseline.contains(finding) {
+			suppressed = append(suppressed, newSuppressedFinding(finding, baselineJustification, ""))
+			continue
+		}
+		remaining[componentId] = component
+	}
+	return remaining, suppressed
+}
+
+// Returns the first rule which isn't expired and matches the finding, or nil if there's no such rule.
+func (ignoreFile *IgnoreFile) findRule(finding Finding, component services.Component, now time.Time) *Rule {
+	for i, rule := range ignoreFile.Rules {
+		if !rule.IsExpired(now) && rule.matches(finding, component) {
+			return &ignoreFile.Rules[i]
+		}
+	}
+	return nil
+}
+
+func (r Rule) matches(finding Finding, component services.Component) bool {
+	if r.Cve != "" && !matchesIssue(r.Cve, finding) {
+		return false
+	}
+	if r.Component != "" && !matchWildcard(r.Component, finding.Component) && !matchWildcard(r.Component+":*", finding.Component) {
+		return false
+	}
+	if r.Path != "" && !matchesPath(r.Path, component) {
+		return false
+	}
+	return true
+}
+
+func matchesIssue(issue string, finding Finding) bool {
+	if strings.EqualFold(issue, finding.IssueId) {
+		return true
+	}
+	for _, cve := range finding.Cves {
+		if strings.EqualFold(issue, cve) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesPath(pattern string, component services.Component) bool {
+	for _, impactPath := range component.ImpactPaths {
+		for _, node := range impactPath {
+			if node.FullPath != "" && matchWildcard(pattern, node.FullPath) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchWildcard(pattern, value string) bool {
+	regex := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	return regexp.MustCompile(regex).MatchString(value)
+}
+
+// Prints the suppressed findings. In the table format, the findings are printed as a separate table. In the other formats, which
+// are printed to the standard output, the findings are logged, so that they aren't mixed with the results.
+func PrintSuppressedFindings(suppressed []SuppressedFinding, format xrutils.OutputFormat) error {
+	if len(suppressed) == 0 {
+		return nil
+	}
+	if format == xrutils.Table {
+		return coreutils.PrintTable(suppressed, "Suppressed Findings", "", false)
+	}
+	log.Info(fmt.Sprintf("%d findings were suppressed by the Xray ignore file:", len(suppressed)))
+	for _, finding := range suppressed {
+		log.Info(fmt.Sprintf("%s in %s: %s", finding.Issue, finding.Component, finding.Justification))
+	}
+	return nil
+}
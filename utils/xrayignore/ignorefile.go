@@ -0,0 +1,201 @@
+package xrayignore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ignoreDirName  = ".jfrog"
+	ignoreFileName = "xray-ignore.yaml"
+	// The format of the expiry dates of the rules.
+	DateFormat = "2006-01-02"
+)
+
+// A rule suppresses the findings of a CVE or an Xray issue, of a component, or of a combination of both.
+type Rule struct {
+	// A CVE ID or an Xray issue ID.
+	Cve string `yaml:"cve,omitempty"`
+	// An Xray component ID, such as "npm://lodash:4.17.20". A component without a version matches all its versions. Supports * wildcards.
+	Component string `yaml:"component,omitempty"`
+	// The path of the component within the scanned file, image or build, as reported by Xray. Supports * wildcards.
+	Path string `yaml:"path,omitempty"`
+	// The last day on which the rule is applied, in the YYYY-MM-DD format. A rule without an expiry date never expires.
+	Expires string `yaml:"expires,omitempty"`
+	// The reason for accepting the risk.
+	Justification string `yaml:"justification"`
+}
+
+// The last moment on which the rule is applied, or the zero time if the rule never expires.
+func (r Rule) expiry() time.Time {
+	expires, err := time.ParseInLocation(DateFormat, r.Expires, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return expires.AddDate(0, 0, 1)
+}
+
+func (r Rule) IsExpired(now time.Time) bool {
+	expiry := r.expiry()
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
+func (r Rule) String() string {
+	var parts []string
+	if r.Cve != "" {
+		parts = append(parts, r.Cve)
+	}
+	if r.Component != "" {
+		parts = append(parts, r.Component)
+	}
+	if r.Path != "" {
+		parts = append(parts, r.Path)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (r Rule) validate() error {
+	if r.Cve == "" && r.Component == "" {
+		return fmt.Errorf("each rule should include a cve, a component, or both")
+	}
+	if strings.TrimSpace(r.Justification) == "" {
+		return fmt.Errorf("the rule '%s' has no justification", r)
+	}
+	if r.Expires != "" {
+		if _, err := time.Parse(DateFormat, r.Expires); err != nil {
+			return fmt.Errorf("the expiry date '%s' of the rule '%s' isn't in the YYYY-MM-DD format", r.Expires, r)
+		}
+	}
+	return nil
+}
+
+// The Xray ignore file lists the accepted risks of a project. The findings which match the rules of the file, or which
+// are included in the baseline referenced by the file, are suppressed from the results of the audit and scan commands.
+// The file is read from .jfrog/xray-ignore.yaml, found in the working directory or in one of its parents.
+type IgnoreFile struct {
+	// The path of a baseline file, created by 'jf audit --baseline'. A relative path is relative to the directory which includes the .jfrog directory.
+	Baseline string `yaml:"baseline,omitempty"`
+	Rules    []Rule `yaml:"ignore,omitempty"`
+	// The path of the ignore file.
+	Path     string    `yaml:"-"`
+	baseline *Baseline `yaml:"-"`
+}
+
+// Searches for the ignore file in the working directory and in its parents. Returns nil if no ignore file was found.
+func FindIgnoreFile() (*IgnoreFile, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return FindIgnoreFileFrom(wd)
+}
+
+// Searches for the ignore file in the provided directory and in its parents. Returns nil if no ignore file was found.
+// The JFrog CLI home directory is skipped, since it isn't a project directory.
+func FindIgnoreFileFrom(dir string) (*IgnoreFile, error) {
+	homeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for {
+		ignoreDir := filepath.Join(dir, ignoreDirName)
+		if ignoreDir != filepath.Clean(homeDir) {
+			ignoreFilePath := filepath.Join(ignoreDir, ignoreFileName)
+			exists, err := fileutils.IsFileExists(ignoreFilePath, false)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return ReadIgnoreFile(ignoreFilePath)
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+func ReadIgnoreFile(path string) (*IgnoreFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	ignoreFile := new(IgnoreFile)
+	if err = yaml.UnmarshalStrict(content, ignoreFile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the Xray ignore file %s: %s", path, err.Error())
+	}
+	for _, rule := range ignoreFile.Rules {
+		if err = rule.validate(); err != nil {
+			return nil, errorutils.CheckErrorf("invalid Xray ignore file %s: %s", path, err.Error())
+		}
+	}
+	ignoreFile.Path = path
+	if ignoreFile.Baseline != "" {
+		baselinePath := ignoreFile.Baseline
+		if !filepath.IsAbs(baselinePath) {
+			// The ignore file is located in the .jfrog directory of the project.
+			baselinePath = filepath.Join(filepath.Dir(filepath.Dir(path)), baselinePath)
+		}
+		if ignoreFile.baseline, err = ReadBaseline(baselinePath); err != nil {
+			return nil, err
+		}
+	}
+	return ignoreFile, nil
+}
+
+// Returns the rules which expired. Expired rules don't suppress findings.
+func (ignoreFile *IgnoreFile) ExpiredRules(now time.Time) (expired []Rule) {
+	if ignoreFile == nil {
+		return
+	}
+	for _, rule := range ignoreFile.Rules {
+		if rule.IsExpired(now) {
+			expired = append(expired, rule)
+		}
+	}
+	return
+}
+
+// Returns an error which fails the command with the same exit code as a vulnerable build, if any of the rules expired.
+func (ignoreFile *IgnoreFile) CheckExpiredRules(now time.Time) error {
+	expired := ignoreFile.ExpiredRules(now)
+	if len(expired) == 0 {
+		return nil
+	}
+	descriptions := make([]string, len(expired))
+	for i, rule := range expired {
+		descriptions[i] = fmt.Sprintf("'%s' (expired on %s)", rule, rule.Expires)
+	}
+	return coreutils.CliError{
+		ExitCode: coreutils.ExitCodeVulnerableBuild,
+		ErrorMsg: fmt.Sprintf("%d rules of the Xray ignore file %s expired: %s. Remove the rules, or extend their expiry dates", len(expired), ignoreFile.Path, strings.Join(descriptions, ", ")),
+	}
+}
+
+// Returns the error of a command which failed, after logging the expired rules, so that they are reported even if the
+// command failed, for example by failing the build. If the command succeeded, returns the error of CheckExpiredRules.
+func (ignoreFile *IgnoreFile) ReportExpiredRules(cmdErr error, now time.Time) error {
+	expiredErr := ignoreFile.CheckExpiredRules(now)
+	if cmdErr == nil {
+		return expiredErr
+	}
+	if expiredErr != nil {
+		log.Error(expiredErr.Error())
+	}
+	return cmdErr
+}
@@ -0,0 +1,175 @@
+package xrayignore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+)
+
+var projectDir = filepath.Join("testdata", "project")
+
+func readIgnoreFile(t *testing.T) *IgnoreFile {
+	ignoreFile, err := FindIgnoreFileFrom(filepath.Join(projectDir, "module"))
+	assert.NoError(t, err)
+	if assert.NotNil(t, ignoreFile) {
+		assert.True(t, strings.HasSuffix(ignoreFile.Path, filepath.Join(projectDir, ".jfrog", "xray-ignore.yaml")))
+	}
+	return ignoreFile
+}
+
+func date(t *testing.T, value string) time.Time {
+	parsed, err := time.ParseInLocation(DateFormat, value, time.Local)
+	assert.NoError(t, err)
+	return parsed
+}
+
+func TestFindIgnoreFile(t *testing.T) {
+	ignoreFile := readIgnoreFile(t)
+	assert.Len(t, ignoreFile.Rules, 3)
+	assert.NotNil(t, ignoreFile.baseline)
+
+	ignoreFile, err := FindIgnoreFileFrom(t.TempDir())
+	assert.NoError(t, err)
+	assert.Nil(t, ignoreFile)
+}
+
+func TestReadIgnoreFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"unknown field", "ignore:\n  - cves: CVE-1\n", "failed to parse"},
+		{"no cve and component", "ignore:\n  - justification: why\n", "should include a cve, a component, or both"},
+		{"no justification", "ignore:\n  - cve: CVE-1\n", "has no justification"},
+		{"invalid expiry", "ignore:\n  - cve: CVE-1\n    expires: 31/12/2024\n    justification: why\n", "isn't in the YYYY-MM-DD format"},
+		{"missing baseline", "baseline: missing.json\n", "failed to read the Xray baseline file"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "xray-ignore.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(test.content), 0644))
+			_, err := ReadIgnoreFile(path)
+			assert.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func TestExpiredRules(t *testing.T) {
+	ignoreFile := readIgnoreFile(t)
+	// A rule is applied through its expiry date.
+	assert.Empty(t, ignoreFile.ExpiredRules(date(t, "2024-06-30").Add(23*time.Hour)))
+	assert.NoError(t, ignoreFile.CheckExpiredRules(date(t, "2024-06-30")))
+
+	now := date(t, "2024-07-01")
+	assert.Equal(t, []Rule{ignoreFile.Rules[1]}, ignoreFile.ExpiredRules(now))
+	err := ignoreFile.CheckExpiredRules(now)
+	assert.ErrorContains(t, err, "'npm://lodash' (expired on 2024-06-30)")
+	assert.Equal(t, coreutils.ExitCodeVulnerableBuild, err.(coreutils.CliError).ExitCode)
+
+	var noIgnoreFile *IgnoreFile
+	assert.NoError(t, noIgnoreFile.CheckExpiredRules(now))
+
+	// The error of a failed command is returned over the expired rules, which are only logged.
+	assert.Equal(t, err, ignoreFile.ReportExpiredRules(nil, now))
+	cmdErr := errors.New("build failed")
+	assert.Equal(t, cmdErr, ignoreFile.ReportExpiredRules(cmdErr, now))
+}
+
+func createResults() []services.ScanResponse {
+	return []services.ScanResponse{{
+		Violations: []services.Violation{
+			{
+				IssueId:  "XRAY-1000",
+				Severity: "Critical",
+				Cves:     []services.Cve{{Id: "CVE-2023-1000"}},
+				Components: map[string]services.Component{
+					"generic://sha256:abc/app.tar": {ImpactPaths: [][]services.ImpactPathNode{{{ComponentId: "generic://sha256:abc/app.tar", FullPath: "usr/lib/libssl.so"}}}},
+					"generic://sha256:def/web.tar": {ImpactPaths: [][]services.ImpactPathNode{{{ComponentId: "generic://sha256:def/web.tar", FullPath: "usr/lib/libssl.so"}}}},
+				},
+				FailBuild: true,
+			},
+		},
+		Vulnerabilities: []services.Vulnerability{
+			{IssueId: "XRAY-1", Severity: "High", Cves: []services.Cve{{Id: "CVE-2023-0001"}}, Components: map[string]services.Component{"npm://express:4.17.1": {}}},
+			{IssueId: "XRAY-2", Severity: "Medium", Components: map[string]services.Component{"npm://lodash:4.17.20": {}, "npm://lodash-es:4.17.20": {}}},
+			{IssueId: "XRAY-2000", Severity: "High", Cves: []services.Cve{{Id: "CVE-2023-2000"}}, Components: map[string]services.Component{"npm://minimist:1.2.5": {}}},
+			{IssueId: "XRAY-3", Severity: "Low", Components: map[string]services.Component{"npm://debug:2.6.8": {}}},
+		},
+		Licenses: []services.License{{Key: "MIT", Components: map[string]services.Component{"npm://lodash:4.17.20": {}}}},
+	}}
+}
+
+func TestFilter(t *testing.T) {
+	ignoreFile := readIgnoreFile(t)
+	filtered, suppressed := ignoreFile.Filter(createResults(), date(t, "2024-01-01"))
+	if !assert.Len(t, filtered, 1) {
+		return
+	}
+	// Only the component which matches the path and the component pattern is suppressed.
+	assert.Len(t, filtered[0].Violations, 1)
+	assert.Equal(t, []string{"generic://sha256:def/web.tar"}, getComponentIds(filtered[0].Violations[0].Components))
+	// lodash-es isn't suppressed by the rule of lodash.
+	assert.Len(t, filtered[0].Vulnerabilities, 2)
+	assert.Equal(t, []string{"npm://lodash-es:4.17.20"}, getComponentIds(filtered[0].Vulnerabilities[0].Components))
+	assert.Equal(t, "XRAY-3", filtered[0].Vulnerabilities[1].IssueId)
+	assert.Len(t, filtered[0].Licenses, 1)
+
+	assert.Equal(t, []SuppressedFinding{
+		{Issue: "XRAY-1 (CVE-2023-0001)", Severity: "High", Component: "npm://express:4.17.1", Justification: "The vulnerable function isn't called."},
+		{Issue: "XRAY-1000 (CVE-2023-1000)", Severity: "Critical", Component: "generic://sha256:abc/app.tar", Justification: "The library isn't loaded."},
+		{Issue: "XRAY-2", Severity: "Medium", Component: "npm://lodash:4.17.20", Justification: "Lodash is replaced in the next release.", Expires: "2024-06-30"},
+		{Issue: "XRAY-2000 (CVE-2023-2000)", Severity: "High", Component: "npm://minimist:1.2.5", Justification: baselineJustification},
+	}, suppressed)
+}
+
+func TestFilterWithExpiredRule(t *testing.T) {
+	ignoreFile := readIgnoreFile(t)
+	filtered, suppressed := ignoreFile.Filter(createResults(), date(t, "2024-07-01"))
+	assert.Len(t, suppressed, 3)
+	assert.Len(t, filtered[0].Vulnerabilities, 2)
+	assert.Equal(t, []string{"npm://lodash-es:4.17.20", "npm://lodash:4.17.20"}, getComponentIds(filtered[0].Vulnerabilities[0].Components))
+}
+
+func TestFilterWithoutIgnoreFile(t *testing.T) {
+	var ignoreFile *IgnoreFile
+	results := createResults()
+	filtered, suppressed := ignoreFile.Filter(results, time.Now())
+	assert.Equal(t, results, filtered)
+	assert.Empty(t, suppressed)
+}
+
+func TestBaseline(t *testing.T) {
+	baseline := NewBaseline(createResults(), date(t, "2024-01-01"))
+	assert.Len(t, baseline.Findings, 7)
+	assert.Equal(t, Finding{IssueId: "XRAY-1000", Cves: []string{"CVE-2023-1000"}, Component: "generic://sha256:abc/app.tar", Severity: "Critical"}, baseline.Findings[0])
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	assert.NoError(t, baseline.Write(path))
+	read, err := ReadBaseline(path)
+	assert.NoError(t, err)
+	assert.Equal(t, baseline.Findings, read.Findings)
+
+	// All the findings of the baseline are suppressed.
+	filtered, suppressed := (&IgnoreFile{baseline: read}).Filter(createResults(), time.Now())
+	assert.Len(t, suppressed, 7)
+	assert.Empty(t, filtered[0].Violations)
+	assert.Empty(t, filtered[0].Vulnerabilities)
+}
+
+func getComponentIds(components map[string]services.Component) []string {
+	var ids []string
+	for id := range components {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -20,6 +20,7 @@ import (
 	scandocs "github.com/jfrog/jfrog-cli/docs/xray/scan"
 	"github.com/jfrog/jfrog-cli/scan"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/secretstore"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/urfave/cli"
 )
@@ -213,6 +214,9 @@ func newXrCurlCommand(c *cli.Context) (*curl.XrCurlCommand, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = secretstore.ResolveSecrets(xrDetails); err != nil {
+		return nil, err
+	}
 	if xrDetails.XrayUrl == "" {
 		return nil, errorutils.CheckErrorf("No Xray servers configured. Use the 'jf c add' command to set the Xray server details.")
 	}